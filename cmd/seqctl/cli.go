@@ -0,0 +1,1049 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	cli "github.com/urfave/cli/v2"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/flags"
+	"github.com/golem-base/seqctl/pkg/handover"
+	"github.com/golem-base/seqctl/pkg/log"
+	"github.com/golem-base/seqctl/pkg/membership"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/output"
+	"github.com/golem-base/seqctl/pkg/provider"
+	"github.com/golem-base/seqctl/pkg/reconcile"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+	"github.com/golem-base/seqctl/pkg/server/handlers"
+)
+
+// Exit codes of the CLI commands
+const (
+	exitError        = 1 // Unexpected failure, e.g. an RPC error
+	exitUsage        = 2 // Invalid arguments or flags
+	exitNotFound     = 3 // Network or sequencer does not exist
+	exitInvalidState = 4 // Action refused in the sequencer's current state
+	exitUnhealthy    = 5 // A network is unhealthy or violates an invariant
+	exitHandover     = 6 // Handover failed or was rolled back
+	exitDenied       = 7 // The server rejected the credentials or role
+	exitPending      = 8 // The server holds the action for a second operator's approval
+)
+
+// rpcTimeout bounds discovery, status refreshes and a single action
+const rpcTimeout = 30 * time.Second
+
+// applyTimeout bounds applying a membership plan, which makes its changes one
+// after another
+const applyTimeout = 60 * time.Second
+
+// cliCommands returns the headless commands. They act on sequencers directly,
+// or through a seqctl server when --server is set.
+func cliCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "status",
+			Usage:     "Show the status of networks and their sequencers",
+			ArgsUsage: "[network...]",
+			Flags:     flags.CLICommandFlags(),
+			Action:    runStatus,
+		},
+		{
+			Name:      "pause",
+			Usage:     "Pause a sequencer's conductor",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "pause",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.Pause(ctx, seq)
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewPause(seq)
+				},
+			}),
+		},
+		{
+			Name:      "resume",
+			Usage:     "Resume a sequencer's conductor",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "resume",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.Resume(ctx, seq)
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewResume(seq)
+				},
+			}),
+		},
+		{
+			Name:      "transfer-leader",
+			Usage:     "Transfer Raft leadership to a specific server",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.TargetID, flags.TargetAddr, flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "transfer_leader",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.TransferLeader(ctx, seq, c.String(flags.TargetID.Name), c.String(flags.TargetAddr.Name))
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewTransferLeader(seq, c.String(flags.TargetID.Name), c.String(flags.TargetAddr.Name))
+				},
+				body: transferLeaderBody,
+			}),
+		},
+		{
+			Name:      "resign-leader",
+			Usage:     "Make the current leader resign, triggering an election",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "resign_leader",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.ResignLeader(ctx, seq)
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewResignLeader(seq)
+				},
+			}),
+		},
+		{
+			Name:      "override-leader",
+			Usage:     "Force a sequencer's leader status (WARNING: can cause split-brain)",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.Override, flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "override_leader",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.OverrideLeader(ctx, seq, c.Bool(flags.Override.Name))
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewOverrideLeader(seq, c.Bool(flags.Override.Name))
+				},
+				body: overrideLeaderBody,
+			}),
+		},
+		{
+			Name:      "halt",
+			Usage:     "Stop a sequencer from producing blocks",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "halt",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.Halt(ctx, seq)
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewHalt(seq)
+				},
+			}),
+		},
+		{
+			Name:      "force-active",
+			Usage:     "Force a sequencer to start producing blocks (WARNING: use only in emergencies)",
+			ArgsUsage: "<sequencer-id>",
+			Flags:     flags.CLICommandFlags(flags.BlockHash, flags.DryRun),
+			Action: sequencerAction(sequencerOp{
+				link: "force_active",
+				run: func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error {
+					return action.ForceActive(ctx, seq, c.String(flags.BlockHash.Name))
+				},
+				preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+					return action.PreviewForceActive(seq, c.String(flags.BlockHash.Name))
+				},
+				body: forceActiveBody,
+			}),
+		},
+		{
+			Name:      "handover",
+			Usage:     "Hand leadership over to a healthy, caught-up voter",
+			ArgsUsage: "<network>",
+			Flags:     flags.CLICommandFlags(flags.HandoverTarget, flags.HandoverTimeout, flags.DryRun),
+			Action:    runHandover,
+		},
+		{
+			Name:  "approval",
+			Usage: "Review actions a seqctl server holds for a second operator's approval",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List pending and recently decided approval requests",
+					Flags:  flags.CLICommandFlags(flags.ApprovalStatus),
+					Action: runApprovals,
+				},
+				{
+					Name:      "approve",
+					Usage:     "Approve a request made by another operator and run its action",
+					ArgsUsage: "<approval-id>",
+					Flags:     flags.CLICommandFlags(),
+					Action:    decideApproval(true),
+				},
+				{
+					Name:      "reject",
+					Usage:     "Reject or withdraw a pending request",
+					ArgsUsage: "<approval-id>",
+					Flags:     flags.CLICommandFlags(),
+					Action:    decideApproval(false),
+				},
+			},
+		},
+		{
+			Name:  "membership",
+			Usage: "Change Raft cluster membership through the leader",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "add",
+					Usage:     "Add a server to the cluster",
+					ArgsUsage: "<sequencer-id>",
+					Flags:     flags.CLICommandFlags(flags.ServerID, flags.ServerAddr, flags.Voting, flags.DryRun),
+					Action: membershipAction(membershipOp{
+						link: "update_member",
+						run: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*membership.Membership, error) {
+							return action.AddMember(ctx, net, seq,
+								c.String(flags.ServerID.Name), c.String(flags.ServerAddr.Name), c.Bool(flags.Voting.Name))
+						},
+						preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+							return action.PreviewAddMember(ctx, net, seq,
+								c.String(flags.ServerID.Name), c.String(flags.ServerAddr.Name), c.Bool(flags.Voting.Name))
+						},
+						body: addMemberBody,
+					}),
+				},
+				{
+					Name:      "remove",
+					Usage:     "Remove a server from the cluster",
+					ArgsUsage: "<sequencer-id>",
+					Flags:     flags.CLICommandFlags(flags.ServerID, flags.DryRun),
+					Action: membershipAction(membershipOp{
+						link: "remove_member",
+						run: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*membership.Membership, error) {
+							return action.RemoveMember(ctx, net, seq, c.String(flags.ServerID.Name))
+						},
+						preview: func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+							return action.PreviewRemoveMember(ctx, net, seq, c.String(flags.ServerID.Name))
+						},
+						body: removeMemberBody,
+					}),
+				},
+				{
+					Name:      "promote",
+					Usage:     "Make a non-voting member a voter",
+					ArgsUsage: "<sequencer-id>",
+					Flags:     flags.CLICommandFlags(flags.DryRun),
+					Action:    suffrageAction(true),
+				},
+				{
+					Name:      "demote",
+					Usage:     "Make a voting member a non-voter",
+					ArgsUsage: "<sequencer-id>",
+					Flags:     flags.CLICommandFlags(flags.DryRun),
+					Action:    suffrageAction(false),
+				},
+				{
+					Name:      "plan",
+					Usage:     "Show the changes that bring the membership in line with the discovered suffrage",
+					ArgsUsage: "<network>",
+					Flags:     flags.CLICommandFlags(),
+					Action:    runMembershipPlan,
+				},
+				{
+					Name:      "apply",
+					Usage:     "Apply the membership plan (dry run unless --dry-run=false)",
+					ArgsUsage: "<network>",
+					Flags:     flags.CLICommandFlags(flags.PlanDryRun, flags.PlanFile),
+					Action:    runMembershipApply,
+				},
+			},
+		},
+	}
+}
+
+// sequencerOp is an action on a single sequencer
+type sequencerOp struct {
+	// link names the action's entry in the _links of a SequencerResponse,
+	// which the server only offers when the action is currently allowed
+	link string
+	// run performs the action directly against the sequencer
+	run func(ctx context.Context, c *cli.Context, seq *sequencer.Sequencer) error
+	// preview checks and describes the action for --dry-run
+	preview previewFunc
+	// body returns the request body in remote mode, if the action takes one
+	body func(c *cli.Context) any
+}
+
+// membershipOp is a Raft membership change made through a single sequencer
+type membershipOp struct {
+	// link names the action's entry in the _links of a SequencerResponse
+	link string
+	// run performs the change directly and returns the new membership
+	run func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*membership.Membership, error)
+	// preview checks and describes the change for --dry-run
+	preview previewFunc
+	// body returns the request body in remote mode
+	body func(c *cli.Context) any
+}
+
+// previewFunc checks an action on a sequencer and describes the RPCs it
+// would send
+type previewFunc func(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error)
+
+// runStatus prints the status of the requested networks, or all of them. It
+// exits with exitUnhealthy if any of them is unhealthy.
+func runStatus(c *cli.Context) error {
+	format, err := outputFormat(c)
+	if err != nil {
+		return err
+	}
+
+	var view statusView
+	if remoteMode(c) {
+		view, err = remoteStatus(c, c.Args().Slice())
+	} else {
+		view, err = localStatus(c, c.Args().Slice())
+	}
+	if err != nil {
+		return err
+	}
+
+	var unhealthy []string
+	for _, net := range view {
+		if format == output.FormatTable {
+			for _, v := range net.Violations {
+				slog.Warn("Invariant violated", "network", net.Name, "kind", v.Kind,
+					"severity", v.Severity, "message", v.Message)
+			}
+		}
+		if !net.Healthy {
+			unhealthy = append(unhealthy, net.Name)
+		}
+	}
+
+	if err := output.Write(os.Stdout, format, view); err != nil {
+		return err
+	}
+
+	if len(unhealthy) > 0 {
+		return cli.Exit(fmt.Sprintf("unhealthy networks: %s", strings.Join(unhealthy, ", ")), exitUnhealthy)
+	}
+	return nil
+}
+
+// sequencerAction returns the action of a command that operates on the
+// sequencer given as its only argument and prints its status afterwards
+func sequencerAction(op sequencerOp) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.Exit("expected exactly one sequencer ID", exitUsage)
+		}
+
+		format, err := outputFormat(c)
+		if err != nil {
+			return err
+		}
+
+		if c.Bool(flags.DryRun.Name) {
+			return previewSequencerAction(c, format, op.link, op.body, op.preview)
+		}
+
+		var view sequencerView
+		if remoteMode(c) {
+			var pending *approvalView
+			view, pending, err = remoteSequencerAction(c, c.Args().First(), op)
+			if err == nil && pending != nil {
+				return writePending(format, *pending)
+			}
+		} else {
+			view, err = localSequencerAction(c, c.Args().First(), op)
+		}
+		if err != nil {
+			return err
+		}
+
+		return output.Write(os.Stdout, format, view)
+	}
+}
+
+// membershipAction returns the action of a command that changes the Raft
+// membership through the sequencer given as its only argument and prints the
+// new membership afterwards
+func membershipAction(op membershipOp) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.Exit("expected exactly one sequencer ID", exitUsage)
+		}
+
+		format, err := outputFormat(c)
+		if err != nil {
+			return err
+		}
+
+		if c.Bool(flags.DryRun.Name) {
+			return previewSequencerAction(c, format, op.link, op.body, op.preview)
+		}
+
+		var view membershipView
+		if remoteMode(c) {
+			view, err = remoteMembershipAction(c, c.Args().First(), op)
+		} else {
+			view, err = localMembershipAction(c, c.Args().First(), op)
+		}
+		if err != nil {
+			return err
+		}
+
+		return output.Write(os.Stdout, format, view)
+	}
+}
+
+// suffrageAction returns the action of a command that promotes or demotes
+// the sequencer given as its only argument and prints the new membership
+func suffrageAction(voter bool) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.Exit("expected exactly one sequencer ID", exitUsage)
+		}
+
+		format, err := outputFormat(c)
+		if err != nil {
+			return err
+		}
+
+		if c.Bool(flags.DryRun.Name) {
+			link, preview := "demote", previewFunc(demotePreview)
+			if voter {
+				link, preview = "promote", promotePreview
+			}
+			return previewSequencerAction(c, format, link, nil, preview)
+		}
+
+		var view suffrageView
+		if remoteMode(c) {
+			view, err = remoteSuffrageAction(c, c.Args().First(), voter)
+		} else {
+			view, err = localSuffrageAction(c, c.Args().First(), voter)
+		}
+		if err != nil {
+			return err
+		}
+
+		if view.VotingError != "" {
+			slog.Warn("Failed to record the new suffrage with the provider", "error", view.VotingError)
+		}
+		return output.Write(os.Stdout, format, view)
+	}
+}
+
+// runHandover performs a guided handover on the network given as the only
+// argument
+func runHandover(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit("expected exactly one network", exitUsage)
+	}
+
+	format, err := outputFormat(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool(flags.DryRun.Name) {
+		var view previewView
+		if remoteMode(c) {
+			view, err = remoteHandoverPreview(c, c.Args().First())
+		} else {
+			view, err = localHandoverPreview(c, c.Args().First())
+		}
+		if err != nil {
+			return err
+		}
+		return writePreview(format, view)
+	}
+
+	var view handoverView
+	if remoteMode(c) {
+		view, err = remoteHandover(c, c.Args().First())
+	} else {
+		view, err = localHandover(c, c.Args().First())
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := output.Write(os.Stdout, format, view); err != nil {
+		return err
+	}
+
+	if !view.Success {
+		return cli.Exit(fmt.Sprintf("handover failed: %s", view.Error), exitHandover)
+	}
+	return nil
+}
+
+// previewSequencerAction prints what the action on the sequencer given as the
+// only argument would do. In remote mode the server checks it by following
+// the action's link with dry_run=true.
+func previewSequencerAction(c *cli.Context, format output.Format, link string, body func(c *cli.Context) any, preview previewFunc) error {
+	var (
+		view previewView
+		err  error
+	)
+	if remoteMode(c) {
+		view, err = remotePreview(c, c.Args().First(), link, body)
+	} else {
+		view, err = localPreview(c, c.Args().First(), preview)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writePreview(format, view)
+}
+
+// writePreview prints the RPCs of a dry run. The table format has no room
+// for the expected effect, which is logged instead.
+func writePreview(format output.Format, view previewView) error {
+	if format == output.FormatTable {
+		slog.Info("Dry run, nothing was sent", "effect", view.Effect)
+	}
+	return output.Write(os.Stdout, format, view)
+}
+
+// writePending prints an action the server holds for approval and exits
+// with exitPending, as the action has not run yet
+func writePending(format output.Format, view approvalView) error {
+	if format == output.FormatTable {
+		slog.Info("Action held for approval by another operator", "effect", view.Effect)
+	}
+	if err := output.Write(os.Stdout, format, view); err != nil {
+		return err
+	}
+	return cli.Exit(fmt.Sprintf("%s awaits approval until %s: seqctl approval approve %s",
+		view.Action, view.ExpiresAt.Format(time.RFC3339), view.ID), exitPending)
+}
+
+// runApprovals lists the approval requests on the server
+func runApprovals(c *cli.Context) error {
+	format, err := outputFormat(c)
+	if err != nil {
+		return err
+	}
+	if !remoteMode(c) {
+		return cli.Exit("approval requests are kept by a seqctl server, set --server", exitUsage)
+	}
+
+	view, err := remoteApprovals(c, c.String(flags.ApprovalStatus.Name))
+	if err != nil {
+		return err
+	}
+	return output.Write(os.Stdout, format, view)
+}
+
+// decideApproval returns the action of a command that approves or rejects
+// the approval request given as its only argument
+func decideApproval(approve bool) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.Exit("expected exactly one approval request ID", exitUsage)
+		}
+
+		format, err := outputFormat(c)
+		if err != nil {
+			return err
+		}
+		if !remoteMode(c) {
+			return cli.Exit("approval requests are kept by a seqctl server, set --server", exitUsage)
+		}
+
+		view, err := remoteDecideApproval(c, c.Args().First(), approve)
+		if err != nil {
+			return err
+		}
+		return output.Write(os.Stdout, format, view)
+	}
+}
+
+func promotePreview(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+	return action.PreviewPromoteMember(ctx, net, seq)
+}
+
+func demotePreview(ctx context.Context, c *cli.Context, net *network.Network, seq *sequencer.Sequencer) (*action.Preview, error) {
+	return action.PreviewDemoteMember(ctx, net, seq)
+}
+
+// runMembershipPlan prints the membership changes the network given as the
+// only argument needs
+func runMembershipPlan(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit("expected exactly one network", exitUsage)
+	}
+
+	format, err := outputFormat(c)
+	if err != nil {
+		return err
+	}
+
+	var view planView
+	if remoteMode(c) {
+		view, err = remoteMembershipPlan(c, c.Args().First())
+	} else {
+		view, err = localMembershipPlan(c, c.Args().First())
+	}
+	if err != nil {
+		return err
+	}
+
+	return output.Write(os.Stdout, format, view)
+}
+
+// runMembershipApply plans the membership changes of the network given as the
+// only argument and applies them unless --dry-run is set. It exits with
+// exitError if a change failed, after printing how far the plan got.
+func runMembershipApply(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cli.Exit("expected exactly one network", exitUsage)
+	}
+
+	format, err := outputFormat(c)
+	if err != nil {
+		return err
+	}
+
+	var view applyView
+	if remoteMode(c) {
+		view, err = remoteMembershipApply(c, c.Args().First())
+	} else {
+		view, err = localMembershipApply(c, c.Args().First())
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := output.Write(os.Stdout, format, view); err != nil {
+		return err
+	}
+
+	if view.Error != "" {
+		return cli.Exit(fmt.Sprintf("applying membership plan failed: %s", view.Error), exitError)
+	}
+	return nil
+}
+
+// localStatus refreshes and returns the status of the named networks, or all
+// of them
+func localStatus(c *cli.Context, names []string) (statusView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if _, ok := networks[name]; !ok {
+			return nil, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+		}
+	}
+	if len(names) == 0 {
+		for name := range networks {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	view := make(statusView, 0, len(names))
+	for _, name := range names {
+		net := networks[name]
+		if err := net.Update(ctx); err != nil {
+			slog.Warn("Failed to update network status", "network", name, "error", err)
+		}
+		view = append(view, newNetworkView(net))
+	}
+	return view, nil
+}
+
+// localSequencerAction runs op directly against the sequencer. Its status is
+// refreshed first so that the action's state checks see current values.
+func localSequencerAction(c *cli.Context, id string, op sequencerOp) (sequencerView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return sequencerView{}, err
+	}
+
+	net, seq := findSequencer(networks, id)
+	if seq == nil {
+		return sequencerView{}, cli.Exit(fmt.Sprintf("sequencer %q not found", id), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	if err := seq.Update(ctx); err != nil {
+		return sequencerView{}, cli.Exit(fmt.Sprintf("failed to fetch status of sequencer %q: %v", id, err), exitError)
+	}
+
+	if err := op.run(ctx, c, seq); err != nil {
+		return sequencerView{}, actionError(err)
+	}
+
+	if err := seq.Update(ctx); err != nil {
+		slog.Warn("Failed to refresh sequencer status", "sequencer", id, "error", err)
+	}
+
+	return newSequencerView(seq, net, time.Now()), nil
+}
+
+// localMembershipAction runs op directly against the sequencer. The whole
+// network is refreshed first, as the quorum checks depend on which members
+// are reachable.
+func localMembershipAction(c *cli.Context, id string, op membershipOp) (membershipView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return membershipView{}, err
+	}
+
+	net, seq := findSequencer(networks, id)
+	if seq == nil {
+		return membershipView{}, cli.Exit(fmt.Sprintf("sequencer %q not found", id), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	if err := net.Update(ctx); err != nil {
+		slog.Warn("Failed to update network status", "network", net.Name(), "error", err)
+	}
+
+	m, err := op.run(ctx, c, net, seq)
+	if err != nil {
+		return membershipView{}, actionError(err)
+	}
+	return newMembershipView(m), nil
+}
+
+// localSuffrageAction promotes or demotes the sequencer through its network's
+// conductor leader and records the new suffrage with the provider
+func localSuffrageAction(c *cli.Context, id string, voter bool) (suffrageView, error) {
+	appProvider, err := newProvider(c)
+	if err != nil {
+		return suffrageView{}, err
+	}
+	networks, err := discoverWith(c, appProvider)
+	if err != nil {
+		return suffrageView{}, err
+	}
+
+	net, seq := findSequencer(networks, id)
+	if seq == nil {
+		return suffrageView{}, cli.Exit(fmt.Sprintf("sequencer %q not found", id), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	if err := net.Update(ctx); err != nil {
+		slog.Warn("Failed to update network status", "network", net.Name(), "error", err)
+	}
+
+	change := action.DemoteMember
+	if voter {
+		change = action.PromoteMember
+	}
+	m, err := change(ctx, net, seq)
+	if err != nil {
+		return suffrageView{}, actionError(err)
+	}
+
+	view := suffrageView{Membership: newMembershipView(m)}
+	view.Voting, err = provider.RecordVoting(ctx, appProvider, seq, voter)
+	if err != nil {
+		view.Voting, view.VotingError = provider.VotingFailed, err.Error()
+	}
+	return view, nil
+}
+
+// localPreview checks the action against the sequencer and describes it.
+// Like the actions, the whole network is refreshed first.
+func localPreview(c *cli.Context, id string, preview previewFunc) (previewView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	net, seq := findSequencer(networks, id)
+	if seq == nil {
+		return previewView{}, cli.Exit(fmt.Sprintf("sequencer %q not found", id), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	if err := net.Update(ctx); err != nil {
+		slog.Warn("Failed to update network status", "network", net.Name(), "error", err)
+	}
+
+	p, err := preview(ctx, c, net, seq)
+	if err != nil {
+		return previewView{}, actionError(err)
+	}
+	return newPreviewView(p), nil
+}
+
+// localHandoverPreview selects the handover target and describes the
+// transfer
+func localHandoverPreview(c *cli.Context, name string) (previewView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	net, ok := networks[name]
+	if !ok {
+		return previewView{}, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	p, err := handover.Preview(ctx, net, handover.Options{
+		TargetID: c.String(flags.HandoverTarget.Name),
+		Timeout:  c.Duration(flags.HandoverTimeout.Name),
+	})
+	switch {
+	case errors.Is(err, handover.ErrNoLeader):
+		return previewView{}, cli.Exit(err.Error(), exitInvalidState)
+	case err != nil:
+		return previewView{}, cli.Exit(err.Error(), exitUsage)
+	}
+	return newPreviewView(p), nil
+}
+
+// localMembershipPlan plans the membership changes of the network directly
+func localMembershipPlan(c *cli.Context, name string) (planView, error) {
+	net, err := localNetwork(c, name)
+	if err != nil {
+		return planView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	plan, err := reconcile.NewPlan(ctx, net)
+	if err != nil {
+		return planView{}, cli.Exit(err.Error(), exitError)
+	}
+	return newPlanView(plan), nil
+}
+
+// localMembershipApply plans the membership changes of the network and
+// applies them directly through its conductor leader unless --dry-run is set
+func localMembershipApply(c *cli.Context, name string) (applyView, error) {
+	net, err := localNetwork(c, name)
+	if err != nil {
+		return applyView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, applyTimeout)
+	defer cancel()
+
+	reviewed, err := reviewedPlan(c, name)
+	if err != nil {
+		return applyView{}, err
+	}
+
+	plan, err := reconcile.NewPlan(ctx, net)
+	if err != nil {
+		return applyView{}, cli.Exit(err.Error(), exitError)
+	}
+
+	dryRun := c.Bool(flags.PlanDryRun.Name)
+	switch {
+	case !dryRun:
+		err = plan.Check(reviewed.Version, reviewed.ReviewedSteps())
+	case reviewed.Version != 0 && reviewed.Version != plan.Version:
+		err = fmt.Errorf("%w: reviewed at version %d, now at %d", reconcile.ErrOutdated, reviewed.Version, plan.Version)
+	}
+	if err != nil {
+		return applyView{}, cli.Exit(err.Error(), exitInvalidState)
+	}
+
+	if dryRun || plan.InSync() {
+		return applyView{DryRun: dryRun, Plan: newPlanView(plan)}, nil
+	}
+
+	m, err := reconcile.Apply(ctx, net, plan)
+	switch {
+	case errors.Is(err, reconcile.ErrNoLeader), errors.Is(err, reconcile.ErrOutdated):
+		return applyView{}, cli.Exit(err.Error(), exitInvalidState)
+	case err != nil:
+		return applyView{Plan: newPlanView(plan), Error: err.Error()}, nil
+	}
+
+	members := newMembershipView(m)
+	return applyView{Plan: newPlanView(plan), Membership: &members}, nil
+}
+
+// reviewedPlan reads the plan given with --plan for the named network and
+// returns the request that applies it. Applying requires one, dry runs check
+// its version only.
+func reviewedPlan(c *cli.Context, name string) (handlers.ApplyPlanRequest, error) {
+	path := c.String(flags.PlanFile.Name)
+	if path == "" {
+		if !c.Bool(flags.PlanDryRun.Name) {
+			return handlers.ApplyPlanRequest{}, cli.Exit(
+				"--plan is required with --dry-run=false, write it with membership plan -o json", exitUsage)
+		}
+		return handlers.ApplyPlanRequest{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return handlers.ApplyPlanRequest{}, cli.Exit(fmt.Sprintf("failed to read plan: %v", err), exitUsage)
+	}
+
+	var plan handlers.MembershipPlanResponse
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return handlers.ApplyPlanRequest{}, cli.Exit(fmt.Sprintf("failed to parse plan %s: %v", path, err), exitUsage)
+	}
+	switch {
+	case plan.Network != name:
+		return handlers.ApplyPlanRequest{}, cli.Exit(fmt.Sprintf("plan %s is for network %q, not %q", path, plan.Network, name), exitUsage)
+	case plan.Version == 0:
+		return handlers.ApplyPlanRequest{}, cli.Exit(fmt.Sprintf("plan %s has no membership version", path), exitUsage)
+	}
+	return handlers.NewApplyPlanRequest(plan), nil
+}
+
+// localNetwork discovers the named network and refreshes its status, as the
+// plan and the checks of each change depend on which members are reachable
+func localNetwork(c *cli.Context, name string) (*network.Network, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return nil, err
+	}
+
+	net, ok := networks[name]
+	if !ok {
+		return nil, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	if err := net.Update(ctx); err != nil {
+		slog.Warn("Failed to update network status", "network", name, "error", err)
+	}
+	return net, nil
+}
+
+// localHandover runs a guided handover directly against the network
+func localHandover(c *cli.Context, name string) (handoverView, error) {
+	networks, err := discover(c)
+	if err != nil {
+		return handoverView{}, err
+	}
+
+	net, ok := networks[name]
+	if !ok {
+		return handoverView{}, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+	}
+
+	result, err := handover.Run(c.Context, net, handover.Options{
+		TargetID: c.String(flags.HandoverTarget.Name),
+		Timeout:  c.Duration(flags.HandoverTimeout.Name),
+	})
+
+	switch {
+	case errors.Is(err, handover.ErrNoLeader):
+		return handoverView{}, cli.Exit(err.Error(), exitInvalidState)
+	case errors.Is(err, handover.ErrInvalidTarget), errors.Is(err, handover.ErrNoCandidate):
+		return handoverView{}, cli.Exit(err.Error(), exitUsage)
+	case result == nil:
+		return handoverView{}, cli.Exit(err.Error(), exitError)
+	}
+
+	return newHandoverView(result, err), nil
+}
+
+// outputFormat returns the format selected with --output
+func outputFormat(c *cli.Context) (output.Format, error) {
+	format, err := output.ParseFormat(c.String(flags.OutputFormat.Name))
+	if err != nil {
+		return "", cli.Exit(err.Error(), exitUsage)
+	}
+	return format, nil
+}
+
+// discover loads the configuration, initializes logging and discovers
+// networks through the configured provider
+func discover(c *cli.Context) (map[string]*network.Network, error) {
+	appProvider, err := newProvider(c)
+	if err != nil {
+		return nil, err
+	}
+	return discoverWith(c, appProvider)
+}
+
+// newProvider loads the configuration, initializes logging and creates the
+// configured provider
+func newProvider(c *cli.Context) (provider.Provider, error) {
+	cfg, err := config.LoadConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := log.Init(cfg.Log.Level, cfg.Log.Format, cfg.Log.NoColor, cfg.Log.FilePath); err != nil {
+		return nil, fmt.Errorf("failed to initialize logging: %w", err)
+	}
+
+	appProvider, err := provider.NewProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	return appProvider, nil
+}
+
+// discoverWith discovers networks through the given provider
+func discoverWith(c *cli.Context, appProvider provider.Provider) (map[string]*network.Network, error) {
+	ctx, cancel := context.WithTimeout(c.Context, rpcTimeout)
+	defer cancel()
+
+	networks, err := appProvider.DiscoverNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover networks: %w", err)
+	}
+	return networks, nil
+}
+
+// findSequencer returns the sequencer with the given ID and its network, or
+// nils if there is none
+func findSequencer(networks map[string]*network.Network, id string) (*network.Network, *sequencer.Sequencer) {
+	for _, net := range networks {
+		if seq := net.SequencerByID(id); seq != nil {
+			return net, seq
+		}
+	}
+	return nil, nil
+}
+
+// actionError maps an action error to the matching exit code
+func actionError(err error) error {
+	switch {
+	case errors.Is(err, action.ErrInvalidState):
+		return cli.Exit(err.Error(), exitInvalidState)
+	case errors.Is(err, action.ErrInvalidArgument):
+		return cli.Exit(err.Error(), exitUsage)
+	default:
+		return cli.Exit(fmt.Sprintf("operation failed: %v", err), exitError)
+	}
+}
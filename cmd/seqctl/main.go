@@ -10,11 +10,18 @@ import (
 	"time"
 
 	cli "github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/golem-base/seqctl/pkg/alert"
 	gbapp "github.com/golem-base/seqctl/pkg/app"
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/auth"
 	"github.com/golem-base/seqctl/pkg/config"
 	"github.com/golem-base/seqctl/pkg/flags"
+	"github.com/golem-base/seqctl/pkg/history"
 	"github.com/golem-base/seqctl/pkg/log"
+	"github.com/golem-base/seqctl/pkg/network"
 	"github.com/golem-base/seqctl/pkg/provider"
 	"github.com/golem-base/seqctl/pkg/repository"
 	"github.com/golem-base/seqctl/pkg/server"
@@ -48,14 +55,20 @@ func main() {
 	cliapp.Name = "seqctl"
 	cliapp.Usage = "Control panel for managing op-conductor sequencer clusters"
 	cliapp.Version = version.Info()
-	cliapp.Commands = []*cli.Command{
+	cliapp.Commands = append([]*cli.Command{
 		{
 			Name:   "serve",
 			Usage:  "Launch Server",
 			Flags:  flags.ServeCommandFlags(),
 			Action: runServe,
 		},
-	}
+		{
+			Name:   "tui",
+			Usage:  "Launch the interactive terminal UI",
+			Flags:  flags.TUICommandFlags(),
+			Action: runTUI,
+		},
+	}, cliCommands()...)
 
 	// Run the application with the context
 	if err := cliapp.RunContext(ctx, os.Args); err != nil {
@@ -81,35 +94,167 @@ func runServe(c *cli.Context) error {
 		return fmt.Errorf("failed to initialize logging: %w", err)
 	}
 
-	// Create provider using factory
-	appProvider, err := provider.NewProvider(cfg)
+	// Create repository with caching
+	repo, err := newRepository(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create provider: %w", err)
+		return err
 	}
 
-	// Parse cache TTL durations
-	discoveryTTL, err := time.ParseDuration(cfg.Cache.DiscoveryTTL)
+	// Initialize app with repository
+	app := gbapp.New(cfg, repo)
+
+	// Create API authenticator
+	authenticator, err := auth.New(c.Context, cfg.Auth)
 	if err != nil {
-		return fmt.Errorf("invalid cache discovery TTL '%s': %w", cfg.Cache.DiscoveryTTL, err)
+		return fmt.Errorf("failed to create authenticator: %w", err)
 	}
 
-	statusTTL, err := time.ParseDuration(cfg.Cache.StatusTTL)
-	if err != nil {
-		return fmt.Errorf("invalid cache status TTL '%s': %w", cfg.Cache.StatusTTL, err)
+	// Open the audit log, if configured
+	var auditLog *audit.Store
+	if cfg.Audit.Path != "" {
+		auditLog, err = audit.Open(cfg.Audit.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer auditLog.Close()
+	} else {
+		slog.Warn("Audit log is disabled, control actions are not recorded")
 	}
 
-	// Create repository with caching
-	repo := repository.NewCachedNetworkRepository(appProvider, discoveryTTL, statusTTL)
+	// Hold dangerous actions for a second operator, if configured
+	var approvals *approval.Store
+	if cfg.Approval.Enabled {
+		approvals, err = newApprovals(cfg)
+		if err != nil {
+			return err
+		}
+	}
 
-	// Initialize app with repository
-	app := gbapp.New(cfg, repo)
+	// Open the status history, if configured, and record every change
+	var (
+		historyStore *history.Store
+		recorder     *history.Recorder
+	)
+	if cfg.History.Path != "" {
+		recorder, historyStore, err = newHistoryRecorder(cfg)
+		if err != nil {
+			return err
+		}
+		defer historyStore.Close()
+		app.Subscribe(recorder.HandleChanges)
+	}
+
+	// Evaluate alerting rules, if configured, against the polled state
+	var alerts *alert.Manager
+	if len(cfg.Alerting.Rules) > 0 {
+		alerts, err = alert.New(cfg.Alerting, app)
+		if err != nil {
+			return fmt.Errorf("failed to configure alerting: %w", err)
+		}
+		app.SubscribeEvents(alerts.HandleEvents)
+	}
 
 	// Create server
 	serverCfg := server.DefaultConfig()
 	serverCfg.Address = cfg.Server.Address
 	serverCfg.Port = cfg.Server.Port
-	server := server.NewServer(serverCfg, app)
+	server := server.NewServer(serverCfg, app, authenticator, auditLog, historyStore, alerts, approvals)
+
+	// Run the background poller alongside the server, stopping both when
+	// either fails or the context is cancelled
+	g, ctx := errgroup.WithContext(c.Context)
+	g.Go(func() error {
+		return repo.Run(ctx)
+	})
+	if recorder != nil {
+		g.Go(func() error {
+			return recorder.Run(ctx)
+		})
+	}
+	if alerts != nil {
+		g.Go(func() error {
+			return alerts.Run(ctx)
+		})
+	}
+	g.Go(func() error {
+		return server.Start(ctx)
+	})
+
+	return g.Wait()
+}
+
+// newApprovals creates the store of actions awaiting approval. Approval needs
+// authentication, anonymous callers could approve their own requests.
+func newApprovals(cfg *config.Config) (*approval.Store, error) {
+	if !cfg.Auth.Enabled {
+		return nil, fmt.Errorf("approval requires authentication to be enabled")
+	}
+
+	ttl, err := time.ParseDuration(cfg.Approval.TTL)
+	if err != nil || ttl <= 0 {
+		return nil, fmt.Errorf("invalid approval TTL '%s': must be a positive duration", cfg.Approval.TTL)
+	}
+
+	return approval.New(ttl), nil
+}
 
-	// Run server
-	return server.Start(c.Context)
+// newHistoryRecorder opens the history database and creates a recorder with
+// the configured retention
+func newHistoryRecorder(cfg *config.Config) (*history.Recorder, *history.Store, error) {
+	retention, err := time.ParseDuration(cfg.History.Retention)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid history retention '%s': %w", cfg.History.Retention, err)
+	}
+
+	snapshotInterval, err := time.ParseDuration(cfg.History.SnapshotInterval)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid history snapshot interval '%s': %w", cfg.History.SnapshotInterval, err)
+	}
+
+	store, err := history.Open(cfg.History.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	return history.NewRecorder(store, retention, snapshotInterval), store, nil
+}
+
+// newRepository creates the caching network repository on top of the
+// configured provider
+func newRepository(cfg *config.Config) (*repository.CachedNetworkRepository, error) {
+	// Create provider using factory
+	appProvider, err := provider.NewProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+
+	// Parse cache TTL durations
+	discoveryTTL, err := time.ParseDuration(cfg.Cache.DiscoveryTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache discovery TTL '%s': %w", cfg.Cache.DiscoveryTTL, err)
+	}
+	if discoveryTTL <= 0 {
+		return nil, fmt.Errorf("invalid cache discovery TTL '%s': must be positive", cfg.Cache.DiscoveryTTL)
+	}
+
+	statusTTL, err := time.ParseDuration(cfg.Cache.StatusTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache status TTL '%s': %w", cfg.Cache.StatusTTL, err)
+	}
+	if statusTTL <= 0 {
+		return nil, fmt.Errorf("invalid cache status TTL '%s': must be positive", cfg.Cache.StatusTTL)
+	}
+
+	if cfg.Health.StallBlocks < 1 {
+		return nil, fmt.Errorf("invalid health stall blocks %d: must be at least 1", cfg.Health.StallBlocks)
+	}
+	if cfg.Health.MaxFollowerLag < 1 {
+		return nil, fmt.Errorf("invalid health max follower lag %d: must be at least 1", cfg.Health.MaxFollowerLag)
+	}
+
+	head := network.HeadConfig{
+		StallBlocks:    cfg.Health.StallBlocks,
+		MaxFollowerLag: uint64(cfg.Health.MaxFollowerLag),
+	}
+	return repository.NewCachedNetworkRepository(appProvider, discoveryTTL, statusTTL, head), nil
 }
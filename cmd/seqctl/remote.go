@@ -0,0 +1,539 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	cli "github.com/urfave/cli/v2"
+
+	"github.com/golem-base/seqctl/pkg/client"
+	"github.com/golem-base/seqctl/pkg/flags"
+	"github.com/golem-base/seqctl/pkg/server/handlers"
+)
+
+// remoteMode reports whether commands go through a seqctl server instead of
+// talking to the sequencers directly
+func remoteMode(c *cli.Context) bool {
+	return c.String(flags.Server.Name) != ""
+}
+
+// newClient creates a client for the server given with --server
+func newClient(c *cli.Context) (*client.Client, error) {
+	cl, err := client.New(c.String(flags.Server.Name), c.String(flags.Token.Name))
+	if err != nil {
+		return nil, cli.Exit(err.Error(), exitUsage)
+	}
+	return cl, nil
+}
+
+// remoteStatus returns the status of the named networks, or all networks
+// visible to the caller, as reported by the server
+func remoteStatus(c *cli.Context, names []string) (statusView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := cl.ListNetworks(c.Context)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	byName := make(map[string]handlers.NetworkResponse, len(networks))
+	for _, net := range networks {
+		byName[net.Name] = net
+	}
+
+	for _, name := range names {
+		if _, ok := byName[name]; !ok {
+			return nil, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+		}
+	}
+	if len(names) == 0 {
+		for name := range byName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	view := make(statusView, 0, len(names))
+	for _, name := range names {
+		view = append(view, networkViewFromResponse(byName[name]))
+	}
+	return view, nil
+}
+
+// remoteSequencerAction asks the server to run op by following the matching
+// link of the sequencer. A missing link means the server does not allow the
+// action in the sequencer's current state. If the server holds the action for
+// a second operator's approval, the pending request is returned instead of
+// the sequencer.
+func remoteSequencerAction(c *cli.Context, id string, op sequencerOp) (sequencerView, *approvalView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return sequencerView{}, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	seq, err := findRemoteSequencer(ctx, cl, id)
+	if err != nil {
+		return sequencerView{}, nil, err
+	}
+
+	link := sequencerLink(seq.Links, op.link)
+	if link == nil {
+		return sequencerView{}, nil, cli.Exit(fmt.Sprintf("%s is not available for sequencer %q in its current state",
+			c.Command.Name, id), exitInvalidState)
+	}
+
+	var body any
+	if op.body != nil {
+		body = op.body(c)
+	}
+
+	var resp struct {
+		handlers.SequencerResponse
+		Approval *handlers.ApprovalResponse `json:"approval"`
+	}
+	if err := cl.Follow(ctx, *link, body, &resp); err != nil {
+		return sequencerView{}, nil, apiError(err)
+	}
+	if resp.Approval != nil {
+		pending := approvalViewFromResponse(*resp.Approval)
+		return sequencerView{}, &pending, nil
+	}
+	updated := resp.SequencerResponse
+
+	// Not every action returns the sequencer, fetch it through its network
+	if updated.ID == "" {
+		var net handlers.NetworkResponse
+		if err := cl.Follow(ctx, seq.Links.Network, nil, &net); err != nil {
+			return sequencerView{}, nil, apiError(err)
+		}
+		for _, s := range net.Sequencers {
+			if s.ID == id {
+				updated = s
+			}
+		}
+	}
+
+	return sequencerViewFromResponse(updated), nil, nil
+}
+
+// remoteMembershipAction asks the server to run op by following the matching
+// link of the sequencer
+func remoteMembershipAction(c *cli.Context, id string, op membershipOp) (membershipView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return membershipView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	seq, err := findRemoteSequencer(ctx, cl, id)
+	if err != nil {
+		return membershipView{}, err
+	}
+
+	link := sequencerLink(seq.Links, op.link)
+	if link == nil {
+		return membershipView{}, cli.Exit(fmt.Sprintf("%s is not available for sequencer %q in its current state",
+			c.Command.Name, id), exitInvalidState)
+	}
+
+	var resp handlers.MembershipResponse
+	if err := cl.Follow(ctx, *link, op.body(c), &resp); err != nil {
+		return membershipView{}, apiError(err)
+	}
+	return membershipViewFromResponse(resp), nil
+}
+
+// remoteSuffrageAction asks the server to promote or demote the sequencer by
+// following the matching link
+func remoteSuffrageAction(c *cli.Context, id string, voter bool) (suffrageView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return suffrageView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	seq, err := findRemoteSequencer(ctx, cl, id)
+	if err != nil {
+		return suffrageView{}, err
+	}
+
+	link := seq.Links.Demote
+	if voter {
+		link = seq.Links.Promote
+	}
+	if link == nil {
+		return suffrageView{}, cli.Exit(fmt.Sprintf("%s is not available for sequencer %q in its current state",
+			c.Command.Name, id), exitInvalidState)
+	}
+
+	var resp handlers.SuffrageResponse
+	if err := cl.Follow(ctx, *link, nil, &resp); err != nil {
+		return suffrageView{}, apiError(err)
+	}
+	return suffrageView{
+		Membership:  membershipViewFromResponse(resp.Membership),
+		Voting:      resp.Voting,
+		VotingError: resp.VotingError,
+	}, nil
+}
+
+// remoteHandover asks the server to run a guided handover on the network
+func remoteHandover(c *cli.Context, name string) (handoverView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return handoverView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	net, err := findRemoteNetwork(ctx, cl, name)
+	if err != nil {
+		return handoverView{}, err
+	}
+
+	req := handlers.HandoverRequest{
+		TargetID:       c.String(flags.HandoverTarget.Name),
+		TimeoutSeconds: int(c.Duration(flags.HandoverTimeout.Name).Seconds()),
+	}
+
+	var resp handlers.HandoverResponse
+	err = cl.Follow(ctx, net.Links.Handover, req, &resp)
+
+	// A failed or rolled back handover still reports its steps
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && json.Unmarshal(apiErr.Body, &resp) == nil && resp.Steps != nil {
+		err = nil
+	}
+	if err != nil {
+		return handoverView{}, apiError(err)
+	}
+
+	return handoverViewFromResponse(resp), nil
+}
+
+// remoteApprovals returns the approval requests the caller may view, only
+// those with the given status if it is not empty
+func remoteApprovals(c *cli.Context, status string) (approvalsView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return approvalsView{}, err
+	}
+
+	approvals, err := cl.ListApprovals(c.Context, status)
+	if err != nil {
+		return approvalsView{}, apiError(err)
+	}
+
+	view := approvalsView{Approvals: make([]approvalView, 0, len(approvals))}
+	for _, resp := range approvals {
+		view.Approvals = append(view.Approvals, approvalViewFromResponse(resp))
+	}
+	return view, nil
+}
+
+// remoteDecideApproval approves or rejects the approval request with the
+// given ID by following its link. Approving runs the held action on the
+// server.
+func remoteDecideApproval(c *cli.Context, id string, approve bool) (approvalView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return approvalView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	approvals, err := cl.ListApprovals(ctx, "")
+	if err != nil {
+		return approvalView{}, apiError(err)
+	}
+	idx := slices.IndexFunc(approvals, func(a handlers.ApprovalResponse) bool { return a.ID == id })
+	if idx < 0 {
+		return approvalView{}, cli.Exit(fmt.Sprintf("approval request %q not found", id), exitNotFound)
+	}
+	existing := approvals[idx]
+
+	link := existing.Links.Reject
+	if approve {
+		link = existing.Links.Approve
+	}
+	if link == nil {
+		return approvalView{}, cli.Exit(fmt.Sprintf("approval request %q is %s, not pending", id, existing.Status),
+			exitInvalidState)
+	}
+
+	if !approve {
+		var rejected handlers.ApprovalResponse
+		if err := cl.Follow(ctx, *link, nil, &rejected); err != nil {
+			return approvalView{}, apiError(err)
+		}
+		return approvalViewFromResponse(rejected), nil
+	}
+
+	var resp handlers.ApprovedActionResponse
+	if err := cl.Follow(ctx, *link, nil, &resp); err != nil {
+		return approvalView{}, apiError(err)
+	}
+	return approvalViewFromResponse(resp.Approval), nil
+}
+
+// remotePreview asks the server to check the action on the sequencer and
+// describe it, by following the action's link with dry_run=true
+func remotePreview(c *cli.Context, id, linkName string, body func(c *cli.Context) any) (previewView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	seq, err := findRemoteSequencer(ctx, cl, id)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	link := sequencerLink(seq.Links, linkName)
+	if link == nil {
+		return previewView{}, cli.Exit(fmt.Sprintf("%s is not available for sequencer %q in its current state",
+			c.Command.Name, id), exitInvalidState)
+	}
+
+	var reqBody any
+	if body != nil {
+		reqBody = body(c)
+	}
+
+	var resp handlers.DryRunResponse
+	if err := cl.Follow(ctx, dryRunLink(*link), reqBody, &resp); err != nil {
+		return previewView{}, apiError(err)
+	}
+	return previewViewFromResponse(resp), nil
+}
+
+// remoteHandoverPreview asks the server to select the handover target and
+// describe the transfer
+func remoteHandoverPreview(c *cli.Context, name string) (previewView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	net, err := findRemoteNetwork(ctx, cl, name)
+	if err != nil {
+		return previewView{}, err
+	}
+
+	req := handlers.HandoverRequest{
+		TargetID:       c.String(flags.HandoverTarget.Name),
+		TimeoutSeconds: int(c.Duration(flags.HandoverTimeout.Name).Seconds()),
+	}
+
+	var resp handlers.DryRunResponse
+	if err := cl.Follow(ctx, dryRunLink(net.Links.Handover), req, &resp); err != nil {
+		return previewView{}, apiError(err)
+	}
+	return previewViewFromResponse(resp), nil
+}
+
+// dryRunLink returns the link with dry_run=true added to its query
+func dryRunLink(link handlers.Link) handlers.Link {
+	link.Href += "?dry_run=true"
+	return link
+}
+
+// remoteMembershipPlan asks the server for the membership changes the network
+// needs
+func remoteMembershipPlan(c *cli.Context, name string) (planView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return planView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	net, err := findRemoteNetwork(ctx, cl, name)
+	if err != nil {
+		return planView{}, err
+	}
+
+	var resp handlers.MembershipPlanResponse
+	if err := cl.Follow(ctx, net.Links.MembershipPlan, nil, &resp); err != nil {
+		return planView{}, apiError(err)
+	}
+	return planViewFromResponse(resp), nil
+}
+
+// remoteMembershipApply asks the server to plan the membership changes of the
+// network and apply them unless --dry-run is set
+func remoteMembershipApply(c *cli.Context, name string) (applyView, error) {
+	cl, err := newClient(c)
+	if err != nil {
+		return applyView{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, client.DefaultTimeout)
+	defer cancel()
+
+	net, err := findRemoteNetwork(ctx, cl, name)
+	if err != nil {
+		return applyView{}, err
+	}
+
+	req, err := reviewedPlan(c, name)
+	if err != nil {
+		return applyView{}, err
+	}
+
+	link := net.Links.ApplyMembership
+	link.Href += "?dry_run=" + strconv.FormatBool(c.Bool(flags.PlanDryRun.Name))
+
+	var resp handlers.ApplyPlanResponse
+	err = cl.Follow(ctx, link, req, &resp)
+
+	// A failed change still reports how far the plan got
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && json.Unmarshal(apiErr.Body, &resp) == nil && resp.Plan.Steps != nil {
+		err = nil
+	}
+	if err != nil {
+		return applyView{}, apiError(err)
+	}
+
+	return applyViewFromResponse(resp), nil
+}
+
+// findRemoteNetwork looks a network up among those visible to the caller
+func findRemoteNetwork(ctx context.Context, cl *client.Client, name string) (*handlers.NetworkResponse, error) {
+	networks, err := cl.ListNetworks(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	for i := range networks {
+		if networks[i].Name == name {
+			return &networks[i], nil
+		}
+	}
+	return nil, cli.Exit(fmt.Sprintf("network %q not found", name), exitNotFound)
+}
+
+// findRemoteSequencer looks a sequencer up in the networks visible to the
+// caller
+func findRemoteSequencer(ctx context.Context, cl *client.Client, id string) (*handlers.SequencerResponse, error) {
+	networks, err := cl.ListNetworks(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	for _, net := range networks {
+		for i := range net.Sequencers {
+			if net.Sequencers[i].ID == id {
+				return &net.Sequencers[i], nil
+			}
+		}
+	}
+	return nil, cli.Exit(fmt.Sprintf("sequencer %q not found", id), exitNotFound)
+}
+
+// sequencerLink returns the link with the given name, or nil if the server
+// did not offer it
+func sequencerLink(links handlers.SequencerLinks, name string) *handlers.Link {
+	switch name {
+	case "pause":
+		return links.Pause
+	case "resume":
+		return links.Resume
+	case "transfer_leader":
+		return links.TransferLeader
+	case "resign_leader":
+		return links.ResignLeader
+	case "override_leader":
+		return links.OverrideLeader
+	case "halt":
+		return links.Halt
+	case "force_active":
+		return links.ForceActive
+	case "remove_member":
+		return links.RemoveMember
+	case "update_member":
+		return links.UpdateMember
+	case "promote":
+		return links.Promote
+	case "demote":
+		return links.Demote
+	default:
+		return nil
+	}
+}
+
+// apiError maps a client error to the matching exit code
+func apiError(err error) error {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return cli.Exit(err.Error(), exitError)
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return cli.Exit(err.Error(), exitUsage)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cli.Exit(err.Error(), exitDenied)
+	case http.StatusNotFound:
+		return cli.Exit(err.Error(), exitNotFound)
+	case http.StatusConflict:
+		return cli.Exit(err.Error(), exitInvalidState)
+	default:
+		return cli.Exit(err.Error(), exitError)
+	}
+}
+
+// Request bodies of the remote actions
+
+func transferLeaderBody(c *cli.Context) any {
+	return handlers.TransferLeaderRequest{
+		TargetID:   c.String(flags.TargetID.Name),
+		TargetAddr: c.String(flags.TargetAddr.Name),
+	}
+}
+
+func overrideLeaderBody(c *cli.Context) any {
+	return handlers.OverrideLeaderRequest{Override: c.Bool(flags.Override.Name)}
+}
+
+func forceActiveBody(c *cli.Context) any {
+	return handlers.ForceActiveRequest{BlockHash: c.String(flags.BlockHash.Name)}
+}
+
+func addMemberBody(c *cli.Context) any {
+	return handlers.UpdateMembershipRequest{
+		ServerID:   c.String(flags.ServerID.Name),
+		ServerAddr: c.String(flags.ServerAddr.Name),
+		Voting:     c.Bool(flags.Voting.Name),
+	}
+}
+
+func removeMemberBody(c *cli.Context) any {
+	return handlers.RemoveMemberRequest{ServerID: c.String(flags.ServerID.Name)}
+}
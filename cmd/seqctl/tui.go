@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	cli "github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/term"
+
+	gbapp "github.com/golem-base/seqctl/pkg/app"
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/log"
+	"github.com/golem-base/seqctl/pkg/tui"
+)
+
+func runTUI(c *cli.Context) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return cli.Exit("seqctl tui needs an interactive terminal", exitUsage)
+	}
+
+	cfg, err := config.LoadConfig(c)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Logs would draw over the screen, so they only go to a file if one is
+	// configured
+	if cfg.Log.FilePath != "" {
+		if err := log.Init(cfg.Log.Level, cfg.Log.Format, true, cfg.Log.FilePath); err != nil {
+			return fmt.Errorf("failed to initialize logging: %w", err)
+		}
+	} else {
+		slog.SetDefault(slog.New(slog.DiscardHandler))
+	}
+
+	repo, err := newRepository(cfg)
+	if err != nil {
+		return err
+	}
+	app := gbapp.New(cfg, repo)
+
+	// Poll in the background until the operator quits
+	ctx, cancel := context.WithCancel(c.Context)
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return repo.Run(ctx)
+	})
+	g.Go(func() error {
+		defer cancel()
+		return tui.New(app, os.Stdin, os.Stdout).Run(ctx)
+	})
+
+	return g.Wait()
+}
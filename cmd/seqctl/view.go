@@ -0,0 +1,582 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/handover"
+	"github.com/golem-base/seqctl/pkg/membership"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/reconcile"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+	"github.com/golem-base/seqctl/pkg/server/handlers"
+)
+
+// The views below are what the CLI prints. Their JSON fields match the API
+// responses so scripts can switch between the two.
+
+// sequencerHeader is the table header shared by sequencer views
+var sequencerHeader = []string{"NETWORK", "SEQUENCER", "LEADER", "ACTIVE", "CONDUCTOR", "HEALTHY", "VOTING", "UNSAFE L2"}
+
+// sequencerView is the status of a single sequencer
+type sequencerView struct {
+	ID               string                            `json:"id"`
+	NetworkID        string                            `json:"network_id"`
+	RaftAddr         string                            `json:"raft_addr"`
+	ConductorActive  bool                              `json:"conductor_active"`
+	ConductorLeader  bool                              `json:"conductor_leader"`
+	ConductorPaused  bool                              `json:"conductor_paused"`
+	ConductorStopped bool                              `json:"conductor_stopped"`
+	SequencerHealthy bool                              `json:"sequencer_healthy"`
+	SequencerActive  bool                              `json:"sequencer_active"`
+	UnsafeL2         uint64                            `json:"unsafe_l2"`
+	SyncStatus       *handlers.SyncStatusResponse      `json:"sync_status,omitempty"`
+	Lag              *handlers.LagResponse             `json:"lag,omitempty"`
+	Head             *handlers.HeadResponse            `json:"head,omitempty"`
+	Reachability     string                            `json:"reachability"`
+	Checks           map[string]handlers.CheckResponse `json:"checks,omitempty"`
+	Voting           bool                              `json:"voting"`
+	UpdatedAt        time.Time                         `json:"updated_at"`
+}
+
+func newSequencerView(seq *sequencer.Sequencer, net *network.Network, updatedAt time.Time) sequencerView {
+	status := seq.Status()
+
+	view := sequencerView{
+		ID:               seq.ID(),
+		NetworkID:        net.Name(),
+		RaftAddr:         seq.RaftAddr(),
+		ConductorActive:  status.ConductorActive,
+		ConductorLeader:  status.ConductorLeader,
+		ConductorPaused:  status.ConductorPaused,
+		ConductorStopped: status.ConductorStopped,
+		SequencerHealthy: status.SequencerHealthy,
+		SequencerActive:  status.SequencerActive,
+		SyncStatus:       handlers.NewSyncStatusResponse(status),
+		Lag:              handlers.NewLagResponse(status),
+		Head:             handlers.NewHeadResponse(net, seq.ID()),
+		Reachability:     string(status.Reachability()),
+		Checks:           handlers.NewChecksResponse(status),
+		Voting:           seq.Voting(),
+		UpdatedAt:        updatedAt,
+	}
+	if status.UnsafeL2 != nil {
+		view.UnsafeL2 = status.UnsafeL2.Number
+	}
+	return view
+}
+
+func (v sequencerView) Header() []string {
+	return sequencerHeader
+}
+
+func (v sequencerView) Rows() [][]string {
+	return [][]string{v.row()}
+}
+
+func (v sequencerView) row() []string {
+	return []string{
+		v.NetworkID,
+		v.ID,
+		strconv.FormatBool(v.ConductorLeader),
+		strconv.FormatBool(v.SequencerActive),
+		v.conductorState(),
+		strconv.FormatBool(v.SequencerHealthy),
+		strconv.FormatBool(v.Voting),
+		strconv.FormatUint(v.UnsafeL2, 10),
+	}
+}
+
+// conductorState summarizes the conductor flags in one word
+func (v sequencerView) conductorState() string {
+	switch {
+	case v.ConductorStopped:
+		return "stopped"
+	case v.ConductorPaused:
+		return "paused"
+	case v.ConductorActive:
+		return "active"
+	default:
+		return "inactive"
+	}
+}
+
+// violationView is a broken cluster invariant
+type violationView struct {
+	Kind       string   `json:"kind"`
+	Severity   string   `json:"severity"`
+	Message    string   `json:"message"`
+	Sequencers []string `json:"sequencers,omitempty"`
+}
+
+// networkView is the status of a network and its sequencers
+type networkView struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Healthy    bool            `json:"healthy"`
+	Violations []violationView `json:"violations"`
+	Sequencers []sequencerView `json:"sequencers"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+func newNetworkView(net *network.Network) networkView {
+	view := networkView{
+		ID:         net.Name(),
+		Name:       net.Name(),
+		Healthy:    net.IsHealthy(),
+		Violations: make([]violationView, 0),
+		Sequencers: make([]sequencerView, 0, len(net.Sequencers())),
+		UpdatedAt:  net.UpdatedAt(),
+	}
+
+	for _, v := range net.Invariants() {
+		view.Violations = append(view.Violations, violationView{
+			Kind:       string(v.Kind),
+			Severity:   string(v.Severity),
+			Message:    v.Message,
+			Sequencers: v.Sequencers,
+		})
+		if v.Severity == network.SeverityCritical {
+			view.Healthy = false
+		}
+	}
+
+	for _, seq := range net.Sequencers() {
+		view.Sequencers = append(view.Sequencers, newSequencerView(seq, net, view.UpdatedAt))
+	}
+	return view
+}
+
+// statusView is the status of every selected network
+type statusView []networkView
+
+func (v statusView) Header() []string {
+	return sequencerHeader
+}
+
+func (v statusView) Rows() [][]string {
+	var rows [][]string
+	for _, net := range v {
+		for _, seq := range net.Sequencers {
+			rows = append(rows, seq.row())
+		}
+	}
+	return rows
+}
+
+// handoverView is the outcome of a guided handover
+type handoverView struct {
+	Network        string             `json:"network"`
+	From           string             `json:"from"`
+	To             string             `json:"to"`
+	Success        bool               `json:"success"`
+	RolledBack     bool               `json:"rolled_back"`
+	Error          string             `json:"error,omitempty"`
+	UnsafeL2Before uint64             `json:"unsafe_l2_before"`
+	UnsafeL2After  uint64             `json:"unsafe_l2_after,omitempty"`
+	Steps          []handoverStepView `json:"steps"`
+}
+
+// handoverStepView is one step of a guided handover
+type handoverStepView struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Detail     string    `json:"detail,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+func newHandoverView(result *handover.Result, err error) handoverView {
+	view := handoverView{
+		Network:        result.Network,
+		From:           result.From,
+		To:             result.To,
+		Success:        result.Success,
+		RolledBack:     result.RolledBack,
+		UnsafeL2Before: result.UnsafeL2Before,
+		UnsafeL2After:  result.UnsafeL2After,
+		Steps:          make([]handoverStepView, 0, len(result.Steps)),
+	}
+	if err != nil {
+		view.Error = err.Error()
+	}
+	for _, step := range result.Steps {
+		view.Steps = append(view.Steps, handoverStepView{
+			Name:       step.Name,
+			Status:     step.Status,
+			Detail:     step.Detail,
+			StartedAt:  step.Started,
+			DurationMs: step.Duration.Milliseconds(),
+		})
+	}
+	return view
+}
+
+func (v handoverView) Header() []string {
+	return []string{"STEP", "STATUS", "DURATION", "DETAIL"}
+}
+
+func (v handoverView) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Steps))
+	for _, step := range v.Steps {
+		duration := time.Duration(step.DurationMs) * time.Millisecond
+		rows = append(rows, []string{step.Name, step.Status, duration.String(), step.Detail})
+	}
+	return rows
+}
+
+// membershipView is the Raft cluster membership of a network
+type membershipView struct {
+	Network    string       `json:"network"`
+	Version    uint64       `json:"version"`
+	Source     string       `json:"source"`
+	FromLeader bool         `json:"from_leader"`
+	Members    []memberView `json:"members"`
+	Missing    []string     `json:"missing"`
+}
+
+// memberView is a server in the Raft configuration
+type memberView struct {
+	ID       string `json:"id"`
+	Addr     string `json:"addr"`
+	Suffrage string `json:"suffrage"`
+	Leader   bool   `json:"leader"`
+	Unknown  bool   `json:"unknown"`
+}
+
+func newMembershipView(m *membership.Membership) membershipView {
+	return membershipViewFromResponse(handlers.NewMembershipResponse(m))
+}
+
+func (v membershipView) Header() []string {
+	return []string{"MEMBER", "ADDR", "SUFFRAGE", "LEADER", "UNKNOWN"}
+}
+
+func (v membershipView) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Members))
+	for _, member := range v.Members {
+		rows = append(rows, []string{
+			member.ID,
+			member.Addr,
+			member.Suffrage,
+			strconv.FormatBool(member.Leader),
+			strconv.FormatBool(member.Unknown),
+		})
+	}
+	return rows
+}
+
+// suffrageView is the outcome of promoting or demoting a member
+type suffrageView struct {
+	Membership  membershipView `json:"membership"`
+	Voting      string         `json:"voting"`
+	VotingError string         `json:"voting_error,omitempty"`
+}
+
+func (v suffrageView) Header() []string {
+	return v.Membership.Header()
+}
+
+func (v suffrageView) Rows() [][]string {
+	return v.Membership.Rows()
+}
+
+// planView lists the changes that bring the Raft membership of a network in
+// line with the suffrage of its discovered sequencers
+type planView struct {
+	Network    string         `json:"network"`
+	Version    uint64         `json:"version"`
+	Source     string         `json:"source"`
+	FromLeader bool           `json:"from_leader"`
+	InSync     bool           `json:"in_sync"`
+	Steps      []planStepView `json:"steps"`
+}
+
+// planStepView is one planned membership change
+type planStepView struct {
+	Action string `json:"action"`
+	ID     string `json:"id"`
+	Addr   string `json:"addr"`
+	Reason string `json:"reason"`
+	Status string `json:"status,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func newPlanView(plan *reconcile.Plan) planView {
+	return planViewFromResponse(handlers.NewPlanResponse(plan))
+}
+
+func (v planView) Header() []string {
+	return []string{"ACTION", "MEMBER", "ADDR", "REASON", "STATUS", "DETAIL"}
+}
+
+func (v planView) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Steps))
+	for _, step := range v.Steps {
+		rows = append(rows, []string{step.Action, step.ID, step.Addr, step.Reason, step.Status, step.Detail})
+	}
+	return rows
+}
+
+// applyView is the outcome of applying a membership plan
+type applyView struct {
+	DryRun     bool            `json:"dry_run"`
+	Plan       planView        `json:"plan"`
+	Membership *membershipView `json:"membership,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+func (v applyView) Header() []string {
+	return v.Plan.Header()
+}
+
+func (v applyView) Rows() [][]string {
+	return v.Plan.Rows()
+}
+
+// previewView describes what an action would do
+type previewView struct {
+	DryRun bool       `json:"dry_run"`
+	Calls  []callView `json:"calls"`
+	Effect string     `json:"effect"`
+}
+
+// callView is an RPC an action would send
+type callView struct {
+	Sequencer string `json:"sequencer"`
+	Endpoint  string `json:"endpoint"`
+	URL       string `json:"url"`
+	Method    string `json:"method"`
+	Params    []any  `json:"params"`
+}
+
+func newPreviewView(preview *action.Preview) previewView {
+	return previewViewFromResponse(handlers.NewDryRunResponse(preview))
+}
+
+func (v previewView) Header() []string {
+	return []string{"SEQUENCER", "ENDPOINT", "URL", "METHOD", "PARAMS"}
+}
+
+func (v previewView) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Calls))
+	for _, call := range v.Calls {
+		params, _ := json.Marshal(call.Params)
+		rows = append(rows, []string{call.Sequencer, call.Endpoint, call.URL, call.Method, string(params)})
+	}
+	return rows
+}
+
+// approvalHeader is the table header shared by approval views
+var approvalHeader = []string{"ID", "ACTION", "NETWORK", "SEQUENCER", "STATUS", "REQUESTED BY", "DECIDED BY", "EXPIRES"}
+
+// approvalView is an action held for a second operator
+type approvalView struct {
+	ID                string          `json:"id"`
+	Action            string          `json:"action"`
+	Network           string          `json:"network"`
+	Sequencer         string          `json:"sequencer"`
+	Request           json.RawMessage `json:"request,omitempty"`
+	Effect            string          `json:"effect"`
+	Status            string          `json:"status"`
+	RequestedBy       string          `json:"requested_by"`
+	RequestedByMethod string          `json:"requested_by_method"`
+	RequestedAt       time.Time       `json:"requested_at"`
+	ExpiresAt         time.Time       `json:"expires_at"`
+	ApprovedBy        string          `json:"approved_by,omitempty"`
+	ApprovedByMethod  string          `json:"approved_by_method,omitempty"`
+	RejectedBy        string          `json:"rejected_by,omitempty"`
+	RejectedByMethod  string          `json:"rejected_by_method,omitempty"`
+	DecidedAt         *time.Time      `json:"decided_at,omitempty"`
+	Error             string          `json:"error,omitempty"`
+}
+
+func (v approvalView) Header() []string {
+	return approvalHeader
+}
+
+func (v approvalView) Rows() [][]string {
+	return [][]string{v.row()}
+}
+
+func (v approvalView) row() []string {
+	var decidedBy string
+	switch {
+	case v.RejectedBy != "":
+		decidedBy = operatorName(v.RejectedBy, v.RejectedByMethod)
+	case v.ApprovedBy != "":
+		decidedBy = operatorName(v.ApprovedBy, v.ApprovedByMethod)
+	}
+	return []string{
+		v.ID,
+		v.Action,
+		v.Network,
+		v.Sequencer,
+		v.Status,
+		operatorName(v.RequestedBy, v.RequestedByMethod),
+		decidedBy,
+		v.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// operatorName names an operator with the way they authenticated, as an API
+// token and a JWT user of the same name are different operators
+func operatorName(name, method string) string {
+	if method == "" {
+		return name
+	}
+	return name + " (" + method + ")"
+}
+
+// approvalsView lists approval requests, newest first
+type approvalsView struct {
+	Approvals []approvalView `json:"approvals"`
+}
+
+func (v approvalsView) Header() []string {
+	return approvalHeader
+}
+
+func (v approvalsView) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Approvals))
+	for _, approval := range v.Approvals {
+		rows = append(rows, approval.row())
+	}
+	return rows
+}
+
+func sequencerViewFromResponse(resp handlers.SequencerResponse) sequencerView {
+	return sequencerView{
+		ID:               resp.ID,
+		NetworkID:        resp.NetworkID,
+		RaftAddr:         resp.RaftAddr,
+		ConductorActive:  resp.ConductorActive,
+		ConductorLeader:  resp.ConductorLeader,
+		ConductorPaused:  resp.ConductorPaused,
+		ConductorStopped: resp.ConductorStopped,
+		SequencerHealthy: resp.SequencerHealthy,
+		SequencerActive:  resp.SequencerActive,
+		UnsafeL2:         resp.UnsafeL2,
+		SyncStatus:       resp.SyncStatus,
+		Lag:              resp.Lag,
+		Reachability:     resp.Reachability,
+		Checks:           resp.Checks,
+		Voting:           resp.Voting,
+		UpdatedAt:        resp.UpdatedAt,
+	}
+}
+
+func networkViewFromResponse(resp handlers.NetworkResponse) networkView {
+	view := networkView{
+		ID:         resp.ID,
+		Name:       resp.Name,
+		Healthy:    resp.Healthy,
+		Violations: make([]violationView, 0, len(resp.Violations)),
+		Sequencers: make([]sequencerView, 0, len(resp.Sequencers)),
+		UpdatedAt:  resp.UpdatedAt,
+	}
+	for _, v := range resp.Violations {
+		view.Violations = append(view.Violations, violationView(v))
+	}
+	for _, seq := range resp.Sequencers {
+		view.Sequencers = append(view.Sequencers, sequencerViewFromResponse(seq))
+	}
+	return view
+}
+
+func handoverViewFromResponse(resp handlers.HandoverResponse) handoverView {
+	view := handoverView{
+		Network:        resp.Network,
+		From:           resp.From,
+		To:             resp.To,
+		Success:        resp.Success,
+		RolledBack:     resp.RolledBack,
+		Error:          resp.Error,
+		UnsafeL2Before: resp.UnsafeL2Before,
+		UnsafeL2After:  resp.UnsafeL2After,
+		Steps:          make([]handoverStepView, 0, len(resp.Steps)),
+	}
+	for _, step := range resp.Steps {
+		view.Steps = append(view.Steps, handoverStepView(step))
+	}
+	return view
+}
+
+func membershipViewFromResponse(resp handlers.MembershipResponse) membershipView {
+	view := membershipView{
+		Network:    resp.Network,
+		Version:    resp.Version,
+		Source:     resp.Source,
+		FromLeader: resp.FromLeader,
+		Members:    make([]memberView, 0, len(resp.Members)),
+		Missing:    resp.Missing,
+	}
+	for _, member := range resp.Members {
+		view.Members = append(view.Members, memberView(member))
+	}
+	return view
+}
+
+func planViewFromResponse(resp handlers.MembershipPlanResponse) planView {
+	view := planView{
+		Network:    resp.Network,
+		Version:    resp.Version,
+		Source:     resp.Source,
+		FromLeader: resp.FromLeader,
+		InSync:     resp.InSync,
+		Steps:      make([]planStepView, 0, len(resp.Steps)),
+	}
+	for _, step := range resp.Steps {
+		view.Steps = append(view.Steps, planStepView(step))
+	}
+	return view
+}
+
+func applyViewFromResponse(resp handlers.ApplyPlanResponse) applyView {
+	view := applyView{
+		DryRun: resp.DryRun,
+		Plan:   planViewFromResponse(resp.Plan),
+		Error:  resp.Error,
+	}
+	if resp.Membership != nil {
+		members := membershipViewFromResponse(*resp.Membership)
+		view.Membership = &members
+	}
+	return view
+}
+
+func previewViewFromResponse(resp handlers.DryRunResponse) previewView {
+	view := previewView{
+		DryRun: resp.DryRun,
+		Calls:  make([]callView, 0, len(resp.Calls)),
+		Effect: resp.Effect,
+	}
+	for _, call := range resp.Calls {
+		view.Calls = append(view.Calls, callView(call))
+	}
+	return view
+}
+
+func approvalViewFromResponse(resp handlers.ApprovalResponse) approvalView {
+	return approvalView{
+		ID:                resp.ID,
+		Action:            resp.Action,
+		Network:           resp.Network,
+		Sequencer:         resp.Sequencer,
+		Request:           resp.Request,
+		Effect:            resp.Effect,
+		Status:            resp.Status,
+		RequestedBy:       resp.RequestedBy,
+		RequestedByMethod: resp.RequestedByMethod,
+		RequestedAt:       resp.RequestedAt,
+		ExpiresAt:         resp.ExpiresAt,
+		ApprovedBy:        resp.ApprovedBy,
+		ApprovedByMethod:  resp.ApprovedByMethod,
+		RejectedBy:        resp.RejectedBy,
+		RejectedByMethod:  resp.RejectedByMethod,
+		DecidedAt:         resp.DecidedAt,
+		Error:             resp.Error,
+	}
+}
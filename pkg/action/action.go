@@ -0,0 +1,372 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/golem-base/seqctl/pkg/membership"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Errors returned before any RPC is sent to the sequencer
+var (
+	ErrInvalidState    = errors.New("invalid state")
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// Pause pauses the sequencer's conductor
+func Pause(ctx context.Context, seq *sequencer.Sequencer) error {
+	if err := checkPause(seq); err != nil {
+		return err
+	}
+	return seq.Pause(ctx)
+}
+
+func checkPause(seq *sequencer.Sequencer) error {
+	if !seq.ConductorActive() {
+		return fmt.Errorf("%w: conductor is already paused", ErrInvalidState)
+	}
+	return nil
+}
+
+// Resume resumes the sequencer's conductor
+func Resume(ctx context.Context, seq *sequencer.Sequencer) error {
+	if err := checkResume(seq); err != nil {
+		return err
+	}
+	return seq.Resume(ctx)
+}
+
+func checkResume(seq *sequencer.Sequencer) error {
+	if seq.ConductorActive() {
+		return fmt.Errorf("%w: conductor is already active", ErrInvalidState)
+	}
+	return nil
+}
+
+// TransferLeader transfers Raft leadership to the given server
+func TransferLeader(ctx context.Context, seq *sequencer.Sequencer, targetID, targetAddr string) error {
+	if err := checkTransferLeader(seq, targetID, targetAddr); err != nil {
+		return err
+	}
+	return seq.TransferLeaderToServer(ctx, targetID, targetAddr)
+}
+
+func checkTransferLeader(seq *sequencer.Sequencer, targetID, targetAddr string) error {
+	if targetID == "" || targetAddr == "" {
+		return fmt.Errorf("%w: target_id and target_addr are required", ErrInvalidArgument)
+	}
+	if seq.ConductorLeader() {
+		return fmt.Errorf("%w: cannot transfer leadership from current leader", ErrInvalidState)
+	}
+	return nil
+}
+
+// TransferLeaderTo asks the network's current leader to hand Raft leadership
+// to the target sequencer
+func TransferLeaderTo(ctx context.Context, net *network.Network, target *sequencer.Sequencer) error {
+	leader := net.ConductorLeader()
+	if leader == nil {
+		return fmt.Errorf("%w: network has no conductor leader", ErrInvalidState)
+	}
+	if leader == target {
+		return fmt.Errorf("%w: sequencer is already the leader", ErrInvalidState)
+	}
+	return leader.TransferLeaderToServer(ctx, target.ID(), target.RaftAddr())
+}
+
+// ResignLeader makes the current leader resign, triggering an election
+func ResignLeader(ctx context.Context, seq *sequencer.Sequencer) error {
+	if err := checkResignLeader(seq); err != nil {
+		return err
+	}
+	return seq.TransferLeader(ctx)
+}
+
+func checkResignLeader(seq *sequencer.Sequencer) error {
+	if !seq.ConductorLeader() {
+		return fmt.Errorf("%w: sequencer is not the current leader", ErrInvalidState)
+	}
+	return nil
+}
+
+// OverrideLeader forces the conductor's leader status. This can cause
+// split-brain and is not checked against the current state.
+func OverrideLeader(ctx context.Context, seq *sequencer.Sequencer, override bool) error {
+	return seq.OverrideLeader(ctx, override)
+}
+
+// Halt stops the sequencer from producing blocks
+func Halt(ctx context.Context, seq *sequencer.Sequencer) error {
+	if err := checkHalt(seq); err != nil {
+		return err
+	}
+	_, err := seq.StopSequencer(ctx)
+	return err
+}
+
+func checkHalt(seq *sequencer.Sequencer) error {
+	if !seq.SequencerActive() {
+		return fmt.Errorf("%w: sequencer is already halted", ErrInvalidState)
+	}
+	return nil
+}
+
+// ForceActive starts block production on the sequencer from the given block
+// hash, or from the zero hash if empty
+func ForceActive(ctx context.Context, seq *sequencer.Sequencer, blockHash string) error {
+	if err := checkForceActive(seq); err != nil {
+		return err
+	}
+	return seq.StartSequencer(ctx, startHash(blockHash))
+}
+
+func checkForceActive(seq *sequencer.Sequencer) error {
+	if seq.SequencerActive() {
+		return fmt.Errorf("%w: sequencer is already active", ErrInvalidState)
+	}
+	return nil
+}
+
+// startHash returns the block hash to start sequencing from
+func startHash(blockHash string) common.Hash {
+	if blockHash == "" {
+		return common.Hash{}
+	}
+	return common.HexToHash(blockHash)
+}
+
+// AddMember adds a server to the Raft cluster through the conductor leader,
+// as a voter or non-voter. The change is made against the membership version
+// read beforehand, so that a concurrent change makes it fail instead of being
+// overwritten. The new membership is returned.
+func AddMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID, serverAddr string, voting bool) (*membership.Membership, error) {
+	current, err := checkAddMember(ctx, net, seq, serverID, serverAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if voting {
+		err = seq.AddServerAsVoter(ctx, serverID, serverAddr, current.Version)
+	} else {
+		err = seq.AddServerAsNonvoter(ctx, serverID, serverAddr, current.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return membership.Read(ctx, net, seq)
+}
+
+// checkAddMember returns the membership the server is added to
+func checkAddMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID, serverAddr string) (*membership.Membership, error) {
+	if serverID == "" || serverAddr == "" {
+		return nil, fmt.Errorf("%w: server_id and server_addr are required", ErrInvalidArgument)
+	}
+
+	current, err := leaderMembership(ctx, net, seq)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := current.Member(serverID); ok {
+		return nil, fmt.Errorf("%w: %s is already a member", ErrInvalidState, serverID)
+	}
+	return current, nil
+}
+
+// RemoveMember removes a server from the Raft cluster through the conductor
+// leader. Removing the leader, or a voter the remaining voters cannot reach a
+// quorum without, is refused. Like AddMember, the change is made against the
+// membership version read beforehand and the new membership is returned.
+func RemoveMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID string) (*membership.Membership, error) {
+	current, err := checkRemoveMember(ctx, net, seq, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := seq.RemoveServer(ctx, serverID, current.Version); err != nil {
+		return nil, err
+	}
+	return membership.Read(ctx, net, seq)
+}
+
+// checkRemoveMember returns the membership the server is removed from
+func checkRemoveMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID string) (*membership.Membership, error) {
+	if serverID == "" {
+		return nil, fmt.Errorf("%w: server_id is required", ErrInvalidArgument)
+	}
+
+	current, err := leaderMembership(ctx, net, seq)
+	if err != nil {
+		return nil, err
+	}
+	if err := current.CheckRemoval(serverID); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidState, err)
+	}
+	return current, nil
+}
+
+// PromoteMember makes a non-voting member of the Raft cluster a voter through
+// the network's conductor leader. Raft promotes a non-voter that is added as
+// a voter in place, so the member stays part of the cluster throughout. The
+// new membership is returned once it lists the member as a voter.
+func PromoteMember(ctx context.Context, net *network.Network, target *sequencer.Sequencer) (*membership.Membership, error) {
+	change, err := checkSuffrageChange(ctx, net, target, true)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, member := change.leader, change.member
+	if err := leader.AddServerAsVoter(ctx, member.ID, member.Addr, change.current.Version); err != nil {
+		return nil, err
+	}
+	return verifySuffrage(ctx, net, leader, member.ID, true)
+}
+
+// DemoteMember makes a voting member of the Raft cluster a non-voter through
+// the network's conductor leader. The conductor API cannot demote, and adding
+// a voter as a non-voter only updates its address, so the member is removed
+// and added back as a non-voter against the version the removal produced.
+// The removal is refused under the same conditions as RemoveMember.
+//
+// The member is not part of the cluster between the two changes. Adding it
+// back is retried, and if it still fails the member is restored as a voter
+// so that a failed demotion leaves the cluster as it was.
+func DemoteMember(ctx context.Context, net *network.Network, target *sequencer.Sequencer) (*membership.Membership, error) {
+	change, err := checkSuffrageChange(ctx, net, target, false)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, member := change.leader, change.member
+	if err := leader.RemoveServer(ctx, member.ID, change.current.Version); err != nil {
+		return nil, err
+	}
+
+	if err := rejoin(ctx, net, leader, member, false); err != nil {
+		// Restore the member even if the caller gave up on the demotion
+		restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), restoreTimeout)
+		defer cancel()
+
+		if restoreErr := rejoin(restoreCtx, net, leader, member, true); restoreErr != nil {
+			return nil, fmt.Errorf("%s was removed from the cluster and could not be added back as a non-voter (%v) nor restored as a voter: %w",
+				member.ID, err, restoreErr)
+		}
+		return nil, fmt.Errorf("%s could not be added back as a non-voter and was restored as a voter: %w", member.ID, err)
+	}
+	return verifySuffrage(ctx, net, leader, member.ID, false)
+}
+
+// Retries of adding a removed member back to the cluster
+const (
+	rejoinAttempts   = 3
+	rejoinRetryDelay = 250 * time.Millisecond
+
+	// restoreTimeout bounds restoring a member after a failed demotion
+	restoreTimeout = 30 * time.Second
+)
+
+// rejoin adds a removed member back to the cluster through the leader with
+// the given suffrage. Each attempt is made against a freshly read membership
+// version. A member already listed, because an earlier attempt succeeded
+// without a response, is left as is.
+func rejoin(ctx context.Context, net *network.Network, leader *sequencer.Sequencer, member membership.Member, voter bool) error {
+	var err error
+	for attempt := range rejoinAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w (after %v)", ctx.Err(), err)
+			case <-time.After(rejoinRetryDelay):
+			}
+		}
+
+		var current *membership.Membership
+		if current, err = membership.Read(ctx, net, leader); err != nil {
+			continue
+		}
+		if _, ok := current.Member(member.ID); ok {
+			return nil
+		}
+
+		if voter {
+			err = leader.AddServerAsVoter(ctx, member.ID, member.Addr, current.Version)
+		} else {
+			err = leader.AddServerAsNonvoter(ctx, member.ID, member.Addr, current.Version)
+		}
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// suffrageChange is a validated promotion or demotion
+type suffrageChange struct {
+	leader  *sequencer.Sequencer
+	current *membership.Membership
+	member  membership.Member
+}
+
+// checkSuffrageChange validates making the target a voter or non-voter
+// through the network's conductor leader
+func checkSuffrageChange(ctx context.Context, net *network.Network, target *sequencer.Sequencer, voter bool) (*suffrageChange, error) {
+	leader := net.ConductorLeader()
+	if leader == nil {
+		return nil, fmt.Errorf("%w: network has no conductor leader", ErrInvalidState)
+	}
+
+	current, err := leaderMembership(ctx, net, leader)
+	if err != nil {
+		return nil, err
+	}
+	member, ok := current.Member(target.ID())
+	switch {
+	case !ok && voter:
+		return nil, fmt.Errorf("%w: %s is not a member, add it first", ErrInvalidState, target.ID())
+	case !ok:
+		return nil, fmt.Errorf("%w: %s is not a member", ErrInvalidState, target.ID())
+	case member.Voter == voter:
+		return nil, fmt.Errorf("%w: %s is already a %s", ErrInvalidState, target.ID(), suffrage(voter))
+	}
+	if !voter {
+		if err := current.CheckRemoval(member.ID); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidState, err)
+		}
+	}
+	return &suffrageChange{leader: leader, current: current, member: member}, nil
+}
+
+// verifySuffrage reads the membership back through the leader and checks
+// that it lists the member with the expected suffrage
+func verifySuffrage(ctx context.Context, net *network.Network, leader *sequencer.Sequencer, id string, voter bool) (*membership.Membership, error) {
+	m, err := membership.Read(ctx, net, leader)
+	if err != nil {
+		return nil, err
+	}
+	if member, ok := m.Member(id); !ok || member.Voter != voter {
+		return nil, fmt.Errorf("membership version %d does not list %s as a %s", m.Version, id, suffrage(voter))
+	}
+	return m, nil
+}
+
+// suffrage names a member's suffrage
+func suffrage(voter bool) string {
+	if voter {
+		return "voter"
+	}
+	return "non-voter"
+}
+
+// leaderMembership reads the current membership through the sequencer, which
+// must be the conductor leader according to its last leader probe
+func leaderMembership(ctx context.Context, net *network.Network, seq *sequencer.Sequencer) (*membership.Membership, error) {
+	if !seq.Status().KnownLeader() {
+		return nil, fmt.Errorf("%w: sequencer is not the conductor leader", ErrInvalidState)
+	}
+	return membership.Read(ctx, net, seq)
+}
@@ -0,0 +1,105 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// newTestCluster returns a cluster of three voters led by sequencer-0 and
+// its network
+func newTestCluster(t *testing.T) (*conductortest.Cluster, *network.Network) {
+	t.Helper()
+
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Servers: []consensus.ServerInfo{
+			{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-1", Addr: "sequencer-1:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-2", Addr: "sequencer-2:50050", Suffrage: consensus.Voter},
+		},
+		Version: 3,
+	}
+	net := network.NewNetwork("devnet", c.Sequencers(t, conductortest.Voters("sequencer-0", "sequencer-1", "sequencer-2")))
+	_ = net.Update(context.Background())
+	return c, net
+}
+
+func TestDemoteMember(t *testing.T) {
+	c, net := newTestCluster(t)
+
+	m, err := DemoteMember(context.Background(), net, net.SequencerByID("sequencer-2"))
+	if err != nil {
+		t.Fatalf("DemoteMember() = %v", err)
+	}
+	if member, ok := m.Member("sequencer-2"); !ok || member.Voter {
+		t.Errorf("DemoteMember() = %+v, want sequencer-2 as a non-voter", member)
+	}
+	if suffrage, _ := c.Suffrage("sequencer-2"); suffrage != consensus.Nonvoter {
+		t.Errorf("Cluster suffrage of sequencer-2 = %v, want non-voter", suffrage)
+	}
+}
+
+func TestDemoteMember_RestoresVoterWhenReAddFails(t *testing.T) {
+	c, net := newTestCluster(t)
+	c.Lock()
+	c.Failing = map[string]bool{"conductor_addServerAsNonvoter": true}
+	c.Unlock()
+
+	_, err := DemoteMember(context.Background(), net, net.SequencerByID("sequencer-2"))
+	if err == nil || !strings.Contains(err.Error(), "restored as a voter") {
+		t.Fatalf("DemoteMember() = %v, want the member restored as a voter", err)
+	}
+
+	suffrage, ok := c.Suffrage("sequencer-2")
+	if !ok || suffrage != consensus.Voter {
+		t.Errorf("Cluster suffrage of sequencer-2 = %v (member %v), want a voter", suffrage, ok)
+	}
+}
+
+func TestDemoteMember_ReportsMemberOutsideCluster(t *testing.T) {
+	c, net := newTestCluster(t)
+	c.Lock()
+	c.Failing = map[string]bool{
+		"conductor_addServerAsNonvoter": true,
+		"conductor_addServerAsVoter":    true,
+	}
+	c.Unlock()
+
+	_, err := DemoteMember(context.Background(), net, net.SequencerByID("sequencer-2"))
+	if err == nil || !strings.Contains(err.Error(), "removed from the cluster") {
+		t.Fatalf("DemoteMember() = %v, want the member reported outside the cluster", err)
+	}
+	if _, ok := c.Suffrage("sequencer-2"); ok {
+		t.Error("sequencer-2 is a member, want it removed since neither re-add succeeded")
+	}
+}
+
+func TestRemoveMember_RefusesStaleLeader(t *testing.T) {
+	c, net := newTestCluster(t)
+
+	// sequencer-0 keeps the leadership it reported before its probe failed
+	c.Lock()
+	c.Failing = map[string]bool{"conductor_leader": true}
+	c.Unlock()
+	_ = net.Update(context.Background())
+
+	leader := net.SequencerByID("sequencer-0")
+	if !leader.ConductorLeader() {
+		t.Fatal("sequencer-0 lost its stale leadership, want it kept from the earlier probe")
+	}
+
+	_, err := RemoveMember(context.Background(), net, leader, "sequencer-2")
+	if !errors.Is(err, ErrInvalidState) {
+		t.Errorf("RemoveMember() = %v, want ErrInvalidState", err)
+	}
+	if _, ok := c.Suffrage("sequencer-2"); !ok {
+		t.Error("sequencer-2 was removed through a leader whose leadership is unknown")
+	}
+}
@@ -0,0 +1,206 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/rpc"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Call is an RPC an action sends
+type Call struct {
+	Sequencer string // Sequencer the call is sent to
+	Endpoint  string // rpc.EndpointConductor or rpc.EndpointNode
+	URL       string
+	Method    string
+	Params    []any
+}
+
+// Preview describes what an action would do, for dry runs. The Preview
+// functions run the same checks as the actions and return their errors, but
+// send no RPC that changes anything: the calls are listed in the order the
+// action would send them, with the change expected of them.
+type Preview struct {
+	Calls  []Call
+	Effect string
+}
+
+// PreviewPause describes Pause
+func PreviewPause(seq *sequencer.Sequencer) (*Preview, error) {
+	if err := checkPause(seq); err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Calls:  []Call{conductorCall(seq, rpc.MethodPause)},
+		Effect: fmt.Sprintf("conductor of %s pauses and stops acting on sequencer health", seq.ID()),
+	}, nil
+}
+
+// PreviewResume describes Resume
+func PreviewResume(seq *sequencer.Sequencer) (*Preview, error) {
+	if err := checkResume(seq); err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Calls:  []Call{conductorCall(seq, rpc.MethodResume)},
+		Effect: fmt.Sprintf("conductor of %s resumes", seq.ID()),
+	}, nil
+}
+
+// PreviewTransferLeader describes TransferLeader
+func PreviewTransferLeader(seq *sequencer.Sequencer, targetID, targetAddr string) (*Preview, error) {
+	if err := checkTransferLeader(seq, targetID, targetAddr); err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Calls:  []Call{conductorCall(seq, rpc.MethodTransferLeaderToServer, targetID, targetAddr)},
+		Effect: fmt.Sprintf("Raft leadership moves to %s (%s)", targetID, targetAddr),
+	}, nil
+}
+
+// PreviewResignLeader describes ResignLeader
+func PreviewResignLeader(seq *sequencer.Sequencer) (*Preview, error) {
+	if err := checkResignLeader(seq); err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Calls:  []Call{conductorCall(seq, rpc.MethodTransferLeader)},
+		Effect: fmt.Sprintf("%s gives up Raft leadership and the cluster elects another leader", seq.ID()),
+	}, nil
+}
+
+// PreviewOverrideLeader describes OverrideLeader
+func PreviewOverrideLeader(seq *sequencer.Sequencer, override bool) (*Preview, error) {
+	effect := fmt.Sprintf("conductor of %s reports itself leader regardless of Raft, other leaders are not stopped", seq.ID())
+	if !override {
+		effect = fmt.Sprintf("conductor of %s reports its Raft leadership again", seq.ID())
+	}
+	return &Preview{
+		Calls:  []Call{conductorCall(seq, rpc.MethodOverrideLeader, override)},
+		Effect: effect,
+	}, nil
+}
+
+// PreviewHalt describes Halt
+func PreviewHalt(seq *sequencer.Sequencer) (*Preview, error) {
+	if err := checkHalt(seq); err != nil {
+		return nil, err
+	}
+	return &Preview{
+		Calls:  []Call{nodeCall(seq, rpc.MethodStopSequencer)},
+		Effect: fmt.Sprintf("%s stops producing blocks at unsafe L2 %d", seq.ID(), seq.UnsafeL2()),
+	}, nil
+}
+
+// PreviewForceActive describes ForceActive
+func PreviewForceActive(seq *sequencer.Sequencer, blockHash string) (*Preview, error) {
+	if err := checkForceActive(seq); err != nil {
+		return nil, err
+	}
+	hash := startHash(blockHash)
+	return &Preview{
+		Calls:  []Call{nodeCall(seq, rpc.MethodStartSequencer, hash.Hex())},
+		Effect: fmt.Sprintf("%s starts producing blocks on top of %s", seq.ID(), hash.Hex()),
+	}, nil
+}
+
+// PreviewAddMember describes AddMember. The membership is read to check the
+// server is not a member yet and to find the version the change is made
+// against.
+func PreviewAddMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID, serverAddr string, voting bool) (*Preview, error) {
+	current, err := checkAddMember(ctx, net, seq, serverID, serverAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	method := rpc.MethodAddServerAsNonvoter
+	if voting {
+		method = rpc.MethodAddServerAsVoter
+	}
+	return &Preview{
+		Calls: []Call{conductorCall(seq, method, serverID, serverAddr, current.Version)},
+		Effect: fmt.Sprintf("%s (%s) joins the cluster as a %s, membership moves on from version %d",
+			serverID, serverAddr, suffrage(voting), current.Version),
+	}, nil
+}
+
+// PreviewRemoveMember describes RemoveMember. The membership is read for the
+// same checks RemoveMember makes.
+func PreviewRemoveMember(ctx context.Context, net *network.Network, seq *sequencer.Sequencer, serverID string) (*Preview, error) {
+	current, err := checkRemoveMember(ctx, net, seq, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, _ := current.Member(serverID)
+	return &Preview{
+		Calls: []Call{conductorCall(seq, rpc.MethodRemoveServer, serverID, current.Version)},
+		Effect: fmt.Sprintf("%s leaves the cluster, which keeps %d voters",
+			serverID, current.Voters()-voterCount(member.Voter)),
+	}, nil
+}
+
+// PreviewPromoteMember describes PromoteMember
+func PreviewPromoteMember(ctx context.Context, net *network.Network, target *sequencer.Sequencer) (*Preview, error) {
+	change, err := checkSuffrageChange(ctx, net, target, true)
+	if err != nil {
+		return nil, err
+	}
+
+	member := change.member
+	return &Preview{
+		Calls: []Call{conductorCall(change.leader, rpc.MethodAddServerAsVoter, member.ID, member.Addr, change.current.Version)},
+		Effect: fmt.Sprintf("%s becomes a voter in place, the cluster has %d voters",
+			member.ID, change.current.Voters()+1),
+	}, nil
+}
+
+// PreviewDemoteMember describes DemoteMember. The version of the second call
+// is only known once the first has been made and is left empty.
+func PreviewDemoteMember(ctx context.Context, net *network.Network, target *sequencer.Sequencer) (*Preview, error) {
+	change, err := checkSuffrageChange(ctx, net, target, false)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, member := change.leader, change.member
+	return &Preview{
+		Calls: []Call{
+			conductorCall(leader, rpc.MethodRemoveServer, member.ID, change.current.Version),
+			conductorCall(leader, rpc.MethodAddServerAsNonvoter, member.ID, member.Addr, nil),
+		},
+		Effect: fmt.Sprintf("%s leaves the cluster and rejoins as a non-voter, the cluster has %d voters",
+			member.ID, change.current.Voters()-1),
+	}, nil
+}
+
+func conductorCall(seq *sequencer.Sequencer, method string, params ...any) Call {
+	return newCall(seq, rpc.EndpointConductor, seq.Config().ConductorURL, method, params)
+}
+
+func nodeCall(seq *sequencer.Sequencer, method string, params ...any) Call {
+	return newCall(seq, rpc.EndpointNode, seq.Config().NodeURL, method, params)
+}
+
+func newCall(seq *sequencer.Sequencer, endpoint, url, method string, params []any) Call {
+	if params == nil {
+		params = []any{}
+	}
+	return Call{
+		Sequencer: seq.ID(),
+		Endpoint:  endpoint,
+		URL:       url,
+		Method:    method,
+		Params:    params,
+	}
+}
+
+// voterCount counts a member towards the voters
+func voterCount(voter bool) int {
+	if voter {
+		return 1
+	}
+	return 0
+}
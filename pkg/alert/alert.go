@@ -0,0 +1,566 @@
+package alert
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// Severity of an alert
+type Severity string
+
+// Alert severities
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// State of an alert
+type State string
+
+// Alert states
+const (
+	// StatePending means the condition holds but not yet for the rule's duration
+	StatePending State = "pending"
+	// StateFiring means the condition has held for the rule's duration
+	StateFiring State = "firing"
+	// StateResolved means the condition no longer holds
+	StateResolved State = "resolved"
+)
+
+// queueSize is the number of notifications waiting for delivery before new
+// ones are dropped
+const queueSize = 256
+
+// Rule raises an alert while its condition holds for a network
+type Rule struct {
+	Name      string
+	Condition Condition
+	Networks  []string // Glob patterns, empty matches all networks
+	For       time.Duration
+	Severity  Severity
+	Webhooks  []string // Empty sends to all webhooks
+	Threshold int
+	Window    time.Duration
+}
+
+// matches reports whether the rule applies to a network
+func (r *Rule) matches(networkName string) bool {
+	if len(r.Networks) == 0 {
+		return true
+	}
+	for _, pattern := range r.Networks {
+		if ok, _ := path.Match(pattern, networkName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Alert is an instance of a rule's condition holding
+type Alert struct {
+	Fingerprint string
+	Rule        string
+	Condition   Condition
+	Severity    Severity
+	Network     string
+	Sequencer   string
+	Subject     string
+	Summary     string
+	State       State
+	ActiveSince time.Time // When the condition started to hold
+	FiredAt     time.Time
+	ResolvedAt  time.Time
+	Silenced    bool
+
+	notified     bool // A firing notification was sent
+	lastNotified time.Time
+}
+
+// Silence suppresses notifications for matching alerts until it expires.
+// Empty fields match everything.
+type Silence struct {
+	ID        string
+	Rule      string
+	Network   string
+	Sequencer string
+	Until     time.Time
+	Comment   string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// matches reports whether the silence covers an alert at the given time
+func (s *Silence) matches(a *Alert, now time.Time) bool {
+	switch {
+	case !now.Before(s.Until),
+		s.Rule != "" && s.Rule != a.Rule,
+		s.Network != "" && s.Network != a.Network,
+		s.Sequencer != "" && s.Sequencer != a.Sequencer:
+		return false
+	}
+	return true
+}
+
+// Source provides the networks rules are evaluated against
+type Source interface {
+	ListNetworks(ctx context.Context) (map[string]*network.Network, error)
+}
+
+// Manager evaluates alerting rules against the network state and sends
+// webhook notifications when alerts fire and resolve. A firing alert is
+// notified once and then every repeat interval, silenced alerts are not
+// notified at all.
+type Manager struct {
+	source             Source
+	rules              []*Rule
+	webhooks           []*webhook
+	evaluationInterval time.Duration
+	repeatInterval     time.Duration // Zero disables repeated notifications
+	client             *http.Client
+	logger             *slog.Logger
+
+	queue chan notification
+
+	mu            sync.Mutex
+	alerts        map[string]*Alert      // Keyed by fingerprint
+	silences      map[string]*Silence    // Keyed by ID
+	leaderChanges map[string][]time.Time // Recent leader changes, keyed by network
+}
+
+// New creates a manager from the alerting configuration
+func New(cfg config.AlertingConfig, source Source) (*Manager, error) {
+	m := &Manager{
+		source:        source,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		logger:        slog.Default().With(slog.String("component", "alert")),
+		queue:         make(chan notification, queueSize),
+		alerts:        make(map[string]*Alert),
+		silences:      make(map[string]*Silence),
+		leaderChanges: make(map[string][]time.Time),
+	}
+
+	var err error
+	if m.evaluationInterval, err = time.ParseDuration(cfg.EvaluationInterval); err != nil || m.evaluationInterval <= 0 {
+		return nil, fmt.Errorf("alerting.evaluation_interval: invalid duration %q", cfg.EvaluationInterval)
+	}
+	if m.repeatInterval, err = time.ParseDuration(cfg.RepeatInterval); err != nil || m.repeatInterval < 0 {
+		return nil, fmt.Errorf("alerting.repeat_interval: invalid duration %q", cfg.RepeatInterval)
+	}
+
+	names := make(map[string]bool)
+	for i, wc := range cfg.Webhooks {
+		hook, err := newWebhook(wc)
+		if err != nil {
+			return nil, fmt.Errorf("alerting.webhooks[%d]: %w", i, err)
+		}
+		if names[hook.name] {
+			return nil, fmt.Errorf("alerting.webhooks[%d]: duplicate name %q", i, hook.name)
+		}
+		names[hook.name] = true
+		m.webhooks = append(m.webhooks, hook)
+	}
+
+	ruleNames := make(map[string]bool)
+	for i, rc := range cfg.Rules {
+		rule, err := newRule(rc, names)
+		if err != nil {
+			return nil, fmt.Errorf("alerting.rules[%d]: %w", i, err)
+		}
+		if ruleNames[rule.Name] {
+			return nil, fmt.Errorf("alerting.rules[%d]: duplicate name %q", i, rule.Name)
+		}
+		ruleNames[rule.Name] = true
+		m.rules = append(m.rules, rule)
+	}
+
+	if len(m.rules) > 0 && len(m.webhooks) == 0 {
+		return nil, fmt.Errorf("alerting rules are configured but no [[alerting.webhooks]]")
+	}
+
+	for i, sc := range cfg.Silences {
+		until, err := time.Parse(time.RFC3339, sc.Until)
+		if err != nil {
+			return nil, fmt.Errorf("alerting.silences[%d]: until must be an RFC 3339 time: %w", i, err)
+		}
+		if !until.After(time.Now()) {
+			m.logger.Debug("Skipping expired silence", "index", i, "until", until)
+			continue
+		}
+		_, err = m.AddSilence(Silence{
+			Rule:      sc.Rule,
+			Network:   sc.Network,
+			Sequencer: sc.Sequencer,
+			Until:     until,
+			Comment:   sc.Comment,
+			CreatedBy: "config",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("alerting.silences[%d]: %w", i, err)
+		}
+	}
+
+	return m, nil
+}
+
+// newRule validates a rule configuration
+func newRule(cfg config.AlertRuleConfig, webhooks map[string]bool) (*Rule, error) {
+	rule := &Rule{
+		Name:      cfg.Name,
+		Condition: Condition(cfg.Condition),
+		Networks:  cfg.Networks,
+		Severity:  Severity(cfg.Severity),
+		Webhooks:  cfg.Webhooks,
+		Threshold: cfg.Threshold,
+	}
+
+	if rule.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if !slices.Contains(Conditions, rule.Condition) {
+		return nil, fmt.Errorf("rule %q: unknown condition %q", rule.Name, cfg.Condition)
+	}
+
+	switch rule.Severity {
+	case "":
+		rule.Severity = SeverityWarning
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	default:
+		return nil, fmt.Errorf("rule %q: unknown severity %q (expected critical, warning or info)", rule.Name, cfg.Severity)
+	}
+
+	for _, pattern := range rule.Networks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("rule %q: invalid network pattern %q", rule.Name, pattern)
+		}
+	}
+	for _, name := range rule.Webhooks {
+		if !webhooks[name] {
+			return nil, fmt.Errorf("rule %q: unknown webhook %q", rule.Name, name)
+		}
+	}
+
+	if cfg.For != "" {
+		d, err := time.ParseDuration(cfg.For)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("rule %q: invalid duration %q", rule.Name, cfg.For)
+		}
+		rule.For = d
+	}
+
+	if rule.Condition == ConditionLeaderFlapping {
+		if rule.Threshold <= 0 {
+			rule.Threshold = DefaultFlappingThreshold
+		}
+		rule.Window = DefaultFlappingWindow
+		if cfg.Window != "" {
+			d, err := time.ParseDuration(cfg.Window)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("rule %q: invalid window %q", rule.Name, cfg.Window)
+			}
+			rule.Window = d
+		}
+	}
+
+	return rule, nil
+}
+
+// Enabled reports whether any rules are configured
+func (m *Manager) Enabled() bool {
+	return len(m.rules) > 0
+}
+
+// Rules returns the configured rules
+func (m *Manager) Rules() []Rule {
+	rules := make([]Rule, len(m.rules))
+	for i, rule := range m.rules {
+		rules[i] = *rule
+	}
+	return rules
+}
+
+// HandleEvents records the leader changes that leader_flapping rules count.
+// It is a network.EventHandler.
+func (m *Manager) HandleEvents(net *network.Network, events []network.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range events {
+		if e.Type == network.EventLeaderChanged {
+			m.leaderChanges[e.Network] = append(m.leaderChanges[e.Network], e.Time)
+		}
+	}
+}
+
+// countLeaderChanges counts the leader changes of a network since a time.
+// The caller must hold m.mu.
+func (m *Manager) countLeaderChanges(networkName string, since time.Time) int {
+	count := 0
+	for _, t := range m.leaderChanges[networkName] {
+		if !t.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// pruneLeaderChanges forgets leader changes no rule can count anymore. The
+// caller must hold m.mu.
+func (m *Manager) pruneLeaderChanges(now time.Time) {
+	var window time.Duration
+	for _, rule := range m.rules {
+		window = max(window, rule.Window)
+	}
+
+	cutoff := now.Add(-window)
+	for name, times := range m.leaderChanges {
+		times = slices.DeleteFunc(times, func(t time.Time) bool { return t.Before(cutoff) })
+		if len(times) == 0 {
+			delete(m.leaderChanges, name)
+		} else {
+			m.leaderChanges[name] = times
+		}
+	}
+}
+
+// Run evaluates the rules every evaluation interval and delivers
+// notifications until ctx is cancelled
+func (m *Manager) Run(ctx context.Context) error {
+	m.logger.Info("Alerting started",
+		"rules", len(m.rules),
+		"webhooks", len(m.webhooks),
+		"evaluation_interval", m.evaluationInterval)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.deliver(ctx)
+	}()
+	defer wg.Wait()
+
+	ticker := time.NewTicker(m.evaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate runs one round of rule evaluation against the current network
+// state and queues the resulting notifications
+func (m *Manager) Evaluate(ctx context.Context) {
+	networks, err := m.source.ListNetworks(ctx)
+	if err != nil {
+		// Resolving everything because discovery failed would be wrong
+		m.logger.Warn("Skipping alert evaluation, networks unavailable", "error", err)
+		return
+	}
+
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneLeaderChanges(now)
+
+	seen := make(map[string]bool)
+	for _, rule := range m.rules {
+		for name, net := range networks {
+			if !rule.matches(name) {
+				continue
+			}
+			for _, f := range m.evaluate(rule, net, now) {
+				fingerprint := fmt.Sprintf("%s/%s/%s/%s", rule.Name, name, f.Sequencer, f.Subject)
+				seen[fingerprint] = true
+
+				a, ok := m.alerts[fingerprint]
+				if !ok {
+					a = &Alert{
+						Fingerprint: fingerprint,
+						Rule:        rule.Name,
+						Condition:   rule.Condition,
+						Severity:    rule.Severity,
+						Network:     name,
+						Sequencer:   f.Sequencer,
+						Subject:     f.Subject,
+						State:       StatePending,
+						ActiveSince: now,
+					}
+					m.alerts[fingerprint] = a
+				}
+				a.Summary = f.Summary
+				m.advance(rule, a, now)
+			}
+		}
+	}
+
+	// Alerts whose condition no longer holds are resolved and forgotten
+	for fingerprint, a := range m.alerts {
+		if seen[fingerprint] {
+			continue
+		}
+		delete(m.alerts, fingerprint)
+		if a.State != StateFiring {
+			continue
+		}
+
+		a.State = StateResolved
+		a.ResolvedAt = now
+		m.logger.Info("Alert resolved", "rule", a.Rule, "network", a.Network, "sequencer", a.Sequencer)
+		if a.notified {
+			m.enqueue(m.rule(a.Rule), *a)
+		}
+	}
+}
+
+// advance moves a holding alert towards firing and notifies it when due. The
+// caller must hold m.mu.
+func (m *Manager) advance(rule *Rule, a *Alert, now time.Time) {
+	if a.State == StatePending && now.Sub(a.ActiveSince) >= rule.For {
+		a.State = StateFiring
+		a.FiredAt = now
+		m.logger.Warn("Alert firing",
+			"rule", a.Rule,
+			"severity", a.Severity,
+			"network", a.Network,
+			"sequencer", a.Sequencer,
+			"summary", a.Summary)
+	}
+
+	a.Silenced = m.silenced(a, now)
+	if a.State != StateFiring || a.Silenced {
+		return
+	}
+
+	if !a.notified || (m.repeatInterval > 0 && now.Sub(a.lastNotified) >= m.repeatInterval) {
+		a.notified = true
+		a.lastNotified = now
+		m.enqueue(rule, *a)
+	}
+}
+
+// rule returns a rule by name
+func (m *Manager) rule(name string) *Rule {
+	for _, rule := range m.rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// silenced reports whether an active silence covers an alert. The caller
+// must hold m.mu.
+func (m *Manager) silenced(a *Alert, now time.Time) bool {
+	for _, s := range m.silences {
+		if s.matches(a, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Alerts returns the pending and firing alerts, ordered by network, rule and
+// sequencer
+func (m *Manager) Alerts() []Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(m.alerts))
+	for _, a := range m.alerts {
+		alerts = append(alerts, *a)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Fingerprint < alerts[j].Fingerprint
+	})
+	return alerts
+}
+
+// Silences returns the silences that have not expired, soonest to expire first
+func (m *Manager) Silences() []Silence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	silences := make([]Silence, 0, len(m.silences))
+	for id, s := range m.silences {
+		if !now.Before(s.Until) {
+			delete(m.silences, id)
+			continue
+		}
+		silences = append(silences, *s)
+	}
+	sort.Slice(silences, func(i, j int) bool {
+		return silences[i].Until.Before(silences[j].Until)
+	})
+	return silences
+}
+
+// AddSilence adds a silence and returns it with its ID. A silence must match
+// at least a rule, a network or a sequencer.
+func (m *Manager) AddSilence(s Silence) (Silence, error) {
+	if s.Rule == "" && s.Network == "" && s.Sequencer == "" {
+		return Silence{}, fmt.Errorf("a silence must match a rule, network or sequencer")
+	}
+	if s.Rule != "" && m.rule(s.Rule) == nil {
+		return Silence{}, fmt.Errorf("unknown rule %q", s.Rule)
+	}
+	if !s.Until.After(time.Now()) {
+		return Silence{}, fmt.Errorf("silence has already expired")
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Silence{}, fmt.Errorf("failed to generate silence ID: %w", err)
+	}
+	s.ID = hex.EncodeToString(id)
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now().UTC()
+	}
+
+	m.mu.Lock()
+	m.silences[s.ID] = &s
+	m.mu.Unlock()
+
+	m.logger.Info("Silence added",
+		"id", s.ID,
+		"rule", s.Rule,
+		"network", s.Network,
+		"sequencer", s.Sequencer,
+		"until", s.Until,
+		"created_by", s.CreatedBy)
+	return s, nil
+}
+
+// RemoveSilence removes a silence and reports whether it existed
+func (m *Manager) RemoveSilence(id string) (Silence, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.silences[id]
+	if !ok {
+		return Silence{}, false
+	}
+	delete(m.silences, id)
+
+	m.logger.Info("Silence removed", "id", id)
+	return *s, true
+}
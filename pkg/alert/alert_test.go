@@ -0,0 +1,272 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// setLeader makes the only member of the cluster of newTestNetwork the leader,
+// or leaves the cluster without one
+func setLeader(c *conductortest.Cluster, leader bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.Leader = ""
+	if leader {
+		c.Leader = "sequencer-0"
+	}
+}
+
+// newTestNetwork creates a network of one member of c
+func newTestNetwork(t *testing.T, c *conductortest.Cluster) *network.Network {
+	t.Helper()
+	return network.NewNetwork("devnet", []*sequencer.Sequencer{c.Sequencer(t, "sequencer-0", true)})
+}
+
+// receiver records the payloads posted to a generic webhook
+func receiver(t *testing.T) (string, <-chan Payload) {
+	t.Helper()
+
+	payloads := make(chan Payload, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p Payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("Failed to decode webhook payload: %v", err)
+		}
+		payloads <- p
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, payloads
+}
+
+func expectPayload(t *testing.T, payloads <-chan Payload, state State) {
+	t.Helper()
+
+	select {
+	case p := <-payloads:
+		if p.Status != state || p.Rule != "no-leader" || p.Network != "devnet" {
+			t.Errorf("payload = %+v, want no-leader %s on devnet", p, state)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no %s notification received", state)
+	}
+}
+
+func expectNoPayload(t *testing.T, payloads <-chan Payload) {
+	t.Helper()
+
+	select {
+	case p := <-payloads:
+		t.Errorf("unexpected notification %+v", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	base := func() config.AlertingConfig {
+		return config.AlertingConfig{
+			EvaluationInterval: "15s",
+			RepeatInterval:     "4h",
+			Webhooks:           []config.AlertWebhookConfig{{Name: "ops", URL: "https://hooks.example.com/ops"}},
+			Rules:              []config.AlertRuleConfig{{Name: "no-leader", Condition: "no_leader"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*config.AlertingConfig)
+		want   string
+	}{
+		{"valid", func(*config.AlertingConfig) {}, ""},
+		{"unknown condition", func(c *config.AlertingConfig) { c.Rules[0].Condition = "bogus" }, "unknown condition"},
+		{"unknown severity", func(c *config.AlertingConfig) { c.Rules[0].Severity = "page" }, "unknown severity"},
+		{"unknown webhook", func(c *config.AlertingConfig) { c.Rules[0].Webhooks = []string{"nope"} }, "unknown webhook"},
+		{"bad pattern", func(c *config.AlertingConfig) { c.Rules[0].Networks = []string{"["} }, "invalid network pattern"},
+		{"no webhooks", func(c *config.AlertingConfig) { c.Webhooks = nil }, "no [[alerting.webhooks]]"},
+		{"pagerduty without key", func(c *config.AlertingConfig) { c.Webhooks[0].Format = FormatPagerDuty }, "routing_key"},
+		{"bad silence", func(c *config.AlertingConfig) {
+			c.Silences = []config.AlertSilenceConfig{{Rule: "no-leader", Until: "tomorrow"}}
+		}, "RFC 3339"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			tt.modify(&cfg)
+
+			_, err := New(cfg, conductortest.Networks[*network.Network]{})
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("New() = %v, want success", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("New() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Evaluate(t *testing.T) {
+	url, payloads := receiver(t)
+
+	c := &conductortest.Cluster{}
+	net := newTestNetwork(t, c)
+	update := func() {
+		if err := net.Update(context.Background()); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+	}
+
+	m, err := New(config.AlertingConfig{
+		EvaluationInterval: "15s",
+		RepeatInterval:     "0s",
+		Webhooks:           []config.AlertWebhookConfig{{Name: "ops", URL: url}},
+		Rules:              []config.AlertRuleConfig{{Name: "no-leader", Condition: "no_leader", Severity: "critical"}},
+	}, conductortest.Networks[*network.Network]{"devnet": net})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.deliver(ctx)
+
+	// Unknown statuses never alert
+	m.Evaluate(ctx)
+	if alerts := m.Alerts(); len(alerts) != 0 {
+		t.Fatalf("Alerts() = %+v before the first update, want none", alerts)
+	}
+
+	// Without a leader the alert fires once, however often it is evaluated
+	update()
+	m.Evaluate(ctx)
+	m.Evaluate(ctx)
+	expectPayload(t, payloads, StateFiring)
+	expectNoPayload(t, payloads)
+
+	alerts := m.Alerts()
+	if len(alerts) != 1 || alerts[0].State != StateFiring || alerts[0].Severity != SeverityCritical {
+		t.Fatalf("Alerts() = %+v, want one critical firing alert", alerts)
+	}
+
+	// A leader resolves it
+	setLeader(c, true)
+	update()
+	m.Evaluate(ctx)
+	expectPayload(t, payloads, StateResolved)
+	if alerts := m.Alerts(); len(alerts) != 0 {
+		t.Errorf("Alerts() = %+v after resolving, want none", alerts)
+	}
+
+	// A silenced alert still fires but is neither notified nor resolved
+	silence, err := m.AddSilence(Silence{Network: "devnet", Until: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("AddSilence() = %v", err)
+	}
+	setLeader(c, false)
+	update()
+	m.Evaluate(ctx)
+	if alerts := m.Alerts(); len(alerts) != 1 || !alerts[0].Silenced {
+		t.Errorf("Alerts() = %+v, want one silenced alert", alerts)
+	}
+	expectNoPayload(t, payloads)
+
+	// Removing the silence notifies the alert that is still firing
+	if _, ok := m.RemoveSilence(silence.ID); !ok {
+		t.Fatal("RemoveSilence() found no silence")
+	}
+	m.Evaluate(ctx)
+	expectPayload(t, payloads, StateFiring)
+}
+
+func TestManager_RuleDuration(t *testing.T) {
+	c := &conductortest.Cluster{}
+	net := newTestNetwork(t, c)
+	if err := net.Update(context.Background()); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	m, err := New(config.AlertingConfig{
+		EvaluationInterval: "15s",
+		RepeatInterval:     "4h",
+		Webhooks:           []config.AlertWebhookConfig{{Name: "ops", URL: "http://127.0.0.1:1"}},
+		Rules:              []config.AlertRuleConfig{{Name: "no-leader", Condition: "no_leader", For: "1h"}},
+	}, conductortest.Networks[*network.Network]{"devnet": net})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	m.Evaluate(context.Background())
+	alerts := m.Alerts()
+	if len(alerts) != 1 || alerts[0].State != StatePending {
+		t.Fatalf("Alerts() = %+v, want one pending alert", alerts)
+	}
+	if len(m.queue) != 0 {
+		t.Errorf("%d notifications queued for a pending alert, want none", len(m.queue))
+	}
+}
+
+func TestManager_LeaderFlapping(t *testing.T) {
+	c := &conductortest.Cluster{Leader: "sequencer-0"}
+	net := newTestNetwork(t, c)
+	if err := net.Update(context.Background()); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	m, err := New(config.AlertingConfig{
+		EvaluationInterval: "15s",
+		RepeatInterval:     "4h",
+		Webhooks:           []config.AlertWebhookConfig{{Name: "ops", URL: "http://127.0.0.1:1"}},
+		Rules: []config.AlertRuleConfig{
+			{Name: "flapping", Condition: "leader_flapping", Threshold: 2, Window: "1m"},
+		},
+	}, conductortest.Networks[*network.Network]{"devnet": net})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	now := time.Now()
+	changes := []network.Event{
+		{Type: network.EventLeaderChanged, Network: "devnet", Time: now.Add(-2 * time.Minute)},
+		{Type: network.EventLeaderChanged, Network: "devnet", Time: now.Add(-30 * time.Second)},
+		{Type: network.EventSequencerActivated, Network: "devnet", Time: now},
+	}
+	m.HandleEvents(net, changes)
+	m.Evaluate(context.Background())
+	if alerts := m.Alerts(); len(alerts) != 0 {
+		t.Fatalf("Alerts() = %+v with one change in the window, want none", alerts)
+	}
+
+	m.HandleEvents(net, []network.Event{{Type: network.EventLeaderChanged, Network: "devnet", Time: now}})
+	m.Evaluate(context.Background())
+	if alerts := m.Alerts(); len(alerts) != 1 || alerts[0].Rule != "flapping" {
+		t.Errorf("Alerts() = %+v, want leader flapping", alerts)
+	}
+}
+
+func TestWebhook_PagerDutyPayload(t *testing.T) {
+	hook, err := newWebhook(config.AlertWebhookConfig{
+		Name:       "pd",
+		URL:        "https://events.pagerduty.com/v2/enqueue",
+		Format:     FormatPagerDuty,
+		RoutingKey: "key",
+	})
+	if err != nil {
+		t.Fatalf("newWebhook() = %v", err)
+	}
+
+	a := Alert{Fingerprint: "no-leader/devnet//", Rule: "no-leader", Severity: SeverityCritical, Network: "devnet", State: StateResolved}
+	payload := hook.payload(a).(map[string]any)
+	if payload["event_action"] != "resolve" || payload["dedup_key"] != a.Fingerprint || payload["routing_key"] != "key" {
+		t.Errorf("payload = %+v, want a resolve event deduplicated by fingerprint", payload)
+	}
+}
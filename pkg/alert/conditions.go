@@ -0,0 +1,165 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Condition identifies what a rule checks
+type Condition string
+
+// Conditions a rule may check
+const (
+	// ConditionNoLeader holds while no reachable sequencer is conductor leader
+	ConditionNoLeader Condition = "no_leader"
+	// ConditionNoActiveSequencer holds while no sequencer is sequencing
+	ConditionNoActiveSequencer Condition = "no_active_sequencer"
+	// ConditionUnsafeHeadStalled holds while the active sequencer's unsafe
+	// head has not advanced within the configured number of block times
+	ConditionUnsafeHeadStalled Condition = "unsafe_head_stalled"
+	// ConditionFollowerLagging holds for each sequencer whose unsafe head
+	// trails the active sequencer by more than the maximum follower lag
+	ConditionFollowerLagging Condition = "follower_lagging"
+	// ConditionLeaderFlapping holds while leadership changed at least
+	// threshold times within the window
+	ConditionLeaderFlapping Condition = "leader_flapping"
+	// ConditionInvariantViolation holds for each broken cluster invariant
+	ConditionInvariantViolation Condition = "invariant_violation"
+	// ConditionSequencerUnhealthy holds for each sequencer reporting itself
+	// unhealthy
+	ConditionSequencerUnhealthy Condition = "sequencer_unhealthy"
+	// ConditionSequencerUnreachable holds for each sequencer whose probes all
+	// fail
+	ConditionSequencerUnreachable Condition = "sequencer_unreachable"
+)
+
+// Conditions lists every condition
+var Conditions = []Condition{
+	ConditionNoLeader,
+	ConditionNoActiveSequencer,
+	ConditionUnsafeHeadStalled,
+	ConditionFollowerLagging,
+	ConditionLeaderFlapping,
+	ConditionInvariantViolation,
+	ConditionSequencerUnhealthy,
+	ConditionSequencerUnreachable,
+}
+
+// Leader flapping defaults
+const (
+	DefaultFlappingThreshold = 3
+	DefaultFlappingWindow    = 10 * time.Minute
+)
+
+// finding is one instance of a holding condition. Subject distinguishes
+// several findings for the same sequencer, such as different invariants.
+type finding struct {
+	Sequencer string
+	Subject   string
+	Summary   string
+}
+
+// evaluate returns the findings of a rule for a network
+func (m *Manager) evaluate(rule *Rule, net *network.Network, now time.Time) []finding {
+	// Nothing can be said about a network whose statuses are all unknown
+	var known []*sequencer.Sequencer
+	for _, seq := range net.Sequencers() {
+		if seq.Status().Reachability() != sequencer.ReachabilityUnknown {
+			known = append(known, seq)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	switch rule.Condition {
+	case ConditionNoLeader:
+		if net.ConductorLeader() != nil {
+			return nil
+		}
+		return []finding{{Summary: fmt.Sprintf("No conductor leader in %s", net.Name())}}
+
+	case ConditionNoActiveSequencer:
+		if net.ActiveSequencer() != nil {
+			return nil
+		}
+		return []finding{{Summary: fmt.Sprintf("No active sequencer in %s", net.Name())}}
+
+	case ConditionUnsafeHeadStalled:
+		active := net.ActiveSequencer()
+		if active == nil {
+			return nil
+		}
+		stats, ok := net.HeadStats(active.ID())
+		if !ok || !stats.Stalled {
+			return nil
+		}
+		return []finding{{
+			Sequencer: active.ID(),
+			Summary: fmt.Sprintf("Active sequencer %s has not produced a block since %d, %s ago",
+				active.ID(), stats.Number, stats.ObservedAt.Sub(stats.Since).Round(time.Second)),
+		}}
+
+	case ConditionFollowerLagging:
+		var findings []finding
+		for _, seq := range known {
+			if stats, ok := net.HeadStats(seq.ID()); ok && stats.Lagging {
+				findings = append(findings, finding{
+					Sequencer: seq.ID(),
+					Summary: fmt.Sprintf("Sequencer %s is %d blocks behind %s",
+						seq.ID(), stats.Lag, stats.Reference),
+				})
+			}
+		}
+		return findings
+
+	case ConditionLeaderFlapping:
+		changes := m.countLeaderChanges(net.Name(), now.Add(-rule.Window))
+		if changes < rule.Threshold {
+			return nil
+		}
+		return []finding{{Summary: fmt.Sprintf("Leadership of %s changed %d times in the last %s",
+			net.Name(), changes, rule.Window)}}
+
+	case ConditionInvariantViolation:
+		var findings []finding
+		for _, v := range net.Invariants() {
+			findings = append(findings, finding{
+				Sequencer: strings.Join(v.Sequencers, ","),
+				Subject:   string(v.Kind),
+				Summary:   v.Message,
+			})
+		}
+		return findings
+
+	case ConditionSequencerUnhealthy:
+		var findings []finding
+		for _, seq := range known {
+			if status := seq.Status(); !status.SequencerHealthy && status.Reachability() != sequencer.ReachabilityUnreachable {
+				findings = append(findings, finding{
+					Sequencer: seq.ID(),
+					Summary:   fmt.Sprintf("Sequencer %s reports itself unhealthy", seq.ID()),
+				})
+			}
+		}
+		return findings
+
+	case ConditionSequencerUnreachable:
+		var findings []finding
+		for _, seq := range known {
+			if status := seq.Status(); status.Reachability() == sequencer.ReachabilityUnreachable {
+				findings = append(findings, finding{
+					Sequencer: seq.ID(),
+					Summary:   fmt.Sprintf("Sequencer %s is unreachable, all %d probes fail", seq.ID(), len(status.Failing())),
+				})
+			}
+		}
+		return findings
+	}
+
+	return nil
+}
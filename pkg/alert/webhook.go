@@ -0,0 +1,234 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+// Webhook payload formats
+const (
+	// FormatGeneric posts the alert as a JSON object
+	FormatGeneric = "generic"
+	// FormatSlack posts a Slack incoming webhook message
+	FormatSlack = "slack"
+	// FormatPagerDuty posts a PagerDuty Events API v2 event
+	FormatPagerDuty = "pagerduty"
+)
+
+// Delivery retries
+const (
+	deliveryAttempts = 3
+	deliveryBackoff  = time.Second
+)
+
+// webhook is a configured notification target
+type webhook struct {
+	name       string
+	url        string
+	format     string
+	routingKey string
+}
+
+// newWebhook validates a webhook configuration
+func newWebhook(cfg config.AlertWebhookConfig) (*webhook, error) {
+	hook := &webhook{
+		name:       cfg.Name,
+		url:        cfg.URL,
+		format:     cfg.Format,
+		routingKey: cfg.RoutingKey,
+	}
+
+	if hook.name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if u, err := url.Parse(hook.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("webhook %q: url must be an http or https URL", hook.name)
+	}
+
+	switch hook.format {
+	case "":
+		hook.format = FormatGeneric
+	case FormatGeneric, FormatSlack:
+	case FormatPagerDuty:
+		if hook.routingKey == "" {
+			return nil, fmt.Errorf("webhook %q: routing_key is required for pagerduty", hook.name)
+		}
+	default:
+		return nil, fmt.Errorf("webhook %q: unknown format %q (expected generic, slack or pagerduty)", hook.name, hook.format)
+	}
+
+	return hook, nil
+}
+
+// notification is an alert state change waiting for delivery
+type notification struct {
+	alert    Alert
+	webhooks []*webhook
+}
+
+// Payload is the body posted to generic webhooks
+type Payload struct {
+	Status      State     `json:"status"`
+	Fingerprint string    `json:"fingerprint"`
+	Rule        string    `json:"rule"`
+	Condition   Condition `json:"condition"`
+	Severity    Severity  `json:"severity"`
+	Network     string    `json:"network"`
+	Sequencer   string    `json:"sequencer,omitempty"`
+	Summary     string    `json:"summary"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at,omitzero"`
+}
+
+// enqueue queues notifications of an alert to the rule's webhooks. The
+// caller must hold m.mu.
+func (m *Manager) enqueue(rule *Rule, a Alert) {
+	webhooks := m.webhooks
+	if rule != nil && len(rule.Webhooks) > 0 {
+		webhooks = nil
+		for _, hook := range m.webhooks {
+			for _, name := range rule.Webhooks {
+				if hook.name == name {
+					webhooks = append(webhooks, hook)
+				}
+			}
+		}
+	}
+
+	select {
+	case m.queue <- notification{alert: a, webhooks: webhooks}:
+	default:
+		m.logger.Error("Alert notification queue full, dropping notification",
+			"rule", a.Rule,
+			"network", a.Network,
+			"state", a.State)
+	}
+}
+
+// deliver sends queued notifications until ctx is cancelled
+func (m *Manager) deliver(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-m.queue:
+			for _, hook := range n.webhooks {
+				if err := m.send(ctx, hook, n.alert); err != nil {
+					m.logger.Error("Failed to deliver alert notification",
+						"webhook", hook.name,
+						"rule", n.alert.Rule,
+						"network", n.alert.Network,
+						"state", n.alert.State,
+						"error", err)
+				}
+			}
+		}
+	}
+}
+
+// send posts an alert to a webhook, retrying failed attempts
+func (m *Manager) send(ctx context.Context, hook *webhook, a Alert) error {
+	body, err := json.Marshal(hook.payload(a))
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = m.post(ctx, hook.url, body)
+		if err == nil || attempt == deliveryAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(deliveryBackoff * time.Duration(attempt)):
+		}
+	}
+}
+
+// post sends a single webhook request
+func (m *Manager) post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// payload builds the request body of an alert in the webhook's format
+func (hook *webhook) payload(a Alert) any {
+	switch hook.format {
+	case FormatSlack:
+		return map[string]string{"text": slackText(a)}
+
+	case FormatPagerDuty:
+		action := "trigger"
+		if a.State == StateResolved {
+			action = "resolve"
+		}
+		source := a.Network
+		if a.Sequencer != "" {
+			source = a.Network + "/" + a.Sequencer
+		}
+		return map[string]any{
+			"routing_key":  hook.routingKey,
+			"event_action": action,
+			"dedup_key":    a.Fingerprint,
+			"payload": map[string]any{
+				"summary":   a.Summary,
+				"source":    source,
+				"severity":  string(a.Severity),
+				"timestamp": a.ActiveSince.UTC().Format(time.RFC3339),
+				"component": a.Sequencer,
+				"group":     a.Network,
+				"class":     string(a.Condition),
+				"custom_details": map[string]string{
+					"rule": a.Rule,
+				},
+			},
+		}
+
+	default:
+		return Payload{
+			Status:      a.State,
+			Fingerprint: a.Fingerprint,
+			Rule:        a.Rule,
+			Condition:   a.Condition,
+			Severity:    a.Severity,
+			Network:     a.Network,
+			Sequencer:   a.Sequencer,
+			Summary:     a.Summary,
+			StartsAt:    a.ActiveSince.UTC(),
+			EndsAt:      a.ResolvedAt.UTC(),
+		}
+	}
+}
+
+// slackText formats an alert as a one-line Slack message
+func slackText(a Alert) string {
+	if a.State == StateResolved {
+		return fmt.Sprintf("[RESOLVED] %s on %s: %s", a.Rule, a.Network, a.Summary)
+	}
+	return fmt.Sprintf("[FIRING:%s] %s on %s: %s", a.Severity, a.Rule, a.Network, a.Summary)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/golem-base/seqctl/pkg/config"
 	"github.com/golem-base/seqctl/pkg/network"
 	"github.com/golem-base/seqctl/pkg/repository"
+	"github.com/golem-base/seqctl/pkg/sequencer"
 )
 
 // App is the main application container that holds all services and configuration
@@ -36,3 +37,19 @@ func (a *App) RefreshNetworks(ctx context.Context) error {
 func (a *App) ListNetworks(ctx context.Context) (map[string]*network.Network, error) {
 	return a.repository.ListNetworks(ctx)
 }
+
+// RecordVoting records the suffrage of a sequencer with the provider, if it
+// keeps one
+func (a *App) RecordVoting(ctx context.Context, seq *sequencer.Sequencer, voting bool) (string, error) {
+	return a.repository.RecordVoting(ctx, seq, voting)
+}
+
+// Subscribe registers a handler notified whenever a network's sequencer statuses change
+func (a *App) Subscribe(handler network.ChangeHandler) {
+	a.repository.Subscribe(handler)
+}
+
+// SubscribeEvents registers a handler notified of the events detected in networks
+func (a *App) SubscribeEvents(handler network.EventHandler) {
+	a.repository.SubscribeEvents(handler)
+}
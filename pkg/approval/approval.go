@@ -0,0 +1,254 @@
+// Package approval holds dangerous control actions until a second operator
+// approves them. Requests are kept in memory only, a restart drops pending
+// requests and they have to be made again.
+package approval
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Actions that require approval when approvals are enabled
+const (
+	ActionOverrideLeader = "override-leader"
+	ActionForceActive    = "force-active"
+)
+
+// DefaultTTL is how long a request waits for approval unless configured
+const DefaultTTL = 15 * time.Minute
+
+// retention is how long settled and expired requests stay listed
+const retention = 24 * time.Hour
+
+// Status of a request
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // Waiting for a second operator
+	StatusApproved Status = "approved" // Approved, the action is running
+	StatusExecuted Status = "executed"
+	StatusFailed   Status = "failed"
+	StatusRejected Status = "rejected"
+	StatusExpired  Status = "expired"
+)
+
+// Errors returned when deciding on a request
+var (
+	ErrNotFound     = errors.New("approval request not found")
+	ErrSelfApproval = errors.New("a request must be approved by another operator than the one who made it")
+	ErrNotPending   = errors.New("approval request is not pending")
+)
+
+// Identity is an operator as authenticated. The method tells apart API tokens
+// and JWT users of the same name, which are different operators.
+type Identity struct {
+	Name   string
+	Method string // auth.Method the operator authenticated with
+}
+
+// String returns the identity as "name (method)"
+func (i Identity) String() string {
+	return fmt.Sprintf("%s (%s)", i.Name, i.Method)
+}
+
+// Request is a dangerous action waiting for, or decided by, a second operator
+type Request struct {
+	ID          string
+	Action      string
+	Network     string
+	Sequencer   string
+	Params      json.RawMessage // Request body of the action
+	Effect      string          // What the action does, from its dry run
+	RequestedBy Identity
+	RequestedAt time.Time
+	ExpiresAt   time.Time
+	Status      Status
+	ApprovedBy  Identity
+	RejectedBy  Identity
+	DecidedAt   time.Time // When approved, rejected or expired
+	Error       string    // Error of a failed action
+}
+
+// Store keeps approval requests
+type Store struct {
+	ttl    time.Duration
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	requests map[string]*Request // Keyed by ID
+}
+
+// New creates a store whose requests expire after ttl
+func New(ttl time.Duration) *Store {
+	return &Store{
+		ttl:      ttl,
+		now:      time.Now,
+		logger:   slog.Default().With(slog.String("component", "approval")),
+		requests: make(map[string]*Request),
+	}
+}
+
+// TTL returns how long a request waits for approval
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Create adds a pending request and returns it with its ID and expiry
+func (s *Store) Create(req Request) (Request, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Request{}, fmt.Errorf("failed to generate approval ID: %w", err)
+	}
+
+	now := s.now().UTC()
+	req.ID = hex.EncodeToString(id)
+	req.RequestedAt = now
+	req.ExpiresAt = now.Add(s.ttl)
+	req.Status = StatusPending
+
+	s.mu.Lock()
+	s.requests[req.ID] = &req
+	s.mu.Unlock()
+
+	s.logger.Info("Approval requested",
+		"id", req.ID,
+		"action", req.Action,
+		"network", req.Network,
+		"sequencer", req.Sequencer,
+		"requested_by", req.RequestedBy.String(),
+		"expires_at", req.ExpiresAt)
+	return req, nil
+}
+
+// Get returns a request
+func (s *Store) Get(id string) (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire()
+	req, ok := s.requests[id]
+	if !ok {
+		return Request{}, false
+	}
+	return *req, true
+}
+
+// List returns the pending requests and those settled within the last day,
+// newest first
+func (s *Store) List() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire()
+	requests := make([]Request, 0, len(s.requests))
+	for _, req := range s.requests {
+		requests = append(requests, *req)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].RequestedAt.After(requests[j].RequestedAt)
+	})
+	return requests
+}
+
+// Approve marks a pending request approved by approver, who must not be the
+// operator who made it, by name and authentication method. The caller then
+// runs the action and reports its outcome with Complete. A request can only
+// be approved once.
+func (s *Store) Approve(id string, approver Identity) (Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, err := s.pending(id)
+	if err != nil {
+		return Request{}, err
+	}
+	if approver == req.RequestedBy {
+		return Request{}, ErrSelfApproval
+	}
+
+	req.Status = StatusApproved
+	req.ApprovedBy = approver
+	req.DecidedAt = s.now().UTC()
+
+	s.logger.Info("Approval granted",
+		"id", id,
+		"action", req.Action,
+		"requested_by", req.RequestedBy.String(),
+		"approved_by", approver.String())
+	return *req, nil
+}
+
+// Reject marks a pending request rejected. Any operator may reject a
+// request, including the one who made it.
+func (s *Store) Reject(id string, by Identity) (Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, err := s.pending(id)
+	if err != nil {
+		return Request{}, err
+	}
+
+	req.Status = StatusRejected
+	req.RejectedBy = by
+	req.DecidedAt = s.now().UTC()
+
+	s.logger.Info("Approval rejected", "id", id, "action", req.Action, "rejected_by", by.String())
+	return *req, nil
+}
+
+// Complete records the outcome of running an approved request's action
+func (s *Store) Complete(id string, actionErr error) (Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, ok := s.requests[id]
+	if !ok {
+		return Request{}, ErrNotFound
+	}
+	if req.Status != StatusApproved {
+		return Request{}, fmt.Errorf("%w: request is %s, not %s", ErrNotPending, req.Status, StatusApproved)
+	}
+
+	req.Status = StatusExecuted
+	if actionErr != nil {
+		req.Status, req.Error = StatusFailed, actionErr.Error()
+	}
+	return *req, nil
+}
+
+// pending returns the request if it still waits for a decision. The lock
+// must be held.
+func (s *Store) pending(id string) (*Request, error) {
+	s.expire()
+	req, ok := s.requests[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if req.Status != StatusPending {
+		return nil, fmt.Errorf("%w: request is %s", ErrNotPending, req.Status)
+	}
+	return req, nil
+}
+
+// expire marks pending requests past their expiry as expired and drops
+// requests settled longer ago than the retention. The lock must be held.
+func (s *Store) expire() {
+	now := s.now()
+	for id, req := range s.requests {
+		if req.Status == StatusPending && !now.Before(req.ExpiresAt) {
+			req.Status, req.DecidedAt = StatusExpired, req.ExpiresAt
+		}
+		if req.Status != StatusPending && req.Status != StatusApproved && now.Sub(req.DecidedAt) > retention {
+			delete(s.requests, id)
+		}
+	}
+}
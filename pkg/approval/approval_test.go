@@ -0,0 +1,126 @@
+package approval
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestStore returns a store whose clock is advanced by the returned
+// function
+func newTestStore(ttl time.Duration) (*Store, func(time.Duration)) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New(ttl)
+	s.now = func() time.Time { return now }
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+// Operators of the tests
+var (
+	alice = Identity{Name: "alice", Method: "oidc"}
+	bob   = Identity{Name: "bob", Method: "oidc"}
+	carol = Identity{Name: "carol", Method: "token"}
+)
+
+func TestStore_Approve(t *testing.T) {
+	s, _ := newTestStore(time.Minute)
+
+	req, err := s.Create(Request{Action: ActionForceActive, Network: "devnet", Sequencer: "seq-0", RequestedBy: alice})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	if req.ID == "" || req.Status != StatusPending {
+		t.Fatalf("Create() = %+v, want a pending request with an ID", req)
+	}
+
+	if _, err := s.Approve(req.ID, alice); !errors.Is(err, ErrSelfApproval) {
+		t.Errorf("Approve() by the requester = %v, want ErrSelfApproval", err)
+	}
+
+	approved, err := s.Approve(req.ID, bob)
+	if err != nil {
+		t.Fatalf("Approve() = %v", err)
+	}
+	if approved.Status != StatusApproved || approved.ApprovedBy != bob {
+		t.Errorf("Approve() = %+v, want approved by bob", approved)
+	}
+
+	if _, err := s.Approve(req.ID, carol); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Second Approve() = %v, want ErrNotPending", err)
+	}
+
+	done, err := s.Complete(req.ID, errors.New("rpc failed"))
+	if err != nil {
+		t.Fatalf("Complete() = %v", err)
+	}
+	if done.Status != StatusFailed || done.Error != "rpc failed" {
+		t.Errorf("Complete() = %+v, want failed with the action error", done)
+	}
+
+	if _, err := s.Approve("unknown", bob); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Approve() of an unknown request = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_Approve_SameNameOtherMethod(t *testing.T) {
+	s, _ := newTestStore(time.Minute)
+
+	req, err := s.Create(Request{Action: ActionForceActive, RequestedBy: alice})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+
+	// An API token named like the requester is another operator
+	token := Identity{Name: "alice", Method: "token"}
+	approved, err := s.Approve(req.ID, token)
+	if err != nil {
+		t.Fatalf("Approve() by token alice = %v", err)
+	}
+	if approved.RequestedBy != alice || approved.ApprovedBy != token {
+		t.Errorf("Approve() = %+v, want requested by JWT user alice and approved by token alice", approved)
+	}
+}
+
+func TestStore_Reject(t *testing.T) {
+	s, _ := newTestStore(time.Minute)
+
+	req, err := s.Create(Request{Action: ActionOverrideLeader, RequestedBy: alice})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+
+	// The requester may withdraw their own request
+	rejected, err := s.Reject(req.ID, alice)
+	if err != nil {
+		t.Fatalf("Reject() = %v", err)
+	}
+	if rejected.Status != StatusRejected || rejected.RejectedBy != alice {
+		t.Errorf("Reject() = %+v, want rejected by alice", rejected)
+	}
+
+	if _, err := s.Approve(req.ID, bob); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Approve() after Reject() = %v, want ErrNotPending", err)
+	}
+}
+
+func TestStore_Expiry(t *testing.T) {
+	s, advance := newTestStore(time.Minute)
+
+	req, err := s.Create(Request{Action: ActionForceActive, RequestedBy: alice})
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+
+	advance(time.Minute)
+	if _, err := s.Approve(req.ID, bob); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Approve() after expiry = %v, want ErrNotPending", err)
+	}
+	if got, _ := s.Get(req.ID); got.Status != StatusExpired {
+		t.Errorf("Get() = %s, want %s", got.Status, StatusExpired)
+	}
+
+	advance(retention + time.Second)
+	if got := s.List(); len(got) != 0 {
+		t.Errorf("List() after retention = %d requests, want none", len(got))
+	}
+}
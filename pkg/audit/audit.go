@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Results of an audited action
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultDenied  = "denied"
+)
+
+// Query limits
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// maxLineSize bounds a single entry when reading the log back
+const maxLineSize = 1 << 20
+
+// Snapshot is the persisted form of a sequencer status
+type Snapshot struct {
+	ConductorActive  bool   `json:"conductor_active"`
+	ConductorLeader  bool   `json:"conductor_leader"`
+	ConductorPaused  bool   `json:"conductor_paused"`
+	ConductorStopped bool   `json:"conductor_stopped"`
+	SequencerHealthy bool   `json:"sequencer_healthy"`
+	SequencerActive  bool   `json:"sequencer_active"`
+	UnsafeL2         uint64 `json:"unsafe_l2"`
+}
+
+// SnapshotOf captures a sequencer status for the audit log
+func SnapshotOf(status sequencer.Status) Snapshot {
+	snapshot := Snapshot{
+		ConductorActive:  status.ConductorActive,
+		ConductorLeader:  status.ConductorLeader,
+		ConductorPaused:  status.ConductorPaused,
+		ConductorStopped: status.ConductorStopped,
+		SequencerHealthy: status.SequencerHealthy,
+		SequencerActive:  status.SequencerActive,
+	}
+	if status.UnsafeL2 != nil {
+		snapshot.UnsafeL2 = status.UnsafeL2.Number
+	}
+	return snapshot
+}
+
+// Approval records the two-person approval of an action: the operator who
+// requested it and the one who approved or rejected it, each by name and
+// authentication method
+type Approval struct {
+	ID                string `json:"id"`
+	Action            string `json:"action"`
+	Status            string `json:"status"`
+	RequestedBy       string `json:"requested_by"`
+	RequestedByMethod string `json:"requested_by_method"`
+	ApprovedBy        string `json:"approved_by,omitempty"`
+	ApprovedByMethod  string `json:"approved_by_method,omitempty"`
+	RejectedBy        string `json:"rejected_by,omitempty"`
+	RejectedByMethod  string `json:"rejected_by_method,omitempty"`
+}
+
+// Entry records a single mutating operation
+type Entry struct {
+	ID         uint64              `json:"id"`
+	Time       time.Time           `json:"time"`
+	RequestID  string              `json:"request_id,omitempty"`
+	Actor      string              `json:"actor"`
+	AuthMethod string              `json:"auth_method"`
+	Action     string              `json:"action"`
+	Network    string              `json:"network,omitempty"`
+	Sequencer  string              `json:"sequencer,omitempty"`
+	Request    json.RawMessage     `json:"request,omitempty"`
+	Before     map[string]Snapshot `json:"before,omitempty"` // Keyed by sequencer ID
+	After      map[string]Snapshot `json:"after,omitempty"`  // Keyed by sequencer ID
+	Result     string              `json:"result"`
+	Status     int                 `json:"status"`
+	Error      string              `json:"error,omitempty"`
+	DryRun     bool                `json:"dry_run,omitempty"`  // Checked and described, not performed
+	Approval   *Approval           `json:"approval,omitempty"` // Set for actions held for approval
+}
+
+// Filter selects entries in Query. Zero fields match everything.
+type Filter struct {
+	Actor     string
+	Action    string
+	Network   string
+	Sequencer string
+	Result    string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// matches reports whether an entry passes the filter
+func (f Filter) matches(e *Entry) bool {
+	switch {
+	case f.Actor != "" && e.Actor != f.Actor,
+		f.Action != "" && e.Action != f.Action,
+		f.Network != "" && e.Network != f.Network,
+		f.Sequencer != "" && e.Sequencer != f.Sequencer,
+		f.Result != "" && e.Result != f.Result,
+		!f.Since.IsZero() && e.Time.Before(f.Since),
+		!f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// Store is an append-only audit log kept as one JSON object per line
+type Store struct {
+	path   string
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	file   *os.File
+	nextID uint64
+}
+
+// Open opens or creates the audit log at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	s := &Store{
+		path:   path,
+		file:   file,
+		nextID: 1,
+		logger: slog.Default().With(slog.String("component", "audit")),
+	}
+
+	// Continue numbering after the last recorded entry
+	if err := s.scan(func(e *Entry) {
+		if e.ID >= s.nextID {
+			s.nextID = e.ID + 1
+		}
+	}); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// Terminate a partial last line so the next entry starts on its own
+	if err := terminateLastLine(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	s.logger.Info("Audit log opened", "path", path, "entries", s.nextID-1)
+	return s, nil
+}
+
+// Append assigns the entry an ID and durably appends it to the log
+func (s *Store) Append(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = s.nextID
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+
+	s.nextID++
+	return nil
+}
+
+// Query returns matching entries, newest first, along with the total number
+// of matches before the limit was applied
+func (s *Store) Query(filter Filter, visible func(*Entry) bool) ([]Entry, int, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	var matches []Entry
+	err := s.scan(func(e *Entry) {
+		if filter.matches(e) && (visible == nil || visible(e)) {
+			matches = append(matches, *e)
+		}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(matches)
+
+	// Entries are stored oldest first
+	result := make([]Entry, 0, min(limit, total))
+	for i := total - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, matches[i])
+	}
+
+	return result, total, nil
+}
+
+// Close closes the audit log
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// terminateLastLine appends a newline if the file does not end with one
+func terminateLastLine(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+
+	if _, err := file.Write([]byte{'\n'}); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// scan reads every entry in the log. Lines that cannot be decoded, such as a
+// partial write after a crash, are skipped.
+func (s *Store) scan(fn func(*Entry)) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			s.logger.Warn("Skipping malformed audit entry", "line", line, "error", err)
+			continue
+		}
+		fn(&entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return nil
+}
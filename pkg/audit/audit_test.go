@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_AppendAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []*Entry{
+		{Time: base, Actor: "alice", Action: "pause", Network: "devnet", Sequencer: "seq-0", Result: ResultSuccess},
+		{Time: base.Add(time.Minute), Actor: "bob", Action: "halt", Network: "devnet", Sequencer: "seq-1", Result: ResultDenied},
+		{Time: base.Add(2 * time.Minute), Actor: "alice", Action: "resume", Network: "testnet", Sequencer: "seq-2", Result: ResultFailure,
+			Request: json.RawMessage(`{"override":true}`)},
+	}
+	for _, e := range entries {
+		if err := s.Append(e); err != nil {
+			t.Fatalf("Failed to append entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		visible func(*Entry) bool
+		wantIDs []uint64
+	}{
+		{"all, newest first", Filter{}, nil, []uint64{3, 2, 1}},
+		{"by actor", Filter{Actor: "alice"}, nil, []uint64{3, 1}},
+		{"by network", Filter{Network: "devnet"}, nil, []uint64{2, 1}},
+		{"by result", Filter{Result: ResultDenied}, nil, []uint64{2}},
+		{"by time range", Filter{Since: base.Add(time.Minute), Until: base.Add(2 * time.Minute)}, nil, []uint64{2}},
+		{"limit", Filter{Limit: 1}, nil, []uint64{3}},
+		{"visibility", Filter{}, func(e *Entry) bool { return e.Network == "testnet" }, []uint64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := s.Query(tt.filter, tt.visible)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.wantIDs), len(got))
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("Expected entry %d to have ID %d, got %d", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+
+	_, total, err := s.Query(Filter{Limit: 1}, nil)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Expected total 3, got %d", total)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+}
+
+func TestStore_ReopenContinuesNumbering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	if err := s.Append(&Entry{Actor: "alice", Action: "pause", Result: ResultSuccess}); err != nil {
+		t.Fatalf("Failed to append entry: %v", err)
+	}
+	s.Close()
+
+	// Simulate a torn write from a crash
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	f.WriteString(`{"id":2,"actor":`)
+	f.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer s.Close()
+
+	entry := &Entry{Actor: "bob", Action: "resume", Result: ResultSuccess}
+	if err := s.Append(entry); err != nil {
+		t.Fatalf("Failed to append entry: %v", err)
+	}
+	if entry.ID != 2 {
+		t.Errorf("Expected ID 2, got %d", entry.ID)
+	}
+
+	got, total, err := s.Query(Filter{}, nil)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if total != 2 || got[0].Actor != "bob" {
+		t.Errorf("Expected 2 entries with bob newest, got %d entries", total)
+	}
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+// Method identifies how a principal was authenticated
+type Method string
+
+// Authentication methods
+const (
+	MethodToken     Method = "token"
+	MethodOIDC      Method = "oidc"
+	MethodAnonymous Method = "anonymous"
+)
+
+// Errors returned by Authenticate
+var (
+	ErrMissingCredentials = errors.New("missing bearer token")
+	ErrInvalidCredentials = errors.New("invalid bearer token")
+)
+
+// Principal is an authenticated caller
+type Principal struct {
+	Name   string   // Token name or JWT username claim
+	Method Method   // How the caller was authenticated
+	Groups []string // Groups from the JWT groups claim
+}
+
+// Anonymous is the principal attached to requests when authentication is disabled
+var Anonymous = &Principal{Name: "anonymous", Method: MethodAnonymous}
+
+type contextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal attached to ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(*Principal)
+	return principal, ok
+}
+
+// Authenticator validates bearer credentials against configured API tokens
+// and, if configured, OIDC-issued JWTs, and grants roles to the resulting
+// principals
+type Authenticator struct {
+	enabled  bool
+	tokens   map[[sha256.Size]byte]string // Token digest -> name
+	verifier *jwtVerifier
+	policy   *policy
+	logger   *slog.Logger
+}
+
+// New creates an authenticator from the auth configuration
+func New(ctx context.Context, cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		enabled: cfg.Enabled,
+		tokens:  make(map[[sha256.Size]byte]string, len(cfg.Tokens)),
+		logger:  slog.Default().With(slog.String("component", "auth")),
+	}
+
+	if !cfg.Enabled {
+		a.logger.Warn("API authentication is disabled, all requests are anonymous")
+		return a, nil
+	}
+
+	for i, token := range cfg.Tokens {
+		switch {
+		case token.Name == "":
+			return nil, fmt.Errorf("auth.tokens[%d]: name is required", i)
+		case token.Token == "":
+			return nil, fmt.Errorf("auth token %q: token is required", token.Name)
+		}
+
+		digest := sha256.Sum256([]byte(token.Token))
+		if other, ok := a.tokens[digest]; ok {
+			return nil, fmt.Errorf("auth token %q: token is already used by %q", token.Name, other)
+		}
+		a.tokens[digest] = token.Name
+	}
+
+	if cfg.OIDC.Issuer != "" || cfg.OIDC.JWKSFile != "" {
+		verifier, err := newJWTVerifier(ctx, cfg.OIDC)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up OIDC verification: %w", err)
+		}
+		a.verifier = verifier
+	}
+
+	if len(a.tokens) == 0 && a.verifier == nil {
+		return nil, fmt.Errorf("auth is enabled but neither [[auth.tokens]] nor [auth.oidc] is configured")
+	}
+
+	policy, err := newPolicy(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role configuration: %w", err)
+	}
+	a.policy = policy
+
+	a.logger.Info("API authentication enabled",
+		"tokens", len(a.tokens),
+		"bindings", len(cfg.Bindings),
+		"default_role", policy.defaultRole,
+		"oidc_issuer", cfg.OIDC.Issuer,
+		"oidc_jwks_file", cfg.OIDC.JWKSFile)
+
+	return a, nil
+}
+
+// Enabled reports whether requests must carry credentials
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// Authenticate resolves a raw bearer credential to a principal. When
+// authentication is disabled every caller is Anonymous.
+func (a *Authenticator) Authenticate(ctx context.Context, credential string) (*Principal, error) {
+	if !a.enabled {
+		return Anonymous, nil
+	}
+	if credential == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	digest := sha256.Sum256([]byte(credential))
+	for known, name := range a.tokens {
+		if subtle.ConstantTimeCompare(known[:], digest[:]) == 1 {
+			return &Principal{Name: name, Method: MethodToken}, nil
+		}
+	}
+
+	// Only attempt JWT validation on credentials that look like a JWT
+	if a.verifier != nil && strings.Count(credential, ".") == 2 {
+		principal, err := a.verifier.verify(ctx, credential)
+		if err != nil {
+			a.logger.Debug("JWT validation failed", "error", err)
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+		return principal, nil
+	}
+
+	return nil, ErrInvalidCredentials
+}
+
+// RoleFor returns the role granted to the principal on a network. An empty
+// network name only considers grants that apply to all networks. When
+// authentication is disabled every caller is an admin.
+func (a *Authenticator) RoleFor(principal *Principal, network string) Role {
+	if !a.enabled {
+		return RoleAdmin
+	}
+	return a.policy.roleFor(principal, network)
+}
+
+// MaxRole returns the highest role granted to the principal on any network
+func (a *Authenticator) MaxRole(principal *Principal) Role {
+	if !a.enabled {
+		return RoleAdmin
+	}
+	return a.policy.maxRole(principal)
+}
+
+// BearerToken extracts the token from an Authorization header value
+func BearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// claimsPrincipal builds a principal from validated JWT claims
+func claimsPrincipal(claims jwt.MapClaims, usernameClaim, groupsClaim string) (*Principal, error) {
+	name, _ := claims[usernameClaim].(string)
+	if name == "" {
+		return nil, fmt.Errorf("claim %q is missing", usernameClaim)
+	}
+
+	principal := &Principal{Name: name, Method: MethodOIDC}
+
+	switch groups := claims[groupsClaim].(type) {
+	case []any:
+		for _, group := range groups {
+			if s, ok := group.(string); ok {
+				principal.Groups = append(principal.Groups, s)
+			}
+		}
+	case string:
+		principal.Groups = []string{groups}
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+func TestAuthenticator_Disabled(t *testing.T) {
+	a, err := New(context.Background(), config.AuthConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	principal, err := a.Authenticate(context.Background(), "")
+	if err != nil || principal != Anonymous {
+		t.Errorf("Expected anonymous principal, got %+v (err=%v)", principal, err)
+	}
+}
+
+func TestAuthenticator_Tokens(t *testing.T) {
+	a, err := New(context.Background(), config.AuthConfig{
+		Enabled: true,
+		Tokens:  []config.APITokenConfig{{Name: "ci", Token: "s3cret"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	principal, err := a.Authenticate(context.Background(), "s3cret")
+	if err != nil {
+		t.Fatalf("Expected valid token, got %v", err)
+	}
+	if principal.Name != "ci" || principal.Method != MethodToken {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	if _, err := a.Authenticate(context.Background(), ""); !errors.Is(err, ErrMissingCredentials) {
+		t.Errorf("Expected ErrMissingCredentials, got %v", err)
+	}
+	if _, err := a.Authenticate(context.Background(), "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestNew_RequiresMethod(t *testing.T) {
+	if _, err := New(context.Background(), config.AuthConfig{Enabled: true}); err == nil {
+		t.Error("Expected error when auth is enabled without tokens or OIDC")
+	}
+}
+
+// testIssuer signs JWTs with an EC key published as a JWKS
+type testIssuer struct {
+	key  *ecdsa.PrivateKey
+	jwks []byte
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kid": "test",
+			"kty": "EC",
+			"use": "sig",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode JWKS: %v", err)
+	}
+
+	return &testIssuer{key: key, jwks: jwks}
+}
+
+func (i *testIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(i.key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestAuthenticator_JWKSFile(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, issuer.jwks, 0o600); err != nil {
+		t.Fatalf("Failed to write JWKS: %v", err)
+	}
+
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.OIDC.Issuer = "https://issuer.example"
+	cfg.OIDC.Audience = "seqctl"
+	cfg.OIDC.JWKSFile = path
+
+	a, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	valid := jwt.MapClaims{
+		"iss":    "https://issuer.example",
+		"aud":    "seqctl",
+		"sub":    "alice",
+		"groups": []string{"sre"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+
+	principal, err := a.Authenticate(context.Background(), issuer.sign(t, valid))
+	if err != nil {
+		t.Fatalf("Expected valid JWT, got %v", err)
+	}
+	if principal.Name != "alice" || principal.Method != MethodOIDC ||
+		len(principal.Groups) != 1 || principal.Groups[0] != "sre" {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims)
+	}{
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{"missing exp", func(c jwt.MapClaims) { delete(c, "exp") }},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://other.example" }},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "other" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{}
+			for k, v := range valid {
+				claims[k] = v
+			}
+			tt.mutate(claims)
+
+			if _, err := a.Authenticate(context.Background(), issuer.sign(t, claims)); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("Expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+
+	// A token signed by another key must be rejected
+	other := newTestIssuer(t)
+	if _, err := a.Authenticate(context.Background(), other.sign(t, valid)); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for foreign key, got %v", err)
+	}
+}
+
+func TestAuthenticator_JWKSSkipsUnsupportedKeys(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(issuer.jwks, &set); err != nil {
+		t.Fatalf("Failed to decode JWKS: %v", err)
+	}
+	unsupported := []map[string]string{
+		{"kid": "secp256k1", "kty": "EC", "use": "sig", "crv": "secp256k1", "x": "AA", "y": "AA"},
+		{"kid": "x448", "kty": "OKP", "use": "sig", "crv": "Ed448", "x": "AA"},
+		{"kid": "future", "kty": "AKP", "use": "sig"},
+	}
+
+	write := func(keys []map[string]string) string {
+		data, err := json.Marshal(map[string]any{"keys": keys})
+		if err != nil {
+			t.Fatalf("Failed to encode JWKS: %v", err)
+		}
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("Failed to write JWKS: %v", err)
+		}
+		return path
+	}
+
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.OIDC.Issuer = "https://issuer.example"
+	cfg.OIDC.JWKSFile = write(append(unsupported, set.Keys...))
+
+	a, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	claims := jwt.MapClaims{
+		"iss": "https://issuer.example",
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if _, err := a.Authenticate(context.Background(), issuer.sign(t, claims)); err != nil {
+		t.Errorf("Expected valid JWT, got %v", err)
+	}
+
+	// Without any usable key the set is still refused
+	cfg.OIDC.JWKSFile = write(unsupported)
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Error("Expected error for a JWKS without usable keys")
+	}
+}
+
+func TestAuthenticator_IssuerDiscovery(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":   server.URL,
+				"jwks_uri": server.URL + "/keys",
+			})
+		case "/keys":
+			w.Write(issuer.jwks)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.OIDC.Issuer = server.URL
+
+	a, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	token := issuer.sign(t, jwt.MapClaims{
+		"iss": server.URL,
+		"sub": "bob",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	principal, err := a.Authenticate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Expected valid JWT, got %v", err)
+	}
+	if principal.Name != "bob" {
+		t.Errorf("Expected principal bob, got %s", principal.Name)
+	}
+}
@@ -0,0 +1,338 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+const (
+	// jwksRefreshInterval bounds how often keys are reloaded when a token
+	// references an unknown key ID, e.g. after the issuer rotated its keys
+	jwksRefreshInterval = time.Minute
+
+	// jwksFetchTimeout bounds issuer discovery and JWKS downloads
+	jwksFetchTimeout = 10 * time.Second
+
+	// maxJWKSSize bounds the size of a fetched JWKS document
+	maxJWKSSize = 1 << 20
+)
+
+// jwtValidMethods are the signing algorithms accepted for JWTs. HMAC is
+// deliberately excluded as JWKS only carries public keys.
+var jwtValidMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// jwtVerifier validates JWTs against keys from a JWKS file or the JWKS
+// published by the OIDC issuer
+type jwtVerifier struct {
+	cfg        config.OIDCConfig
+	httpClient *http.Client
+	jwksURI    string // Set when keys come from the issuer
+	logger     *slog.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]any // Key ID -> public key
+	lastRefresh time.Time
+}
+
+// newJWTVerifier loads the initial key set
+func newJWTVerifier(ctx context.Context, cfg config.OIDCConfig) (*jwtVerifier, error) {
+	v := &jwtVerifier{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: jwksFetchTimeout},
+		logger:     slog.Default().With(slog.String("component", "auth")),
+	}
+
+	if cfg.JWKSFile == "" {
+		jwksURI, err := v.discoverJWKSURI(ctx)
+		if err != nil {
+			return nil, err
+		}
+		v.jwksURI = jwksURI
+	}
+
+	if err := v.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// verify validates a raw JWT and returns its principal
+func (v *jwtVerifier) verify(ctx context.Context, raw string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(jwtValidMethods))
+
+	if _, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, kid)
+	}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, fmt.Errorf("token has no valid exp claim")
+	}
+	if v.cfg.Issuer != "" && !claims.VerifyIssuer(v.cfg.Issuer, true) {
+		return nil, fmt.Errorf("token issuer does not match %q", v.cfg.Issuer)
+	}
+	if v.cfg.Audience != "" && !claims.VerifyAudience(v.cfg.Audience, true) {
+		return nil, fmt.Errorf("token audience does not include %q", v.cfg.Audience)
+	}
+
+	return claimsPrincipal(claims, v.cfg.UsernameClaim, v.cfg.GroupsClaim)
+}
+
+// key returns the public key for a key ID, reloading the key set once if the
+// ID is unknown
+func (v *jwtVerifier) key(ctx context.Context, kid string) (any, error) {
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	v.mu.RLock()
+	stale := time.Since(v.lastRefresh) > jwksRefreshInterval
+	v.mu.RUnlock()
+
+	if stale {
+		if err := v.refresh(ctx); err != nil {
+			v.logger.Warn("Failed to refresh JWKS", "error", err)
+		}
+		if key, ok := v.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup finds a key by ID. Tokens without a key ID are accepted only when
+// the key set holds a single key.
+func (v *jwtVerifier) lookup(kid string) (any, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// refresh reloads the key set from its source
+func (v *jwtVerifier) refresh(ctx context.Context) error {
+	var (
+		data []byte
+		err  error
+	)
+	if v.jwksURI != "" {
+		data, err = v.fetch(ctx, v.jwksURI)
+	} else {
+		data, err = os.ReadFile(v.cfg.JWKSFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data, v.logger)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.lastRefresh = time.Now()
+	v.mu.Unlock()
+
+	v.logger.Debug("JWKS loaded", "keys", len(keys))
+	return nil
+}
+
+// discoverJWKSURI reads jwks_uri from the issuer's discovery document
+func (v *jwtVerifier) discoverJWKSURI(ctx context.Context) (string, error) {
+	url := strings.TrimSuffix(v.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+
+	data, err := v.fetch(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover OIDC configuration: %w", err)
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(data, &discovery); err != nil {
+		return "", fmt.Errorf("failed to parse OIDC configuration: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return "", fmt.Errorf("OIDC configuration at %s has no jwks_uri", url)
+	}
+
+	return discovery.JWKSURI, nil
+}
+
+// fetch downloads a small JSON document
+func (v *jwtVerifier) fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+// jwk is a JSON Web Key as defined in RFC 7517
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS parses the signing keys of a JWKS document. Keys of unsupported
+// types or curves, or with invalid material, are logged and skipped so that
+// an issuer publishing them alongside usable keys is still accepted.
+func parseJWKS(data []byte, logger *slog.Logger) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			logger.Warn("Skipping JWKS key", "index", i, "kid", k.Kid, "error", err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the key material
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		curve, ecdhCurve, err := ellipticCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+
+		// Reject points that are not on the curve
+		size := (curve.Params().BitSize + 7) / 8
+		point := append([]byte{4}, append(x.FillBytes(make([]byte, size)), y.FillBytes(make([]byte, size))...)...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func ellipticCurve(name string) (elliptic.Curve, ecdh.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), ecdh.P256(), nil
+	case "P-384":
+		return elliptic.P384(), ecdh.P384(), nil
+	case "P-521":
+		return elliptic.P521(), ecdh.P521(), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported EC curve %q", name)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+// Role is a level of access. Each role includes the permissions of the roles
+// below it.
+type Role int
+
+// Roles, in increasing order of privilege
+const (
+	RoleNone Role = iota
+	RoleViewer
+	RoleOperator
+	RoleAdmin
+)
+
+// String returns the configuration name of the role
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// ParseRole parses a role name as used in the configuration
+func ParseRole(name string) (Role, error) {
+	switch name {
+	case "", "none":
+		return RoleNone, nil
+	case "viewer":
+		return RoleViewer, nil
+	case "operator":
+		return RoleOperator, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown role %q (expected viewer, operator or admin)", name)
+	}
+}
+
+// binding grants a role to API tokens, JWT users and JWT groups, optionally
+// limited to some networks. Token names and JWT usernames are kept apart so
+// that a token cannot be granted the role of a user with the same name.
+type binding struct {
+	role       Role
+	principals []string // API token names
+	users      []string // JWT usernames
+	groups     []string
+	networks   []string // Empty means all networks
+}
+
+// policy maps principals to roles per network
+type policy struct {
+	defaultRole Role
+	bindings    []binding
+}
+
+// newPolicy builds the role policy from the auth configuration
+func newPolicy(cfg config.AuthConfig) (*policy, error) {
+	defaultRole, err := ParseRole(cfg.DefaultRole)
+	if err != nil {
+		return nil, fmt.Errorf("auth.default_role: %w", err)
+	}
+
+	p := &policy{defaultRole: defaultRole}
+
+	for i, b := range cfg.Bindings {
+		role, err := ParseRole(b.Role)
+		if err != nil {
+			return nil, fmt.Errorf("auth.bindings[%d]: %w", i, err)
+		}
+		if role == RoleNone {
+			return nil, fmt.Errorf("auth.bindings[%d]: role is required", i)
+		}
+		if len(b.Principals) == 0 && len(b.Users) == 0 && len(b.Groups) == 0 {
+			return nil, fmt.Errorf("auth.bindings[%d]: at least one principal, user or group is required", i)
+		}
+
+		p.bindings = append(p.bindings, binding{
+			role:       role,
+			principals: b.Principals,
+			users:      b.Users,
+			groups:     b.Groups,
+			networks:   b.Networks,
+		})
+	}
+
+	return p, nil
+}
+
+// roleFor returns the highest role granted to the principal on the network.
+// An empty network name only matches bindings that apply to all networks.
+func (p *policy) roleFor(principal *Principal, network string) Role {
+	role := p.defaultRole
+
+	for _, b := range p.bindings {
+		if b.role <= role || !b.matches(principal) {
+			continue
+		}
+		if len(b.networks) == 0 || (network != "" && slices.Contains(b.networks, network)) {
+			role = b.role
+		}
+	}
+
+	return role
+}
+
+// maxRole returns the highest role granted to the principal on any network
+func (p *policy) maxRole(principal *Principal) Role {
+	role := p.defaultRole
+	for _, b := range p.bindings {
+		if b.role > role && b.matches(principal) {
+			role = b.role
+		}
+	}
+	return role
+}
+
+// matches reports whether the binding applies to the principal. Names are
+// only compared within the principal's authentication method.
+func (b binding) matches(principal *Principal) bool {
+	switch principal.Method {
+	case MethodToken:
+		return slices.Contains(b.principals, principal.Name)
+	case MethodOIDC:
+		if slices.Contains(b.users, principal.Name) {
+			return true
+		}
+		for _, group := range principal.Groups {
+			if slices.Contains(b.groups, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+func TestAuthenticator_RoleFor(t *testing.T) {
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.Tokens = []config.APITokenConfig{{Name: "ci", Token: "s3cret"}}
+	cfg.DefaultRole = "viewer"
+	cfg.Bindings = []config.RoleBindingConfig{
+		{Role: "admin", Groups: []string{"sre"}},
+		{Role: "operator", Principals: []string{"ci"}, Networks: []string{"devnet"}},
+		{Role: "admin", Principals: []string{"ci"}, Networks: []string{"testnet"}},
+	}
+
+	a, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	ci := &Principal{Name: "ci", Method: MethodToken}
+	sre := &Principal{Name: "alice", Method: MethodOIDC, Groups: []string{"sre"}}
+	other := &Principal{Name: "bob", Method: MethodOIDC}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		network   string
+		want      Role
+	}{
+		{"network binding", ci, "devnet", RoleOperator},
+		{"other network binding", ci, "testnet", RoleAdmin},
+		{"unbound network", ci, "mainnet", RoleViewer},
+		{"unknown network", ci, "", RoleViewer},
+		{"global group binding", sre, "mainnet", RoleAdmin},
+		{"default role", other, "devnet", RoleViewer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.RoleFor(tt.principal, tt.network); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if got := a.MaxRole(ci); got != RoleAdmin {
+		t.Errorf("Expected max role admin, got %s", got)
+	}
+}
+
+func TestAuthenticator_RoleForSeparatesTokensAndUsers(t *testing.T) {
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.Tokens = []config.APITokenConfig{{Name: "alice", Token: "s3cret"}}
+	cfg.DefaultRole = "none"
+	cfg.Bindings = []config.RoleBindingConfig{
+		{Role: "operator", Principals: []string{"alice"}},
+		{Role: "admin", Users: []string{"alice"}},
+		{Role: "viewer", Groups: []string{"alice"}},
+	}
+
+	a, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		want      Role
+	}{
+		{"token", &Principal{Name: "alice", Method: MethodToken}, RoleOperator},
+		{"JWT user", &Principal{Name: "alice", Method: MethodOIDC}, RoleAdmin},
+		{"JWT group", &Principal{Name: "bob", Method: MethodOIDC, Groups: []string{"alice"}}, RoleViewer},
+		{"token with groups", &Principal{Name: "bob", Method: MethodToken, Groups: []string{"alice"}}, RoleNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.RoleFor(tt.principal, "devnet"); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_RoleForDisabled(t *testing.T) {
+	a, err := New(context.Background(), config.AuthConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	// Without authentication everything stays permitted, as before
+	if got := a.RoleFor(Anonymous, "devnet"); got != RoleAdmin {
+		t.Errorf("Expected admin, got %s", got)
+	}
+}
+
+func TestNew_InvalidBinding(t *testing.T) {
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.Tokens = []config.APITokenConfig{{Name: "ci", Token: "s3cret"}}
+	cfg.Bindings = []config.RoleBindingConfig{{Role: "root", Principals: []string{"ci"}}}
+
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Error("Expected error for unknown role")
+	}
+}
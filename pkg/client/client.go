@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/server/handlers"
+)
+
+// networksPath is the API entry point, every other resource is reached by
+// following the links in its responses
+const networksPath = "/api/v1/networks"
+
+// approvalsPath lists the actions held for a second operator, which no
+// network links to
+const approvalsPath = "/api/v1/approvals"
+
+// DefaultTimeout bounds a single request. Handovers block on the server for up
+// to 30 seconds plus a possible rollback.
+const DefaultTimeout = 90 * time.Second
+
+// APIError is returned for responses with an error status
+type APIError struct {
+	StatusCode int
+	Problem    handlers.ErrorResponse // RFC 7807 body, empty if the body was not one
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Problem.Detail != "":
+		return fmt.Sprintf("%s: %s", e.Problem.Title, e.Problem.Detail)
+	case e.Problem.Title != "":
+		return e.Problem.Title
+	default:
+		return fmt.Sprintf("server returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+}
+
+// Client talks to the /api/v1 endpoints of a seqctl server
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a client for the server at serverURL, authenticating with the
+// bearer token if one is given
+func New(serverURL, token string) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(serverURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid server URL %q: scheme must be http or https", serverURL)
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+// ListNetworks returns every network the caller may view
+func (c *Client) ListNetworks(ctx context.Context) ([]handlers.NetworkResponse, error) {
+	var networks []handlers.NetworkResponse
+	if err := c.do(ctx, http.MethodGet, networksPath, nil, &networks); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+// ListApprovals returns the approval requests the caller may view, only
+// those with the given status if it is not empty
+func (c *Client) ListApprovals(ctx context.Context, status string) ([]handlers.ApprovalResponse, error) {
+	path := approvalsPath
+	if status != "" {
+		path += "?status=" + url.QueryEscape(status)
+	}
+
+	var resp handlers.ApprovalsResponse
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Approvals, nil
+}
+
+// Follow requests a link from a previous response, sending body as JSON if
+// not nil and decoding the response into out if not nil
+func (c *Client) Follow(ctx context.Context, link handlers.Link, body, out any) error {
+	method := link.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return c.do(ctx, method, link.Href, body, out)
+}
+
+// do sends a request to a path on the server
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("invalid link %q: %w", path, err)
+	}
+	target := *c.baseURL
+	target.Path = c.baseURL.Path + ref.Path
+	target.RawQuery = ref.RawQuery
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, target.Path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: data}
+		_ = json.Unmarshal(data, &apiErr.Problem)
+		return apiErr
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golem-base/seqctl/pkg/server/handlers"
+)
+
+func TestClient_FollowsLinks(t *testing.T) {
+	var gotAuth, gotMethod, gotBody string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prefix/api/v1/networks", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode([]handlers.NetworkResponse{{
+			Name: "devnet",
+			Sequencers: []handlers.SequencerResponse{{
+				ID: "seq-0",
+				Links: handlers.SequencerLinks{
+					Pause: &handlers.Link{Href: "/api/v1/sequencers/seq-0/pause", Method: "POST"},
+				},
+			}},
+		}})
+	})
+	mux.HandleFunc("/prefix/api/v1/sequencers/seq-0/pause", func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		gotBody, _ = body["reason"].(string)
+		json.NewEncoder(w).Encode(handlers.SequencerResponse{ID: "seq-0", ConductorPaused: true})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := New(srv.URL+"/prefix/", "secret")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	networks, err := c.ListNetworks(t.Context())
+	if err != nil {
+		t.Fatalf("ListNetworks failed: %v", err)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Expected bearer token, got %q", gotAuth)
+	}
+	if len(networks) != 1 || len(networks[0].Sequencers) != 1 {
+		t.Fatalf("Expected one network with one sequencer, got %+v", networks)
+	}
+
+	var seq handlers.SequencerResponse
+	link := networks[0].Sequencers[0].Links.Pause
+	if err := c.Follow(t.Context(), *link, map[string]string{"reason": "maintenance"}, &seq); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotBody != "maintenance" {
+		t.Errorf("Expected POST with body, got %s with %q", gotMethod, gotBody)
+	}
+	if !seq.ConductorPaused {
+		t.Error("Expected the decoded response to report a paused conductor")
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(handlers.ErrorResponse{
+			Title:  "Invalid state",
+			Status: http.StatusConflict,
+			Detail: "conductor is already paused",
+		})
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, "")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = c.Follow(t.Context(), handlers.Link{Href: "/api/v1/sequencers/seq-0/pause", Method: "POST"}, nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", apiErr.StatusCode)
+	}
+	if got := apiErr.Error(); got != "Invalid state: conductor is already paused" {
+		t.Errorf("Unexpected error message %q", got)
+	}
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	if _, err := New("seqctl.example.com", ""); err == nil {
+		t.Error("Expected an error for a URL without scheme")
+	}
+}
@@ -33,21 +33,43 @@ func expandPath(path string) string {
 
 // K8sConfig holds Kubernetes-related configuration
 type K8sConfig struct {
-	AppLabel             string   `koanf:"app_label" toml:"app_label"`
-	ConductorPort        int      `koanf:"conductor_port" toml:"conductor_port"`
-	ConductorPortName    string   `koanf:"conductor_port_name" toml:"conductor_port_name"`
-	ConfigPath           string   `koanf:"config_path" toml:"config_path"`
-	ConnectionMode       string   `koanf:"connection_mode" toml:"connection_mode"`
-	Namespaces           []string `koanf:"namespaces" toml:"namespaces"`
-	NetworkLabel         string   `koanf:"network_label" toml:"network_label"`
-	NodePort             int      `koanf:"node_port" toml:"node_port"`
-	NodePortName         string   `koanf:"node_port_name" toml:"node_port_name"`
-	RaftPort             int      `koanf:"raft_port" toml:"raft_port"`
-	SequencerModeFilter  string   `koanf:"sequencer_mode_filter" toml:"sequencer_mode_filter"`
-	SequencerRoleLabel   string   `koanf:"sequencer_role_label" toml:"sequencer_role_label"`
-	SequencerVoterValues []string `koanf:"sequencer_voter_values" toml:"sequencer_voter_values"`
-	ServiceSelector      string   `koanf:"service_selector" toml:"service_selector"`
-	StatefulSetSelector  string   `koanf:"statefulset_selector" toml:"statefulset_selector"`
+	AppLabel               string   `koanf:"app_label" toml:"app_label"`
+	ConductorPort          int      `koanf:"conductor_port" toml:"conductor_port"`
+	ConductorPortName      string   `koanf:"conductor_port_name" toml:"conductor_port_name"`
+	ConfigPath             string   `koanf:"config_path" toml:"config_path"`
+	ConnectionMode         string   `koanf:"connection_mode" toml:"connection_mode"`
+	DiscoveryMode          string   `koanf:"discovery_mode" toml:"discovery_mode"`
+	Namespaces             []string `koanf:"namespaces" toml:"namespaces"`
+	NetworkLabel           string   `koanf:"network_label" toml:"network_label"`
+	NodePort               int      `koanf:"node_port" toml:"node_port"`
+	NodePortName           string   `koanf:"node_port_name" toml:"node_port_name"`
+	RaftPort               int      `koanf:"raft_port" toml:"raft_port"`
+	SequencerModeFilter    string   `koanf:"sequencer_mode_filter" toml:"sequencer_mode_filter"`
+	SequencerRoleLabel     string   `koanf:"sequencer_role_label" toml:"sequencer_role_label"`
+	SequencerVoterValues   []string `koanf:"sequencer_voter_values" toml:"sequencer_voter_values"`
+	SequencerNonvoterValue string   `koanf:"sequencer_nonvoter_value" toml:"sequencer_nonvoter_value"`
+	ServiceSelector        string   `koanf:"service_selector" toml:"service_selector"`
+	StatefulSetSelector    string   `koanf:"statefulset_selector" toml:"statefulset_selector"`
+}
+
+// ProviderConfig holds sequencer discovery provider configuration
+type ProviderConfig struct {
+	Type string `koanf:"type" toml:"type"`
+}
+
+// StaticSequencerConfig holds the definition of a statically configured sequencer
+type StaticSequencerConfig struct {
+	ID           string `koanf:"id" toml:"id"`
+	RaftAddr     string `koanf:"raft_addr" toml:"raft_addr"`
+	ConductorURL string `koanf:"conductor_url" toml:"conductor_url"`
+	NodeURL      string `koanf:"node_url" toml:"node_url"`
+	Voting       bool   `koanf:"voting" toml:"voting"`
+}
+
+// NetworkConfig holds the definition of a statically configured network
+type NetworkConfig struct {
+	Name       string                  `koanf:"name" toml:"name"`
+	Sequencers []StaticSequencerConfig `koanf:"sequencers" toml:"sequencers"`
 }
 
 // LogConfig holds logging configuration
@@ -64,6 +86,106 @@ type ServerConfig struct {
 	Port    int    `koanf:"port" toml:"port"`
 }
 
+// APITokenConfig holds a static bearer token for API access
+type APITokenConfig struct {
+	Name  string `koanf:"name" toml:"name"`
+	Token string `koanf:"token" toml:"token"`
+}
+
+// OIDCConfig holds OpenID Connect JWT validation configuration
+type OIDCConfig struct {
+	Issuer        string `koanf:"issuer" toml:"issuer"`
+	Audience      string `koanf:"audience" toml:"audience"`
+	JWKSFile      string `koanf:"jwks_file" toml:"jwks_file"`
+	UsernameClaim string `koanf:"username_claim" toml:"username_claim"`
+	GroupsClaim   string `koanf:"groups_claim" toml:"groups_claim"`
+}
+
+// RoleBindingConfig grants a role to API tokens, JWT users and JWT groups,
+// optionally limited to some networks
+type RoleBindingConfig struct {
+	Role       string   `koanf:"role" toml:"role"`
+	Principals []string `koanf:"principals" toml:"principals"` // API token names
+	Users      []string `koanf:"users" toml:"users"`           // JWT usernames
+	Groups     []string `koanf:"groups" toml:"groups"`
+	Networks   []string `koanf:"networks" toml:"networks"`
+}
+
+// AuthConfig holds API authentication and authorization configuration
+type AuthConfig struct {
+	Enabled     bool                `koanf:"enabled" toml:"enabled"`
+	Tokens      []APITokenConfig    `koanf:"tokens" toml:"tokens"`
+	OIDC        OIDCConfig          `koanf:"oidc" toml:"oidc"`
+	DefaultRole string              `koanf:"default_role" toml:"default_role"`
+	Bindings    []RoleBindingConfig `koanf:"bindings" toml:"bindings"`
+}
+
+// AuditConfig holds audit log configuration
+type AuditConfig struct {
+	Path string `koanf:"path" toml:"path"` // Empty disables the audit log
+}
+
+// ApprovalConfig holds two-person approval of dangerous actions, which
+// requires authentication so that the two operators can be told apart
+type ApprovalConfig struct {
+	Enabled bool   `koanf:"enabled" toml:"enabled"`
+	TTL     string `koanf:"ttl" toml:"ttl"` // How long a request waits for approval
+}
+
+// HistoryConfig holds status history configuration
+type HistoryConfig struct {
+	Path             string `koanf:"path" toml:"path"` // Empty disables the history
+	Retention        string `koanf:"retention" toml:"retention"`
+	SnapshotInterval string `koanf:"snapshot_interval" toml:"snapshot_interval"`
+}
+
+// AlertWebhookConfig holds a webhook that receives alert notifications
+type AlertWebhookConfig struct {
+	Name       string `koanf:"name" toml:"name"`
+	URL        string `koanf:"url" toml:"url"`
+	Format     string `koanf:"format" toml:"format"`           // generic, slack or pagerduty
+	RoutingKey string `koanf:"routing_key" toml:"routing_key"` // PagerDuty integration key
+}
+
+// AlertRuleConfig holds an alerting rule. Networks are glob patterns, empty
+// matches all networks.
+type AlertRuleConfig struct {
+	Name      string   `koanf:"name" toml:"name"`
+	Condition string   `koanf:"condition" toml:"condition"`
+	Networks  []string `koanf:"networks" toml:"networks"`
+	For       string   `koanf:"for" toml:"for"`
+	Severity  string   `koanf:"severity" toml:"severity"`
+	Webhooks  []string `koanf:"webhooks" toml:"webhooks"` // Empty sends to all webhooks
+	Threshold int      `koanf:"threshold" toml:"threshold"`
+	Window    string   `koanf:"window" toml:"window"`
+}
+
+// AlertSilenceConfig holds a silence that suppresses matching alerts until
+// it expires
+type AlertSilenceConfig struct {
+	Rule      string `koanf:"rule" toml:"rule"`
+	Network   string `koanf:"network" toml:"network"`
+	Sequencer string `koanf:"sequencer" toml:"sequencer"`
+	Until     string `koanf:"until" toml:"until"`
+	Comment   string `koanf:"comment" toml:"comment"`
+}
+
+// AlertingConfig holds webhook alerting configuration. Alerting is disabled
+// when no rules are configured.
+type AlertingConfig struct {
+	EvaluationInterval string               `koanf:"evaluation_interval" toml:"evaluation_interval"`
+	RepeatInterval     string               `koanf:"repeat_interval" toml:"repeat_interval"`
+	Webhooks           []AlertWebhookConfig `koanf:"webhooks" toml:"webhooks"`
+	Rules              []AlertRuleConfig    `koanf:"rules" toml:"rules"`
+	Silences           []AlertSilenceConfig `koanf:"silences" toml:"silences"`
+}
+
+// HealthConfig holds the thresholds of unsafe head progress checks
+type HealthConfig struct {
+	StallBlocks    int `koanf:"stall_blocks" toml:"stall_blocks"`
+	MaxFollowerLag int `koanf:"max_follower_lag" toml:"max_follower_lag"`
+}
+
 // CacheConfig holds cache configuration
 type CacheConfig struct {
 	DiscoveryTTL string `koanf:"discovery_ttl" toml:"discovery_ttl"`
@@ -72,31 +194,44 @@ type CacheConfig struct {
 
 // Config holds the application configuration
 type Config struct {
-	K8s    K8sConfig    `koanf:"k8s"`
-	Log    LogConfig    `koanf:"log"`
-	Server ServerConfig `koanf:"server"`
-	Cache  CacheConfig  `koanf:"cache"`
+	Provider ProviderConfig  `koanf:"provider"`
+	K8s      K8sConfig       `koanf:"k8s"`
+	Networks []NetworkConfig `koanf:"networks"`
+	Log      LogConfig       `koanf:"log"`
+	Server   ServerConfig    `koanf:"server"`
+	Auth     AuthConfig      `koanf:"auth"`
+	Audit    AuditConfig     `koanf:"audit"`
+	Approval ApprovalConfig  `koanf:"approval"`
+	History  HistoryConfig   `koanf:"history"`
+	Alerting AlertingConfig  `koanf:"alerting"`
+	Health   HealthConfig    `koanf:"health"`
+	Cache    CacheConfig     `koanf:"cache"`
 }
 
 // New creates a new Config instance with default values
 func New() *Config {
 	return &Config{
+		Provider: ProviderConfig{
+			Type: flags.ProviderType.Value,
+		},
 		K8s: K8sConfig{
-			AppLabel:             flags.K8sAppLabel.Value,
-			ConductorPort:        flags.K8sConductorPort.Value,
-			ConductorPortName:    flags.K8sConductorPortName.Value,
-			ConfigPath:           expandPath(flags.K8sConfig.Value),
-			ConnectionMode:       flags.ConnectionMode.Value,
-			Namespaces:           []string{},
-			NetworkLabel:         flags.K8sNetworkLabel.Value,
-			NodePort:             flags.K8sNodePort.Value,
-			NodePortName:         flags.K8sNodePortName.Value,
-			RaftPort:             flags.K8sRaftPort.Value,
-			SequencerModeFilter:  flags.K8sSequencerModeFilter.Value,
-			SequencerRoleLabel:   flags.K8sSequencerRoleLabel.Value,
-			SequencerVoterValues: []string{"voter"}, // Default: only "voter" indicates voting member
-			ServiceSelector:      flags.K8sServiceSelector.Value,
-			StatefulSetSelector:  flags.K8sStatefulSetSelector.Value,
+			AppLabel:               flags.K8sAppLabel.Value,
+			ConductorPort:          flags.K8sConductorPort.Value,
+			ConductorPortName:      flags.K8sConductorPortName.Value,
+			ConfigPath:             expandPath(flags.K8sConfig.Value),
+			ConnectionMode:         flags.ConnectionMode.Value,
+			DiscoveryMode:          flags.K8sDiscoveryMode.Value,
+			Namespaces:             []string{},
+			NetworkLabel:           flags.K8sNetworkLabel.Value,
+			NodePort:               flags.K8sNodePort.Value,
+			NodePortName:           flags.K8sNodePortName.Value,
+			RaftPort:               flags.K8sRaftPort.Value,
+			SequencerModeFilter:    flags.K8sSequencerModeFilter.Value,
+			SequencerRoleLabel:     flags.K8sSequencerRoleLabel.Value,
+			SequencerVoterValues:   []string{"voter"}, // Default: only "voter" indicates voting member
+			SequencerNonvoterValue: flags.K8sSequencerNonvoterValue.Value,
+			ServiceSelector:        flags.K8sServiceSelector.Value,
+			StatefulSetSelector:    flags.K8sStatefulSetSelector.Value,
 		},
 		Log: LogConfig{
 			FilePath: flags.LogFile.Value,
@@ -108,6 +243,34 @@ func New() *Config {
 			Address: flags.ServerAddress.Value,
 			Port:    flags.ServerPort.Value,
 		},
+		Auth: AuthConfig{
+			Enabled:     flags.AuthEnabled.Value,
+			DefaultRole: "viewer",
+			OIDC: OIDCConfig{
+				UsernameClaim: "sub",
+				GroupsClaim:   "groups",
+			},
+		},
+		Audit: AuditConfig{
+			Path: flags.AuditLog.Value,
+		},
+		Approval: ApprovalConfig{
+			Enabled: flags.ApprovalEnabled.Value,
+			TTL:     flags.ApprovalTTL.Value,
+		},
+		History: HistoryConfig{
+			Path:             flags.HistoryPath.Value,
+			Retention:        flags.HistoryRetention.Value,
+			SnapshotInterval: flags.HistorySnapshotInterval.Value,
+		},
+		Alerting: AlertingConfig{
+			EvaluationInterval: "15s",
+			RepeatInterval:     "4h",
+		},
+		Health: HealthConfig{
+			StallBlocks:    flags.HealthStallBlocks.Value,
+			MaxFollowerLag: flags.HealthMaxFollowerLag.Value,
+		},
 		Cache: CacheConfig{
 			DiscoveryTTL: "5m",
 			StatusTTL:    "10s",
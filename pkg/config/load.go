@@ -53,6 +53,9 @@ func LoadConfig(cliCtx *cli.Context) (*Config, error) {
 	// Expand paths after loading
 	cfg.K8s.ConfigPath = expandPath(cfg.K8s.ConfigPath)
 	cfg.Log.FilePath = expandPath(cfg.Log.FilePath)
+	cfg.Auth.OIDC.JWKSFile = expandPath(cfg.Auth.OIDC.JWKSFile)
+	cfg.Audit.Path = expandPath(cfg.Audit.Path)
+	cfg.History.Path = expandPath(cfg.History.Path)
 
 	logFinalConfig(cfg)
 	return cfg, nil
@@ -87,29 +90,41 @@ func loadConfigFile(k *koanf.Koanf, path string) error {
 
 // flagMapping defines the mapping from CLI flags to koanf paths
 var flagMapping = map[string]string{
-	"k8s-config":                 "k8s.config_path",
-	"k8s-statefulset-selector":   "k8s.statefulset_selector",
-	"k8s-service-selector":       "k8s.service_selector",
-	"k8s-connection-mode":        "k8s.connection_mode",
-	"k8s-network-label":          "k8s.network_label",
-	"k8s-app-label":              "k8s.app_label",
-	"k8s-sequencer-role-label":   "k8s.sequencer_role_label",
-	"k8s-sequencer-voter-values": "k8s.sequencer_voter_values",
-	"k8s-sequencer-mode-filter":  "k8s.sequencer_mode_filter",
-	"k8s-conductor-port":         "k8s.conductor_port",
-	"k8s-node-port":              "k8s.node_port",
-	"k8s-raft-port":              "k8s.raft_port",
-	"k8s-conductor-port-name":    "k8s.conductor_port_name",
-	"k8s-node-port-name":         "k8s.node_port_name",
-	"log-level":                  "log.level",
-	"log-format":                 "log.format",
-	"log-no-color":               "log.no_color",
-	"log-file":                   "log.file_path",
-	"server-address":             "server.address",
-	"server-port":                "server.port",
-	"k8s-namespaces":             "k8s.namespaces",
-	"cache-discovery-ttl":        "cache.discovery_ttl",
-	"cache-status-ttl":           "cache.status_ttl",
+	"provider":                     "provider.type",
+	"k8s-config":                   "k8s.config_path",
+	"k8s-statefulset-selector":     "k8s.statefulset_selector",
+	"k8s-service-selector":         "k8s.service_selector",
+	"k8s-connection-mode":          "k8s.connection_mode",
+	"k8s-discovery-mode":           "k8s.discovery_mode",
+	"k8s-network-label":            "k8s.network_label",
+	"k8s-app-label":                "k8s.app_label",
+	"k8s-sequencer-role-label":     "k8s.sequencer_role_label",
+	"k8s-sequencer-voter-values":   "k8s.sequencer_voter_values",
+	"k8s-sequencer-nonvoter-value": "k8s.sequencer_nonvoter_value",
+	"k8s-sequencer-mode-filter":    "k8s.sequencer_mode_filter",
+	"k8s-conductor-port":           "k8s.conductor_port",
+	"k8s-node-port":                "k8s.node_port",
+	"k8s-raft-port":                "k8s.raft_port",
+	"k8s-conductor-port-name":      "k8s.conductor_port_name",
+	"k8s-node-port-name":           "k8s.node_port_name",
+	"log-level":                    "log.level",
+	"log-format":                   "log.format",
+	"log-no-color":                 "log.no_color",
+	"log-file":                     "log.file_path",
+	"server-address":               "server.address",
+	"server-port":                  "server.port",
+	"auth-enabled":                 "auth.enabled",
+	"audit-log":                    "audit.path",
+	"approval-enabled":             "approval.enabled",
+	"approval-ttl":                 "approval.ttl",
+	"history-db":                   "history.path",
+	"history-retention":            "history.retention",
+	"history-snapshot-interval":    "history.snapshot_interval",
+	"k8s-namespaces":               "k8s.namespaces",
+	"cache-discovery-ttl":          "cache.discovery_ttl",
+	"cache-status-ttl":             "cache.status_ttl",
+	"stall-blocks":                 "health.stall_blocks",
+	"max-follower-lag":             "health.max_follower_lag",
 }
 
 // loadCLIFlags loads configuration from command-line flags
@@ -121,9 +136,10 @@ func loadCLIFlags(k *koanf.Koanf, cliCtx *cli.Context) error {
 
 		var value any
 		switch flagName {
-		case "log-no-color":
+		case "log-no-color", "auth-enabled", "approval-enabled":
 			value = cliCtx.Bool(flagName)
-		case "server-port", "k8s-conductor-port", "k8s-node-port", "k8s-raft-port":
+		case "server-port", "k8s-conductor-port", "k8s-node-port", "k8s-raft-port",
+			"stall-blocks", "max-follower-lag":
 			value = cliCtx.Int(flagName)
 		case "namespaces", "k8s-sequencer-voter-values":
 			value = cliCtx.StringSlice(flagName)
@@ -144,14 +160,29 @@ func loadCLIFlags(k *koanf.Koanf, cliCtx *cli.Context) error {
 // logFinalConfig logs the final configuration for debugging
 func logFinalConfig(cfg *Config) {
 	slog.Debug("Configuration loaded",
+		"provider.type", cfg.Provider.Type,
+		"networks", len(cfg.Networks),
 		"k8s.config_path", cfg.K8s.ConfigPath,
 		"k8s.statefulset_selector", cfg.K8s.StatefulSetSelector,
 		"k8s.service_selector", cfg.K8s.ServiceSelector,
 		"k8s.connection_mode", cfg.K8s.ConnectionMode,
+		"k8s.discovery_mode", cfg.K8s.DiscoveryMode,
 		"k8s.namespaces", cfg.K8s.Namespaces,
 		"log.level", cfg.Log.Level,
 		"server.address", cfg.Server.Address,
 		"server.port", cfg.Server.Port,
+		"auth.enabled", cfg.Auth.Enabled,
+		"auth.tokens", len(cfg.Auth.Tokens),
+		"auth.oidc.issuer", cfg.Auth.OIDC.Issuer,
+		"audit.path", cfg.Audit.Path,
+		"approval.enabled", cfg.Approval.Enabled,
+		"approval.ttl", cfg.Approval.TTL,
+		"history.path", cfg.History.Path,
+		"history.retention", cfg.History.Retention,
+		"alerting.rules", len(cfg.Alerting.Rules),
+		"alerting.webhooks", len(cfg.Alerting.Webhooks),
+		"health.stall_blocks", cfg.Health.StallBlocks,
+		"health.max_follower_lag", cfg.Health.MaxFollowerLag,
 		"cache.discovery_ttl", cfg.Cache.DiscoveryTTL,
 		"cache.status_ttl", cfg.Cache.StatusTTL)
 }
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// Log defaults
+const (
+	DefaultCapacity = 1000
+
+	// subscriberBuffer is the number of entries a subscriber may fall behind
+	// before it is dropped
+	subscriberBuffer = 64
+)
+
+// Query limits
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Entry is an event with its position in the log
+type Entry struct {
+	ID uint64
+	network.Event
+}
+
+// Filter selects entries. Zero fields match everything.
+type Filter struct {
+	Types     []network.EventType
+	Network   string
+	Sequencer string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Matches reports whether an entry passes the filter
+func (f Filter) Matches(e *Entry) bool {
+	switch {
+	case len(f.Types) > 0 && !slices.Contains(f.Types, e.Type),
+		f.Network != "" && e.Network != f.Network,
+		f.Sequencer != "" && e.Sequencer != f.Sequencer,
+		!f.Since.IsZero() && e.Time.Before(f.Since),
+		!f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// Log keeps the most recent network events in memory and fans them out to
+// subscribers.
+//
+// IDs start at the time the log was created in microseconds, so that they
+// keep increasing across restarts and a client resuming from an ID seen
+// before a restart does not miss new events.
+type Log struct {
+	capacity int
+
+	mu          sync.Mutex
+	entries     []Entry // Oldest first
+	nextID      uint64
+	subscribers map[chan Entry]struct{}
+}
+
+// NewLog creates a log keeping up to capacity events
+func NewLog(capacity int) *Log {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Log{
+		capacity:    capacity,
+		nextID:      uint64(time.Now().UnixMicro()),
+		subscribers: make(map[chan Entry]struct{}),
+	}
+}
+
+// Publish appends the events of a network. It is a network.EventHandler.
+func (l *Log) Publish(_ *network.Network, events []network.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, event := range events {
+		entry := Entry{ID: l.nextID, Event: event}
+		l.nextID++
+
+		l.entries = append(l.entries, entry)
+		if len(l.entries) > l.capacity {
+			n := copy(l.entries, l.entries[len(l.entries)-l.capacity:])
+			l.entries = l.entries[:n]
+		}
+
+		for ch := range l.subscribers {
+			select {
+			case ch <- entry:
+			default:
+				// Closing lets the subscriber reconnect and resume from
+				// its last ID instead of silently missing events
+				delete(l.subscribers, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+// Query returns matching entries, newest first, along with the total number
+// of matches before the limit was applied
+func (l *Log) Query(filter Filter, visible func(*Entry) bool) ([]Entry, int) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var (
+		result []Entry
+		total  int
+	)
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		e := &l.entries[i]
+		if !filter.Matches(e) || (visible != nil && !visible(e)) {
+			continue
+		}
+		total++
+		if len(result) < limit {
+			result = append(result, *e)
+		}
+	}
+	return result, total
+}
+
+// Subscribe returns the retained entries after the given ID, oldest first,
+// and a channel receiving every entry published from then on. The channel is
+// closed when the subscriber falls too far behind or cancel is called.
+func (l *Log) Subscribe(after uint64) (replay []Entry, entries <-chan Entry, cancel func()) {
+	ch := make(chan Entry, subscriberBuffer)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if after > 0 {
+		for _, e := range l.entries {
+			if e.ID > after {
+				replay = append(replay, e)
+			}
+		}
+	}
+	l.subscribers[ch] = struct{}{}
+
+	return replay, ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subscribers[ch]; ok {
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
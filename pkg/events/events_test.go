@@ -0,0 +1,85 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+func event(typ network.EventType, networkName string, at time.Time) network.Event {
+	return network.Event{Type: typ, Time: at, Network: networkName, Sequencer: "sequencer-0"}
+}
+
+func TestLog_Query(t *testing.T) {
+	log := NewLog(3)
+	base := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	log.Publish(nil, []network.Event{
+		event(network.EventLeaderChanged, "devnet", base),
+		event(network.EventHealthDegraded, "devnet", base.Add(time.Minute)),
+		event(network.EventLeaderChanged, "testnet", base.Add(2*time.Minute)),
+		event(network.EventLeaderChanged, "devnet", base.Add(3*time.Minute)),
+	})
+
+	// The oldest event no longer fits
+	entries, total := log.Query(Filter{}, nil)
+	if total != 3 || len(entries) != 3 || !entries[0].Time.Equal(base.Add(3*time.Minute)) {
+		t.Fatalf("Query() = %+v (total %d), want the three newest events, newest first", entries, total)
+	}
+	if entries[0].ID != entries[2].ID+2 {
+		t.Errorf("IDs = %d, %d, want consecutive IDs", entries[2].ID, entries[0].ID)
+	}
+
+	entries, total = log.Query(Filter{Types: []network.EventType{network.EventLeaderChanged}, Network: "devnet"}, nil)
+	if total != 1 || entries[0].Network != "devnet" || entries[0].Type != network.EventLeaderChanged {
+		t.Errorf("Query() = %+v, want the devnet leader change", entries)
+	}
+
+	entries, total = log.Query(Filter{Limit: 1}, func(e *Entry) bool { return e.Network != "testnet" })
+	if total != 2 || len(entries) != 1 {
+		t.Errorf("Query() = %+v (total %d), want one of two visible events", entries, total)
+	}
+}
+
+func TestLog_Subscribe(t *testing.T) {
+	log := NewLog(DefaultCapacity)
+	base := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	log.Publish(nil, []network.Event{
+		event(network.EventLeaderChanged, "devnet", base),
+		event(network.EventSequencerHalted, "devnet", base),
+	})
+	first, _ := log.Query(Filter{}, nil)
+
+	// Resuming after the older event replays the newer one
+	replay, entries, cancel := log.Subscribe(first[1].ID)
+	if len(replay) != 1 || replay[0].Type != network.EventSequencerHalted {
+		t.Fatalf("Subscribe() replay = %+v, want the missed event", replay)
+	}
+
+	log.Publish(nil, []network.Event{event(network.EventSequencerActivated, "devnet", base)})
+	if e := <-entries; e.Type != network.EventSequencerActivated {
+		t.Errorf("received %s, want sequencer_activated", e.Type)
+	}
+
+	cancel()
+	if _, ok := <-entries; ok {
+		t.Error("channel still open after cancel")
+	}
+	cancel()
+
+	// A subscriber that falls behind is dropped
+	_, slow, cancel := log.Subscribe(0)
+	defer cancel()
+	for range subscriberBuffer + 1 {
+		log.Publish(nil, []network.Event{event(network.EventHealthDegraded, "devnet", base)})
+	}
+	received := 0
+	for range slow {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before being dropped, want %d", received, subscriberBuffer)
+	}
+}
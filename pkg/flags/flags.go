@@ -1,6 +1,10 @@
 package flags
 
-import "github.com/urfave/cli/v2"
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
 
 // EnvVarPrefix is the prefix for all environment variables
 const EnvVarPrefix = "SEQCTL"
@@ -21,6 +25,16 @@ var (
 	}
 )
 
+// Provider flags
+var (
+	ProviderType = &cli.StringFlag{
+		Name:    "provider",
+		Usage:   "Sequencer discovery provider (kubernetes, static)",
+		Value:   "kubernetes",
+		EnvVars: []string{PrefixEnvVar("PROVIDER_TYPE")},
+	}
+)
+
 // Logging flags
 var (
 	LogLevel = &cli.StringFlag{
@@ -93,6 +107,12 @@ var (
 		Value:   cli.NewStringSlice("voter"),
 		EnvVars: []string{PrefixEnvVar("K8S_SEQUENCER_VOTER_VALUES")},
 	}
+	K8sSequencerNonvoterValue = &cli.StringFlag{
+		Name:    "k8s-sequencer-nonvoter-value",
+		Usage:   "Label value written when a member is demoted to non-voter",
+		Value:   "nonvoter",
+		EnvVars: []string{PrefixEnvVar("K8S_SEQUENCER_NONVOTER_VALUE")},
+	}
 	K8sSequencerModeFilter = &cli.StringFlag{
 		Name:    "k8s-sequencer-mode-filter",
 		Usage:   "Filter resources by sequencer mode label (e.g. 'golem-base.io/sequencer-mode=ha'). Empty means no filtering",
@@ -105,6 +125,12 @@ var (
 		Value:   "auto",
 		EnvVars: []string{PrefixEnvVar("K8S_CONNECTION_MODE")},
 	}
+	K8sDiscoveryMode = &cli.StringFlag{
+		Name:    "k8s-discovery-mode",
+		Usage:   "Kubernetes discovery mode: list (poll every discovery TTL) or informer (watch for changes)",
+		Value:   "list",
+		EnvVars: []string{PrefixEnvVar("K8S_DISCOVERY_MODE")},
+	}
 	Namespaces = &cli.StringSliceFlag{
 		Name:    "k8s-namespaces",
 		Usage:   "Kubernetes namespaces to scan for sequencers (empty means all namespaces)",
@@ -174,11 +200,166 @@ var (
 	}
 )
 
+// Health flags
+var (
+	HealthStallBlocks = &cli.IntFlag{
+		Name:    "stall-blocks",
+		Usage:   "Block times without a new unsafe block before the active sequencer counts as stalled",
+		Value:   10,
+		EnvVars: []string{PrefixEnvVar("HEALTH_STALL_BLOCKS")},
+	}
+	HealthMaxFollowerLag = &cli.IntFlag{
+		Name:    "max-follower-lag",
+		Usage:   "Blocks a follower's unsafe head may trail the active sequencer before it counts as lagging",
+		Value:   20,
+		EnvVars: []string{PrefixEnvVar("HEALTH_MAX_FOLLOWER_LAG")},
+	}
+)
+
+// Auth flags
+var (
+	AuthEnabled = &cli.BoolFlag{
+		Name:    "auth-enabled",
+		Usage:   "Require authentication for API requests",
+		Value:   false,
+		EnvVars: []string{PrefixEnvVar("AUTH_ENABLED")},
+	}
+)
+
+// Audit flags
+var (
+	AuditLog = &cli.StringFlag{
+		Name:    "audit-log",
+		Usage:   "Path to the append-only audit log of control actions (disabled if empty)",
+		Value:   "",
+		EnvVars: []string{PrefixEnvVar("AUDIT_PATH")},
+	}
+)
+
+// Approval flags
+var (
+	ApprovalEnabled = &cli.BoolFlag{
+		Name:    "approval-enabled",
+		Usage:   "Hold override-leader and force-active until a second operator approves them (requires --auth-enabled)",
+		Value:   false,
+		EnvVars: []string{PrefixEnvVar("APPROVAL_ENABLED")},
+	}
+	ApprovalTTL = &cli.StringFlag{
+		Name:    "approval-ttl",
+		Usage:   "How long an action waits for approval before it expires (e.g. 15m)",
+		Value:   "15m",
+		EnvVars: []string{PrefixEnvVar("APPROVAL_TTL")},
+	}
+)
+
+// History flags
+var (
+	HistoryPath = &cli.StringFlag{
+		Name:    "history-db",
+		Usage:   "Path to the status history database (disabled if empty)",
+		Value:   "",
+		EnvVars: []string{PrefixEnvVar("HISTORY_PATH")},
+	}
+	HistoryRetention = &cli.StringFlag{
+		Name:    "history-retention",
+		Usage:   "How long to keep status history (e.g. 168h)",
+		Value:   "168h",
+		EnvVars: []string{PrefixEnvVar("HISTORY_RETENTION")},
+	}
+	HistorySnapshotInterval = &cli.StringFlag{
+		Name:    "history-snapshot-interval",
+		Usage:   "Minimum time between status snapshots of a sequencer without transitions (e.g. 1m)",
+		Value:   "1m",
+		EnvVars: []string{PrefixEnvVar("HISTORY_SNAPSHOT_INTERVAL")},
+	}
+)
+
+// CLI flags
+var (
+	OutputFormat = &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "Output format (table, json, yaml)",
+		Value:   "table",
+		EnvVars: []string{PrefixEnvVar("OUTPUT")},
+	}
+	Server = &cli.StringFlag{
+		Name:    "server",
+		Usage:   "URL of a seqctl server to send commands to instead of the sequencers (e.g. https://seqctl.example.com)",
+		EnvVars: []string{PrefixEnvVar("SERVER")},
+	}
+	Token = &cli.StringFlag{
+		Name:    "token",
+		Usage:   "Bearer token for the seqctl server",
+		EnvVars: []string{PrefixEnvVar("TOKEN")},
+	}
+	TargetID = &cli.StringFlag{
+		Name:  "target-id",
+		Usage: "Raft server ID of the new leader",
+	}
+	TargetAddr = &cli.StringFlag{
+		Name:  "target-addr",
+		Usage: "Raft address of the new leader",
+	}
+	HandoverTarget = &cli.StringFlag{
+		Name:  "target",
+		Usage: "Sequencer to hand over to (picked automatically if empty)",
+	}
+	HandoverTimeout = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Time allowed for leadership to move and blocks to flow",
+		Value: 30 * time.Second,
+	}
+	Override = &cli.BoolFlag{
+		Name:  "override",
+		Usage: "Leader status to force (--override=false clears the override)",
+		Value: true,
+	}
+	BlockHash = &cli.StringFlag{
+		Name:  "block-hash",
+		Usage: "Block hash to start sequencing from (zero hash if empty)",
+	}
+	ServerID = &cli.StringFlag{
+		Name:  "server-id",
+		Usage: "Raft server ID of the member",
+	}
+	ServerAddr = &cli.StringFlag{
+		Name:  "server-addr",
+		Usage: "Raft address of the member",
+	}
+	Voting = &cli.BoolFlag{
+		Name:  "voting",
+		Usage: "Add the member as a voter instead of a non-voter",
+	}
+	DryRun = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Check the action and show the RPCs it would send without sending them (membership changes and handover still make read-only RPCs for their checks)",
+	}
+	PlanDryRun = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only show the plan (--dry-run=false applies it)",
+		Value: true,
+	}
+	ApprovalStatus = &cli.StringFlag{
+		Name:  "status",
+		Usage: "Only list requests with this status (e.g. pending)",
+	}
+	PlanFile = &cli.StringFlag{
+		Name:  "plan",
+		Usage: "JSON file with the reviewed plan, as written by membership plan -o json; required with --dry-run=false, which is refused if the membership or the planned changes differ now",
+	}
+)
+
 // ConfigFlags returns configuration-related flags
 func ConfigFlags() []cli.Flag {
 	return []cli.Flag{Config}
 }
 
+// ProviderFlags returns provider-related flags
+func ProviderFlags() []cli.Flag {
+	return []cli.Flag{ProviderType}
+}
+
 // LoggingFlags returns logging-related flags
 func LoggingFlags() []cli.Flag {
 	return []cli.Flag{LogLevel, LogFormat, LogNoColor, LogFile}
@@ -194,8 +375,10 @@ func K8sFlags() []cli.Flag {
 		K8sAppLabel,
 		K8sSequencerRoleLabel,
 		K8sSequencerVoterValues,
+		K8sSequencerNonvoterValue,
 		K8sSequencerModeFilter,
 		ConnectionMode,
+		K8sDiscoveryMode,
 		Namespaces,
 		K8sConductorPort,
 		K8sNodePort,
@@ -210,6 +393,31 @@ func ServerFlags() []cli.Flag {
 	return []cli.Flag{ServerAddress, ServerPort}
 }
 
+// AuthFlags returns authentication-related flags
+func AuthFlags() []cli.Flag {
+	return []cli.Flag{AuthEnabled}
+}
+
+// AuditFlags returns audit-related flags
+func AuditFlags() []cli.Flag {
+	return []cli.Flag{AuditLog}
+}
+
+// ApprovalFlags returns two-person approval flags
+func ApprovalFlags() []cli.Flag {
+	return []cli.Flag{ApprovalEnabled, ApprovalTTL}
+}
+
+// HistoryFlags returns status history flags
+func HistoryFlags() []cli.Flag {
+	return []cli.Flag{HistoryPath, HistoryRetention, HistorySnapshotInterval}
+}
+
+// HealthFlags returns unsafe head health flags
+func HealthFlags() []cli.Flag {
+	return []cli.Flag{HealthStallBlocks, HealthMaxFollowerLag}
+}
+
 // CacheFlags returns cache-related flags
 func CacheFlags() []cli.Flag {
 	return []cli.Flag{CacheDiscoveryTTL, CacheStatusTTL}
@@ -221,7 +429,44 @@ func ServeCommandFlags() []cli.Flag {
 	flags = append(flags, ConfigFlags()...)
 	flags = append(flags, LoggingFlags()...)
 	flags = append(flags, ServerFlags()...)
+	flags = append(flags, AuthFlags()...)
+	flags = append(flags, AuditFlags()...)
+	flags = append(flags, ApprovalFlags()...)
+	flags = append(flags, HistoryFlags()...)
+	flags = append(flags, ProviderFlags()...)
+	flags = append(flags, K8sFlags()...)
+	flags = append(flags, CacheFlags()...)
+	flags = append(flags, HealthFlags()...)
+	return flags
+}
+
+// RemoteFlags returns the flags selecting a seqctl server for the CLI commands
+func RemoteFlags() []cli.Flag {
+	return []cli.Flag{Server, Token}
+}
+
+// CLICommandFlags returns the flags shared by the headless CLI commands, which
+// discover sequencers the same way as the server unless --server is set
+func CLICommandFlags(extra ...cli.Flag) []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, ConfigFlags()...)
+	flags = append(flags, LoggingFlags()...)
+	flags = append(flags, OutputFormat)
+	flags = append(flags, RemoteFlags()...)
+	flags = append(flags, ProviderFlags()...)
+	flags = append(flags, K8sFlags()...)
+	flags = append(flags, extra...)
+	return flags
+}
+
+// TUICommandFlags returns all flags needed for the tui command
+func TUICommandFlags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, ConfigFlags()...)
+	flags = append(flags, LoggingFlags()...)
+	flags = append(flags, ProviderFlags()...)
 	flags = append(flags, K8sFlags()...)
 	flags = append(flags, CacheFlags()...)
+	flags = append(flags, HealthFlags()...)
 	return flags
 }
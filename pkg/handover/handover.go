@@ -0,0 +1,351 @@
+package handover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/rpc"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Default handover settings
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultPollInterval = time.Second
+	DefaultMaxLag       = 5 // Blocks a target may trail the leader's unsafe head
+	rollbackTimeout     = 15 * time.Second
+)
+
+// Step names, in execution order
+const (
+	StepRefresh         = "refresh"
+	StepSelectTarget    = "select_target"
+	StepTransfer        = "transfer_leader"
+	StepAwaitLeadership = "await_leadership"
+	StepAwaitProgress   = "await_progress"
+	StepRollback        = "rollback"
+)
+
+// Step outcomes
+const (
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+)
+
+// Preflight errors, returned before any change is made to the cluster
+var (
+	ErrNoLeader        = errors.New("network has no conductor leader")
+	ErrNoCandidate     = errors.New("no healthy, caught-up voter available")
+	ErrInvalidTarget   = errors.New("invalid handover target")
+	ErrHandoverTimeout = errors.New("handover did not complete in time")
+)
+
+// Options controls a handover
+type Options struct {
+	TargetID     string        // Sequencer to hand over to, picked automatically if empty
+	Timeout      time.Duration // Time allowed for leadership to move and blocks to flow
+	PollInterval time.Duration // Interval between status refreshes while waiting
+	MaxLag       uint64        // Maximum blocks the target may trail the leader
+}
+
+// Step records the outcome of one handover step
+type Step struct {
+	Name     string
+	Status   string
+	Detail   string
+	Started  time.Time
+	Duration time.Duration
+}
+
+// Result describes a completed or failed handover
+type Result struct {
+	Network        string
+	From           string
+	To             string
+	Success        bool
+	RolledBack     bool
+	UnsafeL2Before uint64
+	UnsafeL2After  uint64
+	Steps          []Step
+}
+
+// Run performs a guided leader handover on the network.
+//
+// It selects a healthy, caught-up voter (or validates the requested one),
+// asks the current leader to transfer leadership to it, then waits until the
+// target is both conductor leader and active sequencer and its unsafe head
+// advances. If that does not happen within the timeout, leadership is handed
+// back to the original leader. Errors before the transfer leave the cluster
+// untouched and are returned without a result.
+func Run(ctx context.Context, net *network.Network, opts Options) (*Result, error) {
+	opts = withDefaults(opts)
+	logger := slog.Default().With(slog.String("component", "handover"), slog.String("network", net.Name()))
+
+	result := &Result{Network: net.Name()}
+
+	// Decisions must be made on fresh state, not the cached snapshot
+	result.run(StepRefresh, func() (string, error) {
+		if err := net.Update(ctx); err != nil {
+			return "", err
+		}
+		return "status refreshed", nil
+	})
+
+	from := net.ConductorLeader()
+	if from == nil {
+		return nil, ErrNoLeader
+	}
+	result.From = from.ID()
+	result.UnsafeL2Before = from.UnsafeL2()
+
+	var to *sequencer.Sequencer
+	if err := result.run(StepSelectTarget, func() (string, error) {
+		var err error
+		to, err = selectTarget(net, from, opts)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("selected %s at unsafe L2 %d (leader at %d)", to.ID(), to.UnsafeL2(), from.UnsafeL2()), nil
+	}); err != nil {
+		return nil, err
+	}
+	result.To = to.ID()
+
+	logger.Info("Starting leader handover", "from", from.ID(), "to", to.ID())
+
+	if err := result.run(StepTransfer, func() (string, error) {
+		if err := from.TransferLeaderToServer(ctx, to.ID(), to.RaftAddr()); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("requested transfer from %s to %s", from.ID(), to.ID()), nil
+	}); err != nil {
+		logger.Warn("Leader handover failed", "from", from.ID(), "to", to.ID(), "error", err)
+		return result, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	err := result.run(StepAwaitLeadership, func() (string, error) {
+		// Only fresh probe results count, a value kept from before a failed
+		// probe may be long outdated during the transfer
+		err := poll(waitCtx, net, opts.PollInterval, func() bool {
+			target, previous := to.Status(), from.Status()
+			return target.KnownLeader() && target.KnownActive() &&
+				!previous.KnownLeader() && !previous.KnownActive()
+		})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is conductor leader and active sequencer", to.ID()), nil
+	})
+
+	if err == nil {
+		start := to.UnsafeL2()
+		err = result.run(StepAwaitProgress, func() (string, error) {
+			err := poll(waitCtx, net, opts.PollInterval, func() bool {
+				return to.UnsafeL2() > start
+			})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("unsafe L2 advanced from %d to %d", start, to.UnsafeL2()), nil
+		})
+	}
+
+	if err != nil {
+		logger.Warn("Leader handover did not complete, rolling back",
+			"from", from.ID(), "to", to.ID(), "error", err)
+		result.rollback(ctx, net, from)
+		return result, err
+	}
+
+	result.Success = true
+	result.UnsafeL2After = to.UnsafeL2()
+	logger.Info("Leader handover completed", "from", from.ID(), "to", to.ID(),
+		"unsafe_l2", result.UnsafeL2After)
+
+	return result, nil
+}
+
+// Preview refreshes the network and selects the target the way Run does, and
+// describes the transfer without making it
+func Preview(ctx context.Context, net *network.Network, opts Options) (*action.Preview, error) {
+	opts = withDefaults(opts)
+
+	if err := net.Update(ctx); err != nil {
+		slog.Warn("Failed to refresh network before handover preview",
+			"network", net.Name(), "error", err)
+	}
+
+	from := net.ConductorLeader()
+	if from == nil {
+		return nil, ErrNoLeader
+	}
+	to, err := selectTarget(net, from, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &action.Preview{
+		Calls: []action.Call{{
+			Sequencer: from.ID(),
+			Endpoint:  rpc.EndpointConductor,
+			URL:       from.Config().ConductorURL,
+			Method:    rpc.MethodTransferLeaderToServer,
+			Params:    []any{to.ID(), to.RaftAddr()},
+		}},
+		Effect: fmt.Sprintf("leadership moves from %s to %s at unsafe L2 %d (leader at %d), and back if %s is not "+
+			"leading and producing blocks within %s", from.ID(), to.ID(), to.UnsafeL2(), from.UnsafeL2(), to.ID(), opts.Timeout),
+	}, nil
+}
+
+// rollback hands leadership back to the original leader if it has moved
+func (r *Result) rollback(ctx context.Context, net *network.Network, from *sequencer.Sequencer) {
+	// The caller's context has likely expired, give rollback its own budget
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	err := r.run(StepRollback, func() (string, error) {
+		if err := net.Update(ctx); err != nil {
+			slog.Debug("Status refresh before rollback failed", "network", net.Name(), "error", err)
+		}
+
+		current := net.ConductorLeader()
+		switch {
+		case current == nil:
+			return "", fmt.Errorf("no conductor leader to transfer back from")
+		case current == from:
+			return fmt.Sprintf("leadership remained with %s", from.ID()), nil
+		}
+
+		if err := current.TransferLeaderToServer(ctx, from.ID(), from.RaftAddr()); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("requested transfer from %s back to %s", current.ID(), from.ID()), nil
+	})
+	r.RolledBack = err == nil
+}
+
+// run executes a step and records its outcome
+func (r *Result) run(name string, fn func() (string, error)) error {
+	started := time.Now()
+	detail, err := fn()
+
+	step := Step{
+		Name:     name,
+		Status:   StepSucceeded,
+		Detail:   detail,
+		Started:  started,
+		Duration: time.Since(started),
+	}
+	if err != nil {
+		step.Status = StepFailed
+		step.Detail = err.Error()
+	}
+
+	r.Steps = append(r.Steps, step)
+	return err
+}
+
+// poll refreshes the network until done reports true or ctx expires
+func poll(ctx context.Context, net *network.Network, interval time.Duration, done func() bool) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Individual sequencers may be briefly unreachable during the transfer
+		_ = net.Update(ctx)
+		if done() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrHandoverTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// selectTarget validates the requested target or picks the best candidate
+func selectTarget(net *network.Network, leader *sequencer.Sequencer, opts Options) (*sequencer.Sequencer, error) {
+	leaderHead := leader.UnsafeL2()
+
+	if opts.TargetID != "" {
+		target := net.SequencerByID(opts.TargetID)
+		if target == nil {
+			return nil, fmt.Errorf("%w: sequencer %s not found in network %s", ErrInvalidTarget, opts.TargetID, net.Name())
+		}
+		if target == leader {
+			return nil, fmt.Errorf("%w: sequencer %s is already the leader", ErrInvalidTarget, target.ID())
+		}
+		if reason := ineligible(target.Voting(), target.Status(), leaderHead, opts.MaxLag); reason != "" {
+			return nil, fmt.Errorf("%w: sequencer %s %s", ErrInvalidTarget, target.ID(), reason)
+		}
+		return target, nil
+	}
+
+	var candidates []*sequencer.Sequencer
+	for _, seq := range net.Sequencers() {
+		if seq != leader && ineligible(seq.Voting(), seq.Status(), leaderHead, opts.MaxLag) == "" {
+			candidates = append(candidates, seq)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoCandidate
+	}
+
+	// Prefer the most caught-up voter, breaking ties by ID for determinism
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].UnsafeL2() != candidates[j].UnsafeL2() {
+			return candidates[i].UnsafeL2() > candidates[j].UnsafeL2()
+		}
+		return candidates[i].ID() < candidates[j].ID()
+	})
+
+	return candidates[0], nil
+}
+
+// ineligible returns why a sequencer cannot take over leadership, or an empty
+// string if it can. A sequencer with a failed probe is ineligible, as the rest
+// of its status may be kept from before it became unreachable.
+func ineligible(voting bool, status sequencer.Status, leaderHead, maxLag uint64) string {
+	switch {
+	case !voting:
+		return "is not a voter"
+	case len(status.Checks) == 0:
+		return "has not been probed"
+	case len(status.Failing()) > 0:
+		return fmt.Sprintf("failed its last %s probe", status.Failing()[0])
+	case !status.SequencerHealthy:
+		return "is not healthy"
+	case !status.ConductorActive || status.ConductorPaused || status.ConductorStopped:
+		return "has an inactive conductor"
+	case status.UnsafeL2 == nil:
+		return "has no known unsafe head"
+	case status.UnsafeL2.Number+maxLag < leaderHead:
+		return fmt.Sprintf("is %d blocks behind the leader", leaderHead-status.UnsafeL2.Number)
+	}
+	return ""
+}
+
+// withDefaults fills unset options
+func withDefaults(opts Options) Options {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxLag == 0 {
+		opts.MaxLag = DefaultMaxLag
+	}
+	return opts
+}
@@ -0,0 +1,171 @@
+package handover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// newTestNetwork returns a network whose leader produces a block on every
+// status query
+func newTestNetwork(t *testing.T, c *conductortest.Cluster, voting map[string]bool) *network.Network {
+	t.Helper()
+
+	c.Produce = true
+	return network.NewNetwork("devnet", c.Sequencers(t, voting))
+}
+
+func TestRun_Success(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Heads:  map[string]uint64{"sequencer-0": 100, "sequencer-1": 99, "sequencer-2": 50},
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true, "sequencer-2": true})
+
+	result, err := Run(context.Background(), net, Options{PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Handover failed: %v", err)
+	}
+
+	// sequencer-2 is too far behind, sequencer-1 must be chosen
+	if result.From != "sequencer-0" || result.To != "sequencer-1" {
+		t.Errorf("Expected handover from sequencer-0 to sequencer-1, got %s to %s", result.From, result.To)
+	}
+	if !result.Success || result.RolledBack {
+		t.Errorf("Expected success without rollback, got success=%t rolled_back=%t", result.Success, result.RolledBack)
+	}
+
+	want := []string{StepRefresh, StepSelectTarget, StepTransfer, StepAwaitLeadership, StepAwaitProgress}
+	if len(result.Steps) != len(want) {
+		t.Fatalf("Expected %d steps, got %d", len(want), len(result.Steps))
+	}
+	for i, step := range result.Steps {
+		if step.Name != want[i] || step.Status != StepSucceeded {
+			t.Errorf("Step %d: expected %s to succeed, got %s %s (%s)", i, want[i], step.Name, step.Status, step.Detail)
+		}
+	}
+}
+
+func TestRun_InvalidTarget(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Heads:  map[string]uint64{"sequencer-0": 100, "sequencer-1": 100, "sequencer-2": 100},
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true, "sequencer-2": false})
+
+	_, err := Run(context.Background(), net, Options{TargetID: "sequencer-2"})
+	if !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Expected ErrInvalidTarget for non-voter, got %v", err)
+	}
+
+	_, err = Run(context.Background(), net, Options{TargetID: "sequencer-0"})
+	if !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Expected ErrInvalidTarget for current leader, got %v", err)
+	}
+}
+
+func TestRun_TimeoutRollsBack(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader:         "sequencer-0",
+		Heads:          map[string]uint64{"sequencer-0": 100, "sequencer-1": 100, "sequencer-2": 100},
+		IgnoreTransfer: true,
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true, "sequencer-2": true})
+
+	result, err := Run(context.Background(), net, Options{
+		Timeout:      100 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrHandoverTimeout) {
+		t.Fatalf("Expected ErrHandoverTimeout, got %v", err)
+	}
+	if result.Success || !result.RolledBack {
+		t.Errorf("Expected rolled back failure, got success=%t rolled_back=%t", result.Success, result.RolledBack)
+	}
+
+	last := result.Steps[len(result.Steps)-1]
+	if last.Name != StepRollback || last.Status != StepSucceeded {
+		t.Errorf("Expected final rollback step to succeed, got %s %s", last.Name, last.Status)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Heads:  map[string]uint64{"sequencer-0": 100, "sequencer-1": 99, "sequencer-2": 50},
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true, "sequencer-2": true})
+
+	preview, err := Preview(context.Background(), net, Options{})
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+
+	if len(preview.Calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(preview.Calls))
+	}
+	call := preview.Calls[0]
+	if call.Sequencer != "sequencer-0" || call.Method != "conductor_transferLeaderToServer" || call.Params[0] != "sequencer-1" {
+		t.Errorf("Expected transfer to sequencer-1 through sequencer-0, got %+v", call)
+	}
+
+	// Nothing was sent
+	c.Lock()
+	defer c.Unlock()
+	if c.Leader != "sequencer-0" {
+		t.Errorf("Expected sequencer-0 to remain leader, got %s", c.Leader)
+	}
+}
+
+func TestRun_SkipsUnreachableTarget(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Heads:  map[string]uint64{"sequencer-0": 100, "sequencer-1": 100, "sequencer-2": 99},
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true, "sequencer-2": true})
+	_ = net.Update(context.Background())
+
+	// sequencer-1 keeps its healthy, caught-up status from before it went down
+	c.Lock()
+	c.Down = map[string]bool{"sequencer-1": true}
+	c.Unlock()
+
+	result, err := Run(context.Background(), net, Options{PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Handover failed: %v", err)
+	}
+	if result.To != "sequencer-2" {
+		t.Errorf("Expected handover to reachable sequencer-2, got %s", result.To)
+	}
+
+	_, err = Run(context.Background(), net, Options{TargetID: "sequencer-1"})
+	if !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Expected ErrInvalidTarget for unreachable sequencer-1, got %v", err)
+	}
+}
+
+func TestRun_IgnoresStaleLeadershipOfPreviousLeader(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader:         "sequencer-0",
+		Heads:          map[string]uint64{"sequencer-0": 100, "sequencer-1": 100},
+		DownOnTransfer: true,
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true})
+
+	// sequencer-0 still shows as leader and active once unreachable
+	result, err := Run(context.Background(), net, Options{
+		Timeout:      time.Second,
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Handover failed: %v", err)
+	}
+	if !result.Success || result.RolledBack || result.To != "sequencer-1" {
+		t.Errorf("Expected success to sequencer-1 without rollback, got to=%s success=%t rolled_back=%t",
+			result.To, result.Success, result.RolledBack)
+	}
+}
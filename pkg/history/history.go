@@ -0,0 +1,371 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Query limits
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Top-level buckets. Each holds one nested bucket per network, whose keys
+// start with the big-endian record time so that cursors walk them in order.
+var (
+	snapshotsBucket   = []byte("snapshots")
+	transitionsBucket = []byte("transitions")
+)
+
+// Fields whose changes are recorded as transitions
+const (
+	FieldConductorActive  = "conductor_active"
+	FieldConductorLeader  = "conductor_leader"
+	FieldConductorPaused  = "conductor_paused"
+	FieldConductorStopped = "conductor_stopped"
+	FieldSequencerHealthy = "sequencer_healthy"
+	FieldSequencerActive  = "sequencer_active"
+	FieldReachability     = "reachability"
+)
+
+// State is the persisted form of a sequencer status
+type State struct {
+	ConductorActive  bool   `json:"conductor_active"`
+	ConductorLeader  bool   `json:"conductor_leader"`
+	ConductorPaused  bool   `json:"conductor_paused"`
+	ConductorStopped bool   `json:"conductor_stopped"`
+	SequencerHealthy bool   `json:"sequencer_healthy"`
+	SequencerActive  bool   `json:"sequencer_active"`
+	Reachability     string `json:"reachability"`
+	UnsafeL2         uint64 `json:"unsafe_l2"`
+	SafeL2           uint64 `json:"safe_l2"`
+}
+
+// StateOf captures a sequencer status for the history
+func StateOf(status sequencer.Status) State {
+	state := State{
+		ConductorActive:  status.ConductorActive,
+		ConductorLeader:  status.ConductorLeader,
+		ConductorPaused:  status.ConductorPaused,
+		ConductorStopped: status.ConductorStopped,
+		SequencerHealthy: status.SequencerHealthy,
+		SequencerActive:  status.SequencerActive,
+		Reachability:     string(status.Reachability()),
+	}
+	if status.UnsafeL2 != nil {
+		state.UnsafeL2 = status.UnsafeL2.Number
+	}
+	if status.SafeL2 != nil {
+		state.SafeL2 = status.SafeL2.Number
+	}
+	return state
+}
+
+// fields returns the tracked fields of a state as strings
+func (s State) fields() map[string]string {
+	return map[string]string{
+		FieldConductorActive:  strconv.FormatBool(s.ConductorActive),
+		FieldConductorLeader:  strconv.FormatBool(s.ConductorLeader),
+		FieldConductorPaused:  strconv.FormatBool(s.ConductorPaused),
+		FieldConductorStopped: strconv.FormatBool(s.ConductorStopped),
+		FieldSequencerHealthy: strconv.FormatBool(s.SequencerHealthy),
+		FieldSequencerActive:  strconv.FormatBool(s.SequencerActive),
+		FieldReachability:     s.Reachability,
+	}
+}
+
+// Snapshot is the state of a sequencer at a point in time
+type Snapshot struct {
+	Time      time.Time `json:"time"`
+	Network   string    `json:"network"`
+	Sequencer string    `json:"sequencer"`
+	State     State     `json:"state"`
+}
+
+// Transition is a change of one tracked field of a sequencer
+type Transition struct {
+	Time      time.Time `json:"time"`
+	Network   string    `json:"network"`
+	Sequencer string    `json:"sequencer"`
+	Field     string    `json:"field"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+}
+
+// Diff returns the transitions between two states of a sequencer, ordered by
+// field name
+func Diff(at time.Time, networkName, sequencerID string, from, to State) []Transition {
+	before, after := from.fields(), to.fields()
+
+	var transitions []Transition
+	for _, field := range []string{
+		FieldConductorActive,
+		FieldConductorLeader,
+		FieldConductorPaused,
+		FieldConductorStopped,
+		FieldReachability,
+		FieldSequencerActive,
+		FieldSequencerHealthy,
+	} {
+		if before[field] != after[field] {
+			transitions = append(transitions, Transition{
+				Time:      at,
+				Network:   networkName,
+				Sequencer: sequencerID,
+				Field:     field,
+				From:      before[field],
+				To:        after[field],
+			})
+		}
+	}
+	return transitions
+}
+
+// Query selects transitions of a network in [Since, Until). An empty
+// Sequencer matches all sequencers of the network.
+type Query struct {
+	Network   string
+	Sequencer string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Result is the history of a network over a time range
+type Result struct {
+	// Initial is the last state recorded for each sequencer before Since,
+	// ordered by sequencer ID
+	Initial []Snapshot
+
+	// Transitions are the changes within the range, oldest first
+	Transitions []Transition
+
+	// Total is the number of transitions in the range before the limit was
+	// applied
+	Total int
+}
+
+// Store keeps sequencer history in an embedded BoltDB database
+type Store struct {
+	db     *bolt.DB
+	logger *slog.Logger
+}
+
+// Open opens or creates the history database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o640, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{snapshotsBucket, transitionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize history database: %w", err)
+	}
+
+	s := &Store{
+		db:     db,
+		logger: slog.Default().With(slog.String("component", "history")),
+	}
+	s.logger.Info("History database opened", "path", path)
+	return s, nil
+}
+
+// Record durably stores snapshots and transitions in a single transaction
+func (s *Store) Record(snapshots []Snapshot, transitions []Transition) error {
+	if len(snapshots) == 0 && len(transitions) == 0 {
+		return nil
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, snap := range snapshots {
+			if err := put(tx, snapshotsBucket, snap.Network, recordKey(snap.Time, snap.Sequencer), snap); err != nil {
+				return err
+			}
+		}
+		for _, t := range transitions {
+			if err := put(tx, transitionsBucket, t.Network, recordKey(t.Time, t.Sequencer, t.Field), t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
+
+// Query returns the history of a network over a time range
+func (s *Store) Query(q Query) (*Result, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	result := &Result{Initial: []Snapshot{}, Transitions: []Transition{}}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// Walk snapshots backwards from Since, keeping the newest one of
+		// each sequencer
+		if b := tx.Bucket(snapshotsBucket).Bucket([]byte(q.Network)); b != nil {
+			seen := make(map[string]bool)
+			c := b.Cursor()
+			k, v := c.Seek(timeKey(q.Since))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+			for ; k != nil; k, v = c.Prev() {
+				var snap Snapshot
+				if err := json.Unmarshal(v, &snap); err != nil {
+					s.logger.Warn("Skipping malformed history snapshot", "network", q.Network, "error", err)
+					continue
+				}
+				if !snap.Time.Before(q.Since) || seen[snap.Sequencer] ||
+					(q.Sequencer != "" && snap.Sequencer != q.Sequencer) {
+					continue
+				}
+				seen[snap.Sequencer] = true
+				result.Initial = append(result.Initial, snap)
+				if q.Sequencer != "" {
+					break
+				}
+			}
+		}
+
+		if b := tx.Bucket(transitionsBucket).Bucket([]byte(q.Network)); b != nil {
+			c := b.Cursor()
+			until := timeKey(q.Until)
+			for k, v := c.Seek(timeKey(q.Since)); k != nil; k, v = c.Next() {
+				if !q.Until.IsZero() && bytes.Compare(k[:8], until) >= 0 {
+					break
+				}
+				var t Transition
+				if err := json.Unmarshal(v, &t); err != nil {
+					s.logger.Warn("Skipping malformed history transition", "network", q.Network, "error", err)
+					continue
+				}
+				if q.Sequencer != "" && t.Sequencer != q.Sequencer {
+					continue
+				}
+				result.Total++
+				if len(result.Transitions) < limit {
+					result.Transitions = append(result.Transitions, t)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+
+	sort.Slice(result.Initial, func(i, j int) bool {
+		return result.Initial[i].Sequencer < result.Initial[j].Sequencer
+	})
+	return result, nil
+}
+
+// Prune deletes all records older than before and returns how many were
+// removed
+func (s *Store) Prune(before time.Time) (int, error) {
+	removed := 0
+	cutoff := timeKey(before)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{snapshotsBucket, transitionsBucket} {
+			err := tx.Bucket(name).ForEach(func(networkName, _ []byte) error {
+				b := tx.Bucket(name).Bucket(networkName)
+
+				// Collect first, deleting under a cursor skips keys
+				var expired [][]byte
+				c := b.Cursor()
+				for k, _ := c.First(); k != nil && bytes.Compare(k[:8], cutoff) < 0; k, _ = c.Next() {
+					expired = append(expired, append([]byte(nil), k...))
+				}
+				for _, k := range expired {
+					if err := b.Delete(k); err != nil {
+						return err
+					}
+				}
+				removed += len(expired)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("failed to prune history: %w", err)
+	}
+	return removed, nil
+}
+
+// Close closes the history database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// put stores a JSON record in the network's nested bucket
+func put(tx *bolt.Tx, bucket []byte, networkName string, key []byte, record any) error {
+	b, err := tx.Bucket(bucket).CreateBucketIfNotExists([]byte(networkName))
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode history record: %w", err)
+	}
+	return b.Put(key, value)
+}
+
+// timeKey encodes a time so that keys sort chronologically
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if !t.IsZero() {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	return key
+}
+
+// recordKey is the time of a record followed by the parts that make it
+// unique at that time
+func recordKey(t time.Time, parts ...string) []byte {
+	key := timeKey(t)
+	for _, part := range parts {
+		key = append(key, 0)
+		key = append(key, part...)
+	}
+	return key
+}
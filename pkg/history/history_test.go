@@ -0,0 +1,165 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestDiff(t *testing.T) {
+	at := time.Unix(1_700_000_000, 0)
+	from := State{ConductorLeader: true, SequencerActive: true, Reachability: "reachable", UnsafeL2: 10}
+	to := State{ConductorLeader: false, SequencerActive: true, Reachability: "degraded", UnsafeL2: 11}
+
+	transitions := Diff(at, "devnet", "sequencer-0", from, to)
+	if len(transitions) != 2 {
+		t.Fatalf("Diff() = %+v, want 2 transitions", transitions)
+	}
+	if got := transitions[0]; got.Field != FieldConductorLeader || got.From != "true" || got.To != "false" {
+		t.Errorf("transitions[0] = %+v, want conductor_leader true -> false", got)
+	}
+	if got := transitions[1]; got.Field != FieldReachability || got.From != "reachable" || got.To != "degraded" {
+		t.Errorf("transitions[1] = %+v, want reachability reachable -> degraded", got)
+	}
+}
+
+func TestStore_Query(t *testing.T) {
+	store := openStore(t)
+	base := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	err := store.Record(
+		[]Snapshot{
+			{Time: base, Network: "devnet", Sequencer: "sequencer-0", State: State{ConductorLeader: true}},
+			{Time: base, Network: "devnet", Sequencer: "sequencer-1"},
+			{Time: base.Add(5 * time.Minute), Network: "devnet", Sequencer: "sequencer-0"},
+			{Time: base, Network: "testnet", Sequencer: "sequencer-9", State: State{ConductorLeader: true}},
+		},
+		[]Transition{
+			{Time: base.Add(5 * time.Minute), Network: "devnet", Sequencer: "sequencer-0", Field: FieldConductorLeader, From: "true", To: "false"},
+			{Time: base.Add(5 * time.Minute), Network: "devnet", Sequencer: "sequencer-1", Field: FieldConductorLeader, From: "false", To: "true"},
+			{Time: base.Add(20 * time.Minute), Network: "devnet", Sequencer: "sequencer-1", Field: FieldSequencerHealthy, From: "true", To: "false"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Record() = %v", err)
+	}
+
+	result, err := store.Query(Query{Network: "devnet", Since: base.Add(time.Minute), Until: base.Add(10 * time.Minute)})
+	if err != nil {
+		t.Fatalf("Query() = %v", err)
+	}
+	if len(result.Initial) != 2 || result.Initial[0].Sequencer != "sequencer-0" || !result.Initial[0].State.ConductorLeader {
+		t.Errorf("Initial = %+v, want sequencer-0 as leader and sequencer-1", result.Initial)
+	}
+	if result.Total != 2 || len(result.Transitions) != 2 {
+		t.Errorf("Transitions = %+v (total %d), want the two leadership changes", result.Transitions, result.Total)
+	}
+
+	result, err = store.Query(Query{Network: "devnet", Sequencer: "sequencer-1", Since: base, Until: base.Add(time.Hour), Limit: 1})
+	if err != nil {
+		t.Fatalf("Query() = %v", err)
+	}
+	if result.Total != 2 || len(result.Transitions) != 1 || result.Transitions[0].Field != FieldConductorLeader {
+		t.Errorf("Transitions = %+v (total %d), want the first of two sequencer-1 transitions", result.Transitions, result.Total)
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	store := openStore(t)
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	var snapshots []Snapshot
+	for i := range 5 {
+		snapshots = append(snapshots, Snapshot{Time: base.Add(time.Duration(i) * time.Hour), Network: "devnet", Sequencer: "sequencer-0"})
+	}
+	if err := store.Record(snapshots, nil); err != nil {
+		t.Fatalf("Record() = %v", err)
+	}
+
+	removed, err := store.Prune(base.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("Prune() = %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Prune() removed %d records, want 3", removed)
+	}
+
+	result, err := store.Query(Query{Network: "devnet", Since: base.Add(10 * time.Hour)})
+	if err != nil {
+		t.Fatalf("Query() = %v", err)
+	}
+	if len(result.Initial) != 1 || !result.Initial[0].Time.Equal(base.Add(4*time.Hour)) {
+		t.Errorf("Initial = %+v, want the newest snapshot", result.Initial)
+	}
+}
+
+func TestRecorder_HandleChanges(t *testing.T) {
+	store := openStore(t)
+	recorder := NewRecorder(store, DefaultRetention, time.Hour)
+
+	seq, err := sequencer.New(context.Background(), sequencer.Config{
+		ID:           "sequencer-0",
+		ConductorURL: "http://127.0.0.1:1",
+		NodeURL:      "http://127.0.0.1:1",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sequencer: %v", err)
+	}
+	net := network.NewNetwork("devnet", []*sequencer.Sequencer{seq})
+
+	base := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	status := func(at time.Time, leader bool) sequencer.Status {
+		checks := make(map[sequencer.Check]sequencer.CheckResult)
+		for _, check := range sequencer.Checks {
+			checks[check] = sequencer.CheckResult{CheckedAt: at, SucceededAt: at}
+		}
+		return sequencer.Status{ConductorLeader: leader, Checks: checks, LastUpdateTime: at}
+	}
+
+	// A sequencer that was never updated is skipped
+	recorder.HandleChanges(net, []network.StatusChange{{Sequencer: seq, Current: sequencer.Status{}}})
+
+	// The first state is recorded without transitions, an unchanged one
+	// within the snapshot interval is not recorded at all
+	recorder.HandleChanges(net, []network.StatusChange{{Sequencer: seq, Current: status(base, true)}})
+	recorder.HandleChanges(net, []network.StatusChange{{Sequencer: seq, Current: status(base.Add(time.Minute), true)}})
+	recorder.HandleChanges(net, []network.StatusChange{{Sequencer: seq, Current: status(base.Add(2*time.Minute), false)}})
+
+	result, err := store.Query(Query{Network: "devnet", Since: base, Until: base.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Query() = %v", err)
+	}
+	if len(result.Transitions) != 1 {
+		t.Fatalf("Transitions = %+v, want one leadership change", result.Transitions)
+	}
+	if got := result.Transitions[0]; got.Field != FieldConductorLeader || !got.Time.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("Transition = %+v, want conductor_leader at 03:02", got)
+	}
+
+	// A new recorder, as after a restart, continues from the stored state
+	restarted := NewRecorder(store, DefaultRetention, time.Hour)
+	restarted.HandleChanges(net, []network.StatusChange{{Sequencer: seq, Current: status(base.Add(3*time.Minute), true)}})
+
+	result, err = store.Query(Query{Network: "devnet", Since: base, Until: base.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Query() = %v", err)
+	}
+	if len(result.Transitions) != 2 || result.Transitions[1].To != "true" {
+		t.Errorf("Transitions = %+v, want leadership to be regained after the restart", result.Transitions)
+	}
+}
@@ -0,0 +1,138 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Recorder defaults
+const (
+	DefaultRetention        = 7 * 24 * time.Hour
+	DefaultSnapshotInterval = time.Minute
+
+	// pruneInterval is how often records older than the retention are removed
+	pruneInterval = time.Hour
+)
+
+// Recorder persists the status changes reported by the network repository.
+// Every transition of a tracked field is recorded together with a snapshot of
+// the sequencer. Without transitions, snapshots are written at most once per
+// snapshot interval, since the unsafe head changes on nearly every poll.
+type Recorder struct {
+	store            *Store
+	retention        time.Duration
+	snapshotInterval time.Duration
+	logger           *slog.Logger
+
+	mu   sync.Mutex
+	last map[string]Snapshot // Last recorded snapshot, keyed by network and sequencer
+}
+
+// NewRecorder creates a recorder writing to store
+func NewRecorder(store *Store, retention, snapshotInterval time.Duration) *Recorder {
+	return &Recorder{
+		store:            store,
+		retention:        retention,
+		snapshotInterval: snapshotInterval,
+		logger:           slog.Default().With(slog.String("component", "history")),
+		last:             make(map[string]Snapshot),
+	}
+}
+
+// HandleChanges records the changes of a network update. It is a
+// network.ChangeHandler.
+func (r *Recorder) HandleChanges(net *network.Network, changes []network.StatusChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		snapshots   []Snapshot
+		transitions []Transition
+	)
+	for _, change := range changes {
+		// Nothing is known about a sequencer that was never updated
+		if change.Current.Reachability() == sequencer.ReachabilityUnknown {
+			continue
+		}
+
+		at := change.Current.LastUpdateTime.UTC()
+		if at.IsZero() {
+			at = time.Now().UTC()
+		}
+
+		snap := Snapshot{
+			Time:      at,
+			Network:   net.Name(),
+			Sequencer: change.Sequencer.ID(),
+			State:     StateOf(change.Current),
+		}
+
+		// Compare against what was recorded rather than the previous status,
+		// which is empty after a rediscovery or restart
+		key := snap.Network + "/" + snap.Sequencer
+		last, ok := r.last[key]
+		if !ok {
+			last, ok = r.latest(snap.Network, snap.Sequencer, at)
+		}
+
+		var diff []Transition
+		if ok {
+			diff = Diff(at, snap.Network, snap.Sequencer, last.State, snap.State)
+		}
+		if ok && len(diff) == 0 && at.Sub(last.Time) < r.snapshotInterval {
+			continue
+		}
+
+		snapshots = append(snapshots, snap)
+		transitions = append(transitions, diff...)
+		r.last[key] = snap
+	}
+
+	if err := r.store.Record(snapshots, transitions); err != nil {
+		r.logger.Error("Failed to record status history",
+			"network", net.Name(),
+			"error", err)
+	}
+}
+
+// latest looks up the last snapshot of a sequencer recorded before at
+func (r *Recorder) latest(networkName, sequencerID string, at time.Time) (Snapshot, bool) {
+	result, err := r.store.Query(Query{Network: networkName, Sequencer: sequencerID, Since: at, Limit: 1})
+	if err != nil {
+		r.logger.Warn("Failed to load last recorded state",
+			"network", networkName,
+			"sequencer", sequencerID,
+			"error", err)
+		return Snapshot{}, false
+	}
+	if len(result.Initial) == 0 {
+		return Snapshot{}, false
+	}
+	return result.Initial[0], true
+}
+
+// Run removes records older than the retention until ctx is cancelled
+func (r *Recorder) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := r.store.Prune(time.Now().Add(-r.retention))
+		if err != nil {
+			r.logger.Warn("Failed to prune status history", "error", err)
+		} else if removed > 0 {
+			r.logger.Info("Pruned status history", "records", removed, "retention", r.retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
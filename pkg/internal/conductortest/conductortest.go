@@ -0,0 +1,243 @@
+// Package conductortest simulates op-conductor clusters and their op-node
+// sequencers over JSON-RPC for tests.
+package conductortest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Cluster simulates a set of conductors and their nodes sharing one Raft
+// configuration. Every member answers the conductor and node methods on a
+// single server. Lock the cluster to change it while its servers are in use.
+type Cluster struct {
+	sync.Mutex
+
+	Leader     string                 // Conductor leader, which is also the active sequencer
+	Leading    map[string]bool        // Other members claiming conductor leadership
+	Sequencing map[string]bool        // Other members actively sequencing
+	Heads      map[string]uint64      // Unsafe L2 head number of each member
+	Hashes     map[string]common.Hash // Unsafe L2 head hash of each member
+	Produce    bool                   // The leader produces a block on every sync status query
+
+	Servers        []consensus.ServerInfo // Raft configuration
+	Version        uint64                 // Raft configuration version, bumped by every change
+	IgnoreTransfer bool                   // Accept leadership transfers without moving leadership
+	DownOnTransfer bool                   // A leader stops answering once it transfers leadership
+
+	Down     map[string]bool // Members failing every request
+	NodeDown map[string]bool // Members whose node fails while their conductor answers
+	Failing  map[string]bool // Methods failing on every member
+
+	servers map[string]*httptest.Server // Started by Sequencer, keyed by member
+}
+
+// Handler returns the JSON-RPC handler of the member id
+func (c *Cluster) Handler(id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     json.RawMessage   `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		c.Lock()
+		defer c.Unlock()
+
+		if c.Down[id] || (c.NodeDown[id] && !strings.HasPrefix(req.Method, "conductor_")) {
+			http.Error(w, "Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		result, err := c.call(id, req.Method, req.Params)
+		if err != nil {
+			resp["error"] = map[string]any{"code": -32000, "message": err.Error()}
+		} else {
+			resp["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// call answers a method on the member id. The lock must be held.
+func (c *Cluster) call(id, method string, params []json.RawMessage) (any, error) {
+	if c.Failing[method] {
+		return nil, errors.New("method failed")
+	}
+
+	switch method {
+	case "conductor_leader":
+		return c.Leader == id || c.Leading[id], nil
+	case "admin_sequencerActive":
+		return c.Leader == id || c.Sequencing[id], nil
+	case "conductor_paused", "conductor_stopped":
+		return false, nil
+	case "optimism_syncStatus":
+		if c.Produce && c.Leader == id {
+			if c.Heads == nil {
+				c.Heads = make(map[string]uint64)
+			}
+			c.Heads[id]++
+		}
+		return map[string]any{"unsafe_l2": map[string]any{"number": c.Heads[id], "hash": c.Hashes[id]}}, nil
+	case "conductor_clusterMembership":
+		return consensus.ClusterMembership{Servers: c.Servers, Version: c.Version}, nil
+	case "conductor_transferLeaderToServer":
+		var target string
+		_ = json.Unmarshal(params[0], &target)
+		if !c.IgnoreTransfer {
+			c.Leader = target
+		}
+		if c.DownOnTransfer {
+			if c.Down == nil {
+				c.Down = make(map[string]bool)
+			}
+			c.Down[id] = true
+		}
+		return nil, nil
+	case "conductor_addServerAsVoter", "conductor_addServerAsNonvoter", "conductor_removeServer":
+		return nil, c.change(method, params)
+	default:
+		return true, nil
+	}
+}
+
+// change applies a membership change the way Raft does, refusing it unless
+// it was made against the current version. The lock must be held.
+func (c *Cluster) change(method string, params []json.RawMessage) error {
+	var (
+		id, addr string
+		version  uint64
+	)
+	_ = json.Unmarshal(params[0], &id)
+	if method == "conductor_removeServer" {
+		_ = json.Unmarshal(params[1], &version)
+	} else {
+		_ = json.Unmarshal(params[1], &addr)
+		_ = json.Unmarshal(params[2], &version)
+	}
+	if version != c.Version {
+		return errors.New("configuration changed since prevIndex")
+	}
+	c.Version++
+
+	i := slices.IndexFunc(c.Servers, func(s consensus.ServerInfo) bool { return s.ID == id })
+	switch method {
+	case "conductor_removeServer":
+		if i >= 0 {
+			c.Servers = slices.Delete(c.Servers, i, i+1)
+		}
+	case "conductor_addServerAsVoter":
+		if i >= 0 {
+			c.Servers[i].Suffrage = consensus.Voter
+		} else {
+			c.Servers = append(c.Servers, consensus.ServerInfo{ID: id, Addr: addr, Suffrage: consensus.Voter})
+		}
+	case "conductor_addServerAsNonvoter":
+		// Like Raft, adding an existing voter as a non-voter does not demote it
+		if i < 0 {
+			c.Servers = append(c.Servers, consensus.ServerInfo{ID: id, Addr: addr, Suffrage: consensus.Nonvoter})
+		}
+	}
+	return nil
+}
+
+// Suffrage returns the suffrage of a member of the Raft configuration
+func (c *Cluster) Suffrage(id string) (consensus.ServerSuffrage, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, server := range c.Servers {
+		if server.ID == id {
+			return server.Suffrage, true
+		}
+	}
+	return 0, false
+}
+
+// Sequencer returns a new sequencer connected to the member id, with the Raft
+// address "<id>:50050" on network devnet. The member's server is started on
+// first use, later sequencers of the member share its URL.
+func (c *Cluster) Sequencer(t testing.TB, id string, voting bool) *sequencer.Sequencer {
+	t.Helper()
+
+	c.Lock()
+	server, ok := c.servers[id]
+	if !ok {
+		server = httptest.NewServer(c.Handler(id))
+		t.Cleanup(server.Close)
+		if c.servers == nil {
+			c.servers = make(map[string]*httptest.Server)
+		}
+		c.servers[id] = server
+	}
+	c.Unlock()
+
+	seq, err := sequencer.New(context.Background(), sequencer.Config{
+		ID:           id,
+		RaftAddr:     id + ":50050",
+		ConductorURL: server.URL,
+		NodeURL:      server.URL,
+		Voting:       voting,
+		Network:      "devnet",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sequencer: %v", err)
+	}
+	return seq
+}
+
+// Sequencers returns a sequencer for each member, sorted by ID and voting as
+// given
+func (c *Cluster) Sequencers(t testing.TB, voting map[string]bool) []*sequencer.Sequencer {
+	t.Helper()
+
+	ids := make([]string, 0, len(voting))
+	for id := range voting {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	sequencers := make([]*sequencer.Sequencer, 0, len(ids))
+	for _, id := range ids {
+		sequencers = append(sequencers, c.Sequencer(t, id, voting[id]))
+	}
+	return sequencers
+}
+
+// Voters returns a voting map of the given members for Sequencers
+func Voters(ids ...string) map[string]bool {
+	voting := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		voting[id] = true
+	}
+	return voting
+}
+
+// Networks serves a fixed set of networks to anything listing them, such as
+// the metrics collector or the alert manager
+type Networks[N any] map[string]N
+
+// ListNetworks returns the networks
+func (n Networks[N]) ListNetworks(context.Context) (map[string]N, error) {
+	return n, nil
+}
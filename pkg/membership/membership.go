@@ -0,0 +1,174 @@
+// Package membership reads the Raft cluster membership of a network through
+// its conductors and cross-references it against the discovered sequencers
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// ErrUnavailable is returned when no conductor of a network answered
+var ErrUnavailable = errors.New("cluster membership unavailable")
+
+// Member is a server in the Raft configuration
+type Member struct {
+	ID        string
+	Addr      string
+	Voter     bool
+	Leader    bool                 // The member is the network's conductor leader
+	Sequencer *sequencer.Sequencer // Discovered sequencer with the member's ID, nil if unknown
+}
+
+// Membership is the Raft configuration of a network
+type Membership struct {
+	Network    string
+	Version    uint64
+	Source     string // Sequencer the configuration was read from
+	FromLeader bool   // Source is the conductor leader
+	Members    []Member
+	Missing    []*sequencer.Sequencer // Discovered sequencers that are not members
+}
+
+// Member returns the member with the given server ID
+func (m *Membership) Member(id string) (Member, bool) {
+	for _, member := range m.Members {
+		if member.ID == id {
+			return member, true
+		}
+	}
+	return Member{}, false
+}
+
+// Reachable reports whether the member's conductor answered the last status
+// update. Unknown members are never reachable.
+func (m Member) Reachable() bool {
+	return m.Sequencer != nil && m.Sequencer.Status().Checks[sequencer.CheckConductorLeader].OK()
+}
+
+// Voters returns the number of voting members
+func (m *Membership) Voters() int {
+	voters := 0
+	for _, member := range m.Members {
+		if member.Voter {
+			voters++
+		}
+	}
+	return voters
+}
+
+// CheckRemoval returns an error if removing the member would remove the
+// conductor leader, or leave fewer reachable voters than the remaining
+// configuration needs for a quorum
+func (m *Membership) CheckRemoval(id string) error {
+	member, ok := m.Member(id)
+	if !ok {
+		return fmt.Errorf("%s is not a member of %s", id, m.Network)
+	}
+	if member.Leader {
+		return fmt.Errorf("%s is the conductor leader, transfer leadership first", id)
+	}
+	if !member.Voter {
+		return nil
+	}
+
+	voters, reachable := 0, 0
+	for _, other := range m.Members {
+		if other.ID == id || !other.Voter {
+			continue
+		}
+		voters++
+		if other.Reachable() {
+			reachable++
+		}
+	}
+	if voters == 0 {
+		return fmt.Errorf("%s is the last voter of %s", id, m.Network)
+	}
+	if quorum := voters/2 + 1; reachable < quorum {
+		return fmt.Errorf("removing %s leaves %d of %d voters reachable, a quorum needs %d",
+			id, reachable, voters, quorum)
+	}
+	return nil
+}
+
+// Get reads the cluster membership of a network from its conductor leader.
+// When no leader is known or it fails to answer, the other reachable
+// sequencers are asked in turn.
+func Get(ctx context.Context, net *network.Network) (*Membership, error) {
+	leader := net.ConductorLeader()
+
+	candidates := make([]*sequencer.Sequencer, 0, len(net.Sequencers()))
+	if leader != nil {
+		candidates = append(candidates, leader)
+	}
+	for _, seq := range net.Sequencers() {
+		if seq != leader && seq.Status().Reachability() != sequencer.ReachabilityUnreachable {
+			candidates = append(candidates, seq)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no reachable sequencer in %s", ErrUnavailable, net.Name())
+	}
+
+	var errs []error
+	for _, seq := range candidates {
+		cm, err := seq.GetClusterMembership(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		return newMembership(net, seq, seq == leader, cm), nil
+	}
+	return nil, fmt.Errorf("%w: %w", ErrUnavailable, errors.Join(errs...))
+}
+
+// Read reads the cluster membership of a network through the given
+// sequencer only
+func Read(ctx context.Context, net *network.Network, seq *sequencer.Sequencer) (*Membership, error) {
+	cm, err := seq.GetClusterMembership(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return newMembership(net, seq, seq == net.ConductorLeader(), cm), nil
+}
+
+// newMembership cross-references a Raft configuration against the
+// sequencers of a network
+func newMembership(net *network.Network, source *sequencer.Sequencer, fromLeader bool, cm *consensus.ClusterMembership) *Membership {
+	leaderID := ""
+	if leader := net.ConductorLeader(); leader != nil {
+		leaderID = leader.ID()
+	}
+
+	m := &Membership{
+		Network:    net.Name(),
+		Version:    cm.Version,
+		Source:     source.ID(),
+		FromLeader: fromLeader,
+		Members:    make([]Member, 0, len(cm.Servers)),
+	}
+	for _, server := range cm.Servers {
+		m.Members = append(m.Members, Member{
+			ID:        server.ID,
+			Addr:      server.Addr,
+			Voter:     server.Suffrage == consensus.Voter,
+			Leader:    server.ID == leaderID,
+			Sequencer: net.SequencerByID(server.ID),
+		})
+	}
+	for _, seq := range net.Sequencers() {
+		if _, ok := m.Member(seq.ID()); !ok {
+			m.Missing = append(m.Missing, seq)
+		}
+	}
+	return m
+}
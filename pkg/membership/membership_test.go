@@ -0,0 +1,121 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+func newTestNetwork(t *testing.T, c *conductortest.Cluster, ids ...string) *network.Network {
+	t.Helper()
+
+	net := network.NewNetwork("devnet", c.Sequencers(t, conductortest.Voters(ids...)))
+	_ = net.Update(context.Background())
+	return net
+}
+
+func TestGet(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Servers: []consensus.ServerInfo{
+			{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-1", Addr: "sequencer-1:50050", Suffrage: consensus.Nonvoter},
+			{ID: "sequencer-9", Addr: "sequencer-9:50050", Suffrage: consensus.Voter},
+		},
+		Version: 7,
+	}
+	net := newTestNetwork(t, c, "sequencer-0", "sequencer-1", "sequencer-2")
+
+	m, err := Get(context.Background(), net)
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if m.Version != 7 || m.Source != "sequencer-0" || !m.FromLeader {
+		t.Errorf("Get() = version %d from %s (leader %v), want version 7 from the leader", m.Version, m.Source, m.FromLeader)
+	}
+	if m.Voters() != 2 {
+		t.Errorf("Voters() = %d, want 2", m.Voters())
+	}
+
+	if member, _ := m.Member("sequencer-0"); !member.Leader || !member.Voter || member.Sequencer == nil {
+		t.Errorf("Member(sequencer-0) = %+v, want the discovered voting leader", member)
+	}
+	if member, _ := m.Member("sequencer-1"); member.Voter || member.Leader {
+		t.Errorf("Member(sequencer-1) = %+v, want a non-voting follower", member)
+	}
+	if member, _ := m.Member("sequencer-9"); member.Sequencer != nil {
+		t.Errorf("Member(sequencer-9) = %+v, want an unknown member", member)
+	}
+	if len(m.Missing) != 1 || m.Missing[0].ID() != "sequencer-2" {
+		t.Errorf("Missing = %v, want sequencer-2", m.Missing)
+	}
+}
+
+func TestGet_FallsBackFromLeader(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader:  "sequencer-0",
+		Servers: []consensus.ServerInfo{{ID: "sequencer-0", Addr: "sequencer-0:50050"}},
+	}
+	net := newTestNetwork(t, c, "sequencer-0", "sequencer-1")
+
+	// The leader stops answering after the status update
+	c.Lock()
+	c.Down = map[string]bool{"sequencer-0": true}
+	c.Unlock()
+
+	m, err := Get(context.Background(), net)
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	if m.Source != "sequencer-1" || m.FromLeader {
+		t.Errorf("Get() read from %s (leader %v), want the follower sequencer-1", m.Source, m.FromLeader)
+	}
+
+	c.Lock()
+	c.Down["sequencer-1"] = true
+	c.Unlock()
+
+	if _, err := Get(context.Background(), net); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Get() = %v, want ErrUnavailable", err)
+	}
+}
+
+func TestMembership_CheckRemoval(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Servers: []consensus.ServerInfo{
+			{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-1", Addr: "sequencer-1:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-2", Addr: "sequencer-2:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-3", Addr: "sequencer-3:50050", Suffrage: consensus.Nonvoter},
+		},
+		Down: map[string]bool{"sequencer-2": true},
+	}
+	net := newTestNetwork(t, c, "sequencer-0", "sequencer-1", "sequencer-2", "sequencer-3")
+
+	m, err := Read(context.Background(), net, net.SequencerByID("sequencer-0"))
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+
+	tests := []struct {
+		id      string
+		allowed bool
+	}{
+		{"sequencer-0", false}, // Leader
+		{"sequencer-1", false}, // Leaves the leader as the only reachable voter of two
+		{"sequencer-2", true},  // Unreachable voter, the two others keep quorum
+		{"sequencer-3", true},  // Non-voter
+		{"sequencer-9", false}, // Not a member
+	}
+	for _, tt := range tests {
+		if err := m.CheckRemoval(tt.id); (err == nil) != tt.allowed {
+			t.Errorf("CheckRemoval(%s) = %v, want allowed %v", tt.id, err, tt.allowed)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// collectTimeout bounds how long a scrape waits for the network list
+const collectTimeout = 5 * time.Second
+
+// NetworkSource lists the networks whose state is exported
+type NetworkSource interface {
+	ListNetworks(ctx context.Context) (map[string]*network.Network, error)
+}
+
+var (
+	sequencerLabels = []string{"network", "sequencer"}
+
+	conductorLeaderDesc = prometheus.NewDesc(
+		namespace+"_sequencer_conductor_leader",
+		"Whether the sequencer's conductor is the Raft leader (1) or not (0).",
+		sequencerLabels, nil)
+	conductorActiveDesc = prometheus.NewDesc(
+		namespace+"_sequencer_conductor_active",
+		"Whether the sequencer's conductor is active (1) or not (0).",
+		sequencerLabels, nil)
+	conductorPausedDesc = prometheus.NewDesc(
+		namespace+"_sequencer_conductor_paused",
+		"Whether the sequencer's conductor is paused (1) or not (0).",
+		sequencerLabels, nil)
+	conductorStoppedDesc = prometheus.NewDesc(
+		namespace+"_sequencer_conductor_stopped",
+		"Whether the sequencer's conductor is stopped (1) or not (0).",
+		sequencerLabels, nil)
+	sequencerHealthyDesc = prometheus.NewDesc(
+		namespace+"_sequencer_healthy",
+		"Whether the conductor reports the sequencer as healthy (1) or not (0).",
+		sequencerLabels, nil)
+	sequencerActiveDesc = prometheus.NewDesc(
+		namespace+"_sequencer_active",
+		"Whether the sequencer is actively producing blocks (1) or not (0).",
+		sequencerLabels, nil)
+	sequencerVotingDesc = prometheus.NewDesc(
+		namespace+"_sequencer_voting",
+		"Whether the sequencer is a voting Raft member (1) or not (0).",
+		sequencerLabels, nil)
+	unsafeL2Desc = prometheus.NewDesc(
+		namespace+"_sequencer_unsafe_l2_block",
+		"Number of the sequencer's unsafe L2 head.",
+		sequencerLabels, nil)
+	sequencerUpdateAgeDesc = prometheus.NewDesc(
+		namespace+"_sequencer_last_update_age_seconds",
+		"Seconds since the sequencer's status was last fetched successfully.",
+		sequencerLabels, nil)
+
+	networkHealthyDesc = prometheus.NewDesc(
+		namespace+"_network_healthy",
+		"Whether every sequencer in the network is healthy, the active sequencer advances its unsafe head, no follower lags behind it and no invariant is critically violated (1) or not (0).",
+		[]string{"network"}, nil)
+	networkViolationsDesc = prometheus.NewDesc(
+		namespace+"_network_invariant_violations",
+		"Number of currently violated cluster invariants, by kind.",
+		[]string{"network", "kind"}, nil)
+	networkUpdateAgeDesc = prometheus.NewDesc(
+		namespace+"_network_last_update_age_seconds",
+		"Seconds since the network's status was last refreshed.",
+		[]string{"network"}, nil)
+)
+
+// networkCollector exports the last known state of every network at scrape
+// time, so that sequencers which disappear from discovery stop being reported
+type networkCollector struct {
+	source NetworkSource
+	logger *slog.Logger
+}
+
+func newNetworkCollector(source NetworkSource) *networkCollector {
+	return &networkCollector{
+		source: source,
+		logger: slog.Default().With(slog.String("component", "metrics")),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *networkCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		conductorLeaderDesc,
+		conductorActiveDesc,
+		conductorPausedDesc,
+		conductorStoppedDesc,
+		sequencerHealthyDesc,
+		sequencerActiveDesc,
+		sequencerVotingDesc,
+		unsafeL2Desc,
+		sequencerUpdateAgeDesc,
+		networkHealthyDesc,
+		networkViolationsDesc,
+		networkUpdateAgeDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *networkCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	networks, err := c.source.ListNetworks(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to list networks for metrics", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for name, net := range networks {
+		c.collectNetwork(ch, name, net, now)
+	}
+}
+
+func (c *networkCollector) collectNetwork(ch chan<- prometheus.Metric, name string, net *network.Network, now time.Time) {
+	violations := net.Invariants()
+
+	healthy := net.IsHealthy()
+	counts := make(map[network.ViolationKind]int, len(violations))
+	for _, v := range violations {
+		counts[v.Kind]++
+		if v.Severity == network.SeverityCritical {
+			healthy = false
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(networkHealthyDesc, prometheus.GaugeValue, boolValue(healthy), name)
+
+	// Report every kind so that alerts can match on zero values
+	for _, kind := range network.ViolationKinds {
+		ch <- prometheus.MustNewConstMetric(networkViolationsDesc, prometheus.GaugeValue,
+			float64(counts[kind]), name, string(kind))
+	}
+
+	if updated := net.LastUpdateTime(); !updated.IsZero() {
+		ch <- prometheus.MustNewConstMetric(networkUpdateAgeDesc, prometheus.GaugeValue,
+			now.Sub(updated).Seconds(), name)
+	}
+
+	for _, seq := range net.Sequencers() {
+		status := seq.Status()
+		labels := []string{name, seq.ID()}
+
+		gauge := func(desc *prometheus.Desc, value float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+		}
+
+		gauge(sequencerVotingDesc, boolValue(seq.Voting()))
+
+		// Nothing else is known until the first update
+		if status.LastUpdateTime.IsZero() {
+			continue
+		}
+
+		gauge(conductorLeaderDesc, boolValue(status.ConductorLeader))
+		gauge(conductorActiveDesc, boolValue(status.ConductorActive))
+		gauge(conductorPausedDesc, boolValue(status.ConductorPaused))
+		gauge(conductorStoppedDesc, boolValue(status.ConductorStopped))
+		gauge(sequencerHealthyDesc, boolValue(status.SequencerHealthy))
+		gauge(sequencerActiveDesc, boolValue(status.SequencerActive))
+		if fetched := status.FetchedAt(); !fetched.IsZero() {
+			gauge(sequencerUpdateAgeDesc, now.Sub(fetched).Seconds())
+		}
+		if status.UnsafeL2 != nil {
+			gauge(unsafeL2Desc, float64(status.UnsafeL2.Number))
+		}
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// gauge returns the value of the metric with the given name and labels
+func gauge(t *testing.T, families []*dto.MetricFamily, name string, labels map[string]string) float64 {
+	t.Helper()
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, pair := range m.GetLabel() {
+				if want, ok := labels[pair.GetName()]; ok && want != pair.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+
+	t.Fatalf("Metric %s%v not found", name, labels)
+	return 0
+}
+
+func TestNetworkCollector(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader:     "seq-0",
+		Leading:    map[string]bool{"seq-1": true},
+		Sequencing: map[string]bool{"seq-1": true},
+		Heads:      map[string]uint64{"seq-0": 100, "seq-1": 99, "seq-2": 98},
+	}
+	net := network.NewNetwork("devnet", c.Sequencers(t, conductortest.Voters("seq-0", "seq-1", "seq-2")))
+	if err := net.Update(context.Background()); err != nil {
+		t.Fatalf("Failed to update network: %v", err)
+	}
+
+	m := New(conductortest.Networks[*network.Network]{"devnet": net})
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   float64
+	}{
+		{"leader", "seqctl_sequencer_conductor_leader", map[string]string{"sequencer": "seq-0"}, 1},
+		{"follower", "seqctl_sequencer_conductor_leader", map[string]string{"sequencer": "seq-2"}, 0},
+		{"unsafe head", "seqctl_sequencer_unsafe_l2_block", map[string]string{"sequencer": "seq-1"}, 99},
+		{"split brain", "seqctl_network_invariant_violations", map[string]string{"kind": "multiple_leaders"}, 1},
+		{"no violation", "seqctl_network_invariant_violations", map[string]string{"kind": "no_leader"}, 0},
+		{"unhealthy", "seqctl_network_healthy", map[string]string{"network": "devnet"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gauge(t, families, tt.metric, tt.labels); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	var rpcCalls uint64
+	for _, family := range families {
+		if family.GetName() == "seqctl_rpc_request_duration_seconds" {
+			for _, m := range family.GetMetric() {
+				rpcCalls += m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	if rpcCalls == 0 {
+		t.Error("Expected RPC calls to be recorded")
+	}
+}
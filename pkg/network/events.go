@@ -0,0 +1,223 @@
+package network
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// EventType identifies a kind of network event
+type EventType string
+
+// Events detected by Network.Update and on rediscovery
+const (
+	EventLeaderChanged      EventType = "leader_changed"
+	EventSequencerActivated EventType = "sequencer_activated"
+	EventSequencerHalted    EventType = "sequencer_halted"
+	EventConductorPaused    EventType = "conductor_paused"
+	EventConductorResumed   EventType = "conductor_resumed"
+	EventHealthDegraded     EventType = "health_degraded"
+	EventHealthRecovered    EventType = "health_recovered"
+	EventUnsafeHeadStalled  EventType = "unsafe_head_stalled"
+	EventMemberAdded        EventType = "member_added"
+	EventMemberRemoved      EventType = "member_removed"
+)
+
+// EventTypes lists every event type
+var EventTypes = []EventType{
+	EventLeaderChanged,
+	EventSequencerActivated,
+	EventSequencerHalted,
+	EventConductorPaused,
+	EventConductorResumed,
+	EventHealthDegraded,
+	EventHealthRecovered,
+	EventUnsafeHeadStalled,
+	EventMemberAdded,
+	EventMemberRemoved,
+}
+
+// Event is a notable change in a network, derived from successive statuses
+type Event struct {
+	Type      EventType
+	Time      time.Time
+	Network   string
+	Sequencer string // Sequencer the event is about, the new leader for EventLeaderChanged
+	From      string // Previous leader, only set for EventLeaderChanged
+	To        string // New leader, only set for EventLeaderChanged
+	Message   string
+}
+
+// EventHandler is called after an update with the events it detected
+type EventHandler func(net *Network, events []Event)
+
+// SetEventHandler registers the handler invoked when an update detects
+// events. Passing nil removes the handler.
+func (n *Network) SetEventHandler(handler EventHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.onEvents = handler
+}
+
+// detectEvents compares the statuses before and after an update. Sequencers
+// whose previous status is unknown, such as right after discovery, only
+// contribute to stall tracking.
+func (n *Network) detectEvents(previous, current []sequencer.Status, now time.Time) []Event {
+	var events []Event
+	event := func(typ EventType, seqID, format string, args ...any) {
+		events = append(events, Event{
+			Type:      typ,
+			Time:      now,
+			Network:   n.name,
+			Sequencer: seqID,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	if from, to, ok := leaderChange(n.sequencers, previous, current); ok {
+		seqID := to
+		message := fmt.Sprintf("Leadership moved from %s to %s", from, to)
+		switch {
+		case from == "":
+			message = fmt.Sprintf("%s became leader", to)
+		case to == "":
+			seqID = from
+			message = fmt.Sprintf("%s lost leadership, no leader is known", from)
+		}
+		events = append(events, Event{
+			Type:      EventLeaderChanged,
+			Time:      now,
+			Network:   n.name,
+			Sequencer: seqID,
+			From:      from,
+			To:        to,
+			Message:   message,
+		})
+	}
+
+	for i, seq := range n.sequencers {
+		prev, cur := previous[i], current[i]
+		id := seq.ID()
+
+		if prev.Reachability() != sequencer.ReachabilityUnknown {
+			switch {
+			case !prev.SequencerActive && cur.SequencerActive:
+				event(EventSequencerActivated, id, "%s started sequencing", id)
+			case prev.SequencerActive && !cur.SequencerActive:
+				event(EventSequencerHalted, id, "%s stopped sequencing", id)
+			}
+
+			switch {
+			case !prev.ConductorPaused && cur.ConductorPaused:
+				event(EventConductorPaused, id, "Conductor of %s was paused", id)
+			case prev.ConductorPaused && !cur.ConductorPaused:
+				event(EventConductorResumed, id, "Conductor of %s was resumed", id)
+			}
+
+			switch wasHealthy, isHealthy := healthy(prev), healthy(cur); {
+			case wasHealthy && !isHealthy:
+				event(EventHealthDegraded, id, "%s is %s", id, unhealthyReason(cur))
+			case !wasHealthy && isHealthy:
+				event(EventHealthRecovered, id, "%s is healthy again", id)
+			}
+		}
+
+		if e, ok := n.trackHead(seq, cur, now); ok {
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+// leaderChange returns the leader before and after an update. ok is false if
+// the leader did not change or the previous statuses are unknown.
+func leaderChange(sequencers []*sequencer.Sequencer, previous, current []sequencer.Status) (from, to string, ok bool) {
+	known := false
+	for i, seq := range sequencers {
+		if previous[i].Reachability() != sequencer.ReachabilityUnknown {
+			known = true
+			if previous[i].KnownLeader() && from == "" {
+				from = seq.ID()
+			}
+		}
+		if current[i].KnownLeader() && to == "" {
+			to = seq.ID()
+		}
+	}
+	return from, to, known && from != to
+}
+
+// healthy reports whether a sequencer is reachable and reports itself healthy
+func healthy(status sequencer.Status) bool {
+	return status.SequencerHealthy && status.Reachability() == sequencer.ReachabilityReachable
+}
+
+// unhealthyReason describes why a status is not healthy
+func unhealthyReason(status sequencer.Status) string {
+	failing := status.Failing()
+	if len(failing) == 0 {
+		return "reporting itself unhealthy"
+	}
+
+	checks := make([]string, len(failing))
+	for i, check := range failing {
+		checks[i] = string(check)
+	}
+	return fmt.Sprintf("%s, failing %s", status.Reachability(), strings.Join(checks, ", "))
+}
+
+// MemberEvents returns the sequencers added to and removed from a network
+// between two discoveries. Either network may be nil when the network
+// appeared or disappeared.
+func MemberEvents(previous, current *Network, now time.Time) []Event {
+	ids := func(net *Network) []string {
+		if net == nil {
+			return nil
+		}
+		ids := make([]string, 0, len(net.sequencers))
+		for _, seq := range net.sequencers {
+			ids = append(ids, seq.ID())
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	name := ""
+	switch {
+	case current != nil:
+		name = current.name
+	case previous != nil:
+		name = previous.name
+	}
+
+	before, after := ids(previous), ids(current)
+
+	var events []Event
+	for _, id := range after {
+		if !slices.Contains(before, id) {
+			events = append(events, Event{
+				Type:      EventMemberAdded,
+				Time:      now,
+				Network:   name,
+				Sequencer: id,
+				Message:   fmt.Sprintf("%s joined %s", id, name),
+			})
+		}
+	}
+	for _, id := range before {
+		if !slices.Contains(after, id) {
+			events = append(events, Event{
+				Type:      EventMemberRemoved,
+				Time:      now,
+				Network:   name,
+				Sequencer: id,
+				Message:   fmt.Sprintf("%s left %s", id, name),
+			})
+		}
+	}
+	return events
+}
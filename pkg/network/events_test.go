@@ -0,0 +1,183 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// knownStatus returns a status whose checks all succeeded at the given time
+func knownStatus(at time.Time, modify func(*sequencer.Status)) sequencer.Status {
+	checks := make(map[sequencer.Check]sequencer.CheckResult)
+	for _, check := range sequencer.Checks {
+		checks[check] = sequencer.CheckResult{CheckedAt: at, SucceededAt: at}
+	}
+	status := sequencer.Status{
+		SequencerHealthy: true,
+		UnsafeL2:         &eth.L2BlockRef{Number: 100},
+		Checks:           checks,
+		LastUpdateTime:   at,
+	}
+	if modify != nil {
+		modify(&status)
+	}
+	return status
+}
+
+func eventNetwork(t *testing.T, ids ...string) *Network {
+	t.Helper()
+
+	var sequencers []*sequencer.Sequencer
+	for _, id := range ids {
+		seq, err := sequencer.New(context.Background(), sequencer.Config{
+			ID:           id,
+			ConductorURL: "http://127.0.0.1:1",
+			NodeURL:      "http://127.0.0.1:1",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create sequencer: %v", err)
+		}
+		sequencers = append(sequencers, seq)
+	}
+	return NewNetwork("devnet", sequencers)
+}
+
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func TestNetwork_DetectEvents(t *testing.T) {
+	net := eventNetwork(t, "sequencer-0", "sequencer-1")
+	now := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	leader := func(s *sequencer.Status) {
+		s.ConductorLeader = true
+		s.SequencerActive = true
+	}
+
+	// Nothing is reported against statuses that were never fetched
+	initial := []sequencer.Status{knownStatus(now, leader), knownStatus(now, nil)}
+	if events := net.detectEvents(make([]sequencer.Status, 2), initial, now); len(events) != 0 {
+		t.Fatalf("detectEvents() = %v, want no events after discovery", eventTypes(events))
+	}
+
+	// Leadership and sequencing move to sequencer-1 while sequencer-0 turns
+	// unhealthy and is paused
+	now = now.Add(time.Second)
+	current := []sequencer.Status{
+		knownStatus(now, func(s *sequencer.Status) {
+			s.SequencerHealthy = false
+			s.ConductorPaused = true
+			s.UnsafeL2 = &eth.L2BlockRef{Number: 101}
+		}),
+		knownStatus(now, func(s *sequencer.Status) {
+			leader(s)
+			s.UnsafeL2 = &eth.L2BlockRef{Number: 101}
+		}),
+	}
+	events := net.detectEvents(initial, current, now)
+
+	want := []EventType{
+		EventLeaderChanged,
+		EventSequencerHalted,
+		EventConductorPaused,
+		EventHealthDegraded,
+		EventSequencerActivated,
+	}
+	if got := eventTypes(events); len(got) != len(want) {
+		t.Fatalf("detectEvents() = %v, want %v", got, want)
+	}
+	for i, typ := range want {
+		if events[i].Type != typ {
+			t.Errorf("events[%d] = %s, want %s", i, events[i].Type, typ)
+		}
+	}
+	if e := events[0]; e.From != "sequencer-0" || e.To != "sequencer-1" || e.Sequencer != "sequencer-1" {
+		t.Errorf("leader_changed = %+v, want sequencer-0 -> sequencer-1", e)
+	}
+}
+
+func TestNetwork_DetectEvents_UnreachableIsDegraded(t *testing.T) {
+	net := eventNetwork(t, "sequencer-0")
+	now := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	previous := []sequencer.Status{knownStatus(now, nil)}
+
+	// Failed probes keep the last values, so the status still claims to be
+	// healthy
+	current := []sequencer.Status{knownStatus(now, func(s *sequencer.Status) {
+		for _, check := range sequencer.Checks {
+			s.Checks[check] = sequencer.CheckResult{Err: context.DeadlineExceeded, CheckedAt: now.Add(time.Second), SucceededAt: now}
+		}
+	})}
+
+	events := net.detectEvents(previous, current, now.Add(time.Second))
+	if len(events) != 1 || events[0].Type != EventHealthDegraded {
+		t.Fatalf("detectEvents() = %v, want health_degraded", eventTypes(events))
+	}
+
+	events = net.detectEvents(current, previous, now.Add(2*time.Second))
+	if len(events) != 1 || events[0].Type != EventHealthRecovered {
+		t.Fatalf("detectEvents() = %v, want health_recovered", eventTypes(events))
+	}
+}
+
+func TestNetwork_DetectEvents_UnsafeHeadStalled(t *testing.T) {
+	net := eventNetwork(t, "sequencer-0")
+	start := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	stalled := 0
+	for i := range 10 {
+		now := start.Add(time.Duration(i) * 10 * time.Second)
+		status := []sequencer.Status{knownStatus(now, nil)}
+		for _, e := range net.detectEvents(status, status, now) {
+			if e.Type == EventUnsafeHeadStalled {
+				stalled++
+			}
+		}
+	}
+	if stalled != 1 {
+		t.Errorf("unsafe_head_stalled raised %d times, want once per stall", stalled)
+	}
+
+	// The head moving again ends the stall
+	now := start.Add(time.Hour)
+	moved := []sequencer.Status{knownStatus(now, func(s *sequencer.Status) {
+		s.UnsafeL2 = &eth.L2BlockRef{Number: 200}
+	})}
+	if events := net.detectEvents(moved, moved, now); len(events) != 0 {
+		t.Errorf("detectEvents() = %v, want no events once the head moves", eventTypes(events))
+	}
+	if events := net.detectEvents(moved, moved, now.Add(DefaultStallThreshold)); len(events) != 1 {
+		t.Errorf("detectEvents() = %v, want a new stall", eventTypes(events))
+	}
+}
+
+func TestMemberEvents(t *testing.T) {
+	now := time.Now()
+	before := eventNetwork(t, "sequencer-0", "sequencer-1")
+	after := eventNetwork(t, "sequencer-1", "sequencer-2")
+
+	events := MemberEvents(before, after, now)
+	if len(events) != 2 {
+		t.Fatalf("MemberEvents() = %+v, want two events", events)
+	}
+	if e := events[0]; e.Type != EventMemberAdded || e.Sequencer != "sequencer-2" {
+		t.Errorf("events[0] = %+v, want sequencer-2 added", e)
+	}
+	if e := events[1]; e.Type != EventMemberRemoved || e.Sequencer != "sequencer-0" {
+		t.Errorf("events[1] = %+v, want sequencer-0 removed", e)
+	}
+
+	if events := MemberEvents(before, nil, now); len(events) != 2 || events[0].Network != "devnet" {
+		t.Errorf("MemberEvents() = %+v, want both members removed from devnet", events)
+	}
+}
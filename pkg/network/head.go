@@ -0,0 +1,251 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Unsafe head progress defaults
+const (
+	// DefaultStallBlocks is how many block times the unsafe head of a
+	// sequencer may stay at the same block before it counts as stalled
+	DefaultStallBlocks = 10
+	// DefaultMaxFollowerLag is how many blocks a follower may trail the
+	// active sequencer before it counts as lagging
+	DefaultMaxFollowerLag = 20
+	// DefaultStallThreshold is the stall threshold used while the block time
+	// of a sequencer is unknown
+	DefaultStallThreshold = 30 * time.Second
+)
+
+const (
+	// rateWindow is how long head observations are kept to compute the
+	// block production rate
+	rateWindow = 2 * time.Minute
+	// maxHeadSamples bounds the observations kept per sequencer
+	maxHeadSamples = 64
+)
+
+// HeadConfig holds the thresholds of unsafe head tracking. Zero values select
+// the defaults.
+type HeadConfig struct {
+	StallBlocks    int    // Block times without a new block before a head counts as stalled
+	MaxFollowerLag uint64 // Blocks a follower may trail the active sequencer
+}
+
+// HeadStats describes the progress of a sequencer's unsafe head
+type HeadStats struct {
+	Number         uint64        // Last observed unsafe head
+	Since          time.Time     // When the head was first observed at Number
+	ObservedAt     time.Time     // When the head was last fetched
+	BlockTime      time.Duration // Derived from L2 block timestamps, zero while unknown
+	Rate           float64       // Blocks per second over the observation window
+	RateWindow     time.Duration // Span of the observations Rate is based on, zero while unknown
+	StallThreshold time.Duration // How long the head may stay put before it counts as stalled
+	Stalled        bool          // The head has not moved for StallThreshold
+	Reference      string        // Sequencer the lag is measured against, empty for itself
+	Lag            uint64        // Blocks behind the reference sequencer
+	Lagging        bool          // Lag exceeds the maximum follower lag
+	Fresh          bool          // The last update fetched the head, the rest is kept from earlier updates
+}
+
+// headSample is one observation of an unsafe head
+type headSample struct {
+	number uint64
+	l2Time uint64 // L2 block timestamp in seconds
+	at     time.Time
+}
+
+// headProgress tracks how a sequencer's unsafe head advances
+type headProgress struct {
+	number    uint64
+	since     time.Time
+	blockTime time.Duration
+	samples   []headSample // Observations within the rate window, oldest first
+	fresh     bool         // The last update fetched the head
+	stalled   bool         // An event was raised for the current stall
+}
+
+// SetHeadConfig sets the thresholds of unsafe head tracking
+func (n *Network) SetHeadConfig(cfg HeadConfig) {
+	if cfg.StallBlocks <= 0 {
+		cfg.StallBlocks = DefaultStallBlocks
+	}
+	if cfg.MaxFollowerLag == 0 {
+		cfg.MaxFollowerLag = DefaultMaxFollowerLag
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.head = cfg
+}
+
+// ContinueFrom carries the head progress of sequencers that are also part of
+// previous over, so that rediscovering a network does not restart stall and
+// rate tracking
+func (n *Network) ContinueFrom(previous *Network) {
+	if previous == nil || previous == n {
+		return
+	}
+
+	previous.mu.Lock()
+	heads := make(map[string]headProgress, len(previous.heads))
+	for id, head := range previous.heads {
+		heads[id] = *head
+	}
+	previous.mu.Unlock()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.heads == nil {
+		n.heads = make(map[string]*headProgress)
+	}
+	for _, seq := range n.sequencers {
+		if head, ok := heads[seq.ID()]; ok {
+			head.samples = append([]headSample(nil), head.samples...)
+			n.heads[seq.ID()] = &head
+		}
+	}
+}
+
+// trackHead records the unsafe head of a sequencer and reports a stall once
+// per stall. Only a fresh sync status counts, a failed probe keeps the
+// previous head and would otherwise look like a stall.
+func (n *Network) trackHead(seq *sequencer.Sequencer, status sequencer.Status, now time.Time) (Event, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	head, ok := n.heads[seq.ID()]
+	if status.UnsafeL2 == nil || !status.Checks[sequencer.CheckSyncStatus].OK() {
+		if ok {
+			head.fresh = false
+		}
+		return Event{}, false
+	}
+
+	if n.heads == nil {
+		n.heads = make(map[string]*headProgress)
+	}
+	if !ok {
+		head = &headProgress{}
+		n.heads[seq.ID()] = head
+	}
+	head.observe(status.UnsafeL2.Number, status.UnsafeL2.Time, now)
+
+	stalledFor := now.Sub(head.since)
+	threshold := head.stallThreshold(n.head.StallBlocks)
+	if head.stalled || stalledFor < threshold {
+		return Event{}, false
+	}
+	head.stalled = true
+
+	return Event{
+		Type:      EventUnsafeHeadStalled,
+		Time:      now,
+		Network:   n.name,
+		Sequencer: seq.ID(),
+		Message: fmt.Sprintf("Unsafe head of %s has been at block %d for %s",
+			seq.ID(), head.number, stalledFor.Round(time.Second)),
+	}, true
+}
+
+// HeadStats returns the progress of a sequencer's unsafe head. Followers are
+// compared against the active sequencer, or the conductor leader while no
+// sequencer is active. ok is false until the head was fetched once.
+func (n *Network) HeadStats(id string) (stats HeadStats, ok bool) {
+	reference := n.ActiveSequencer()
+	if reference == nil {
+		reference = n.ConductorLeader()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	head, ok := n.heads[id]
+	if !ok {
+		return HeadStats{}, false
+	}
+
+	stats = HeadStats{
+		Number:         head.number,
+		Since:          head.since,
+		BlockTime:      head.blockTime,
+		StallThreshold: head.stallThreshold(n.head.StallBlocks),
+		Fresh:          head.fresh,
+	}
+	if last := len(head.samples) - 1; last >= 0 {
+		stats.ObservedAt = head.samples[last].at
+	}
+	stats.Rate, stats.RateWindow = head.rate()
+
+	// A head that failed to update can neither be stalled nor lagging, it is
+	// merely unknown
+	if !head.fresh {
+		return stats, true
+	}
+	stats.Stalled = stats.ObservedAt.Sub(head.since) >= stats.StallThreshold
+
+	if reference == nil || reference.ID() == id {
+		return stats, true
+	}
+	stats.Reference = reference.ID()
+	if ref, ok := n.heads[reference.ID()]; ok && ref.fresh && ref.number > head.number {
+		stats.Lag = ref.number - head.number
+		stats.Lagging = stats.Lag > n.head.MaxFollowerLag
+	}
+	return stats, true
+}
+
+// observe records a fetched unsafe head
+func (h *headProgress) observe(number, l2Time uint64, now time.Time) {
+	h.fresh = true
+
+	// A head moving backwards, such as after a reorg or a resync, starts
+	// over
+	if number < h.number {
+		h.samples = nil
+	}
+	if len(h.samples) == 0 || number != h.number {
+		h.number = number
+		h.since = now
+		h.stalled = false
+	}
+
+	h.samples = append(h.samples, headSample{number: number, l2Time: l2Time, at: now})
+	for len(h.samples) > 2 && (now.Sub(h.samples[0].at) > rateWindow || len(h.samples) > maxHeadSamples) {
+		h.samples = h.samples[1:]
+	}
+
+	// The block time is kept when the head stops moving, so that the stall
+	// threshold does not fall back to the default during a stall
+	first := h.samples[0]
+	if number > first.number && l2Time > first.l2Time && first.l2Time > 0 {
+		h.blockTime = time.Duration(l2Time-first.l2Time) * time.Second / time.Duration(number-first.number)
+	}
+}
+
+// rate returns the blocks per second over the observations kept, and the
+// span of those observations. The span is zero while unknown.
+func (h *headProgress) rate() (float64, time.Duration) {
+	if len(h.samples) < 2 {
+		return 0, 0
+	}
+	first, last := h.samples[0], h.samples[len(h.samples)-1]
+	span := last.at.Sub(first.at)
+	if span <= 0 {
+		return 0, 0
+	}
+	return float64(last.number-first.number) / span.Seconds(), span
+}
+
+// stallThreshold returns how long the head may stay put before it counts as
+// stalled
+func (h *headProgress) stallThreshold(stallBlocks int) time.Duration {
+	if h.blockTime <= 0 || stallBlocks <= 0 {
+		return DefaultStallThreshold
+	}
+	return h.blockTime * time.Duration(stallBlocks)
+}
@@ -0,0 +1,129 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+func TestNetwork_HeadStats(t *testing.T) {
+	net := eventNetwork(t, "sequencer-0")
+	net.SetHeadConfig(HeadConfig{StallBlocks: 5})
+	start := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	observe := func(at time.Time, number, l2Time uint64) []Event {
+		status := []sequencer.Status{knownStatus(at, func(s *sequencer.Status) {
+			s.UnsafeL2 = &eth.L2BlockRef{Number: number, Time: l2Time}
+		})}
+		return net.detectEvents(status, status, at)
+	}
+
+	if _, ok := net.HeadStats("sequencer-0"); ok {
+		t.Fatal("HeadStats() ok before the head was fetched")
+	}
+
+	// Two second blocks, observed every ten seconds
+	for i := range uint64(4) {
+		observe(start.Add(time.Duration(i)*10*time.Second), 100+5*i, 1000+10*i)
+	}
+
+	stats, ok := net.HeadStats("sequencer-0")
+	if !ok {
+		t.Fatal("HeadStats() not ok after updates")
+	}
+	if stats.Number != 115 || stats.BlockTime != 2*time.Second {
+		t.Errorf("HeadStats() = block %d every %s, want block 115 every 2s", stats.Number, stats.BlockTime)
+	}
+	if stats.Rate != 0.5 || stats.RateWindow != 30*time.Second {
+		t.Errorf("HeadStats() rate = %v over %s, want 0.5 over 30s", stats.Rate, stats.RateWindow)
+	}
+	if stats.StallThreshold != 10*time.Second || stats.Stalled {
+		t.Errorf("HeadStats() threshold = %s, stalled = %v, want 10s and not stalled", stats.StallThreshold, stats.Stalled)
+	}
+
+	// Five block times without a new block are a stall, reported once
+	at := start.Add(30 * time.Second)
+	var stalls int
+	for _, offset := range []time.Duration{5, 10, 15} {
+		for _, e := range observe(at.Add(offset*time.Second), 115, 1030) {
+			if e.Type == EventUnsafeHeadStalled {
+				stalls++
+			}
+		}
+	}
+	if stalls != 1 {
+		t.Errorf("unsafe_head_stalled raised %d times, want once", stalls)
+	}
+	if stats, _ := net.HeadStats("sequencer-0"); !stats.Stalled || stats.BlockTime != 2*time.Second {
+		t.Errorf("HeadStats() = %+v, want stalled with the block time kept", stats)
+	}
+
+	// A failed sync status leaves the head unknown rather than stalled
+	failed := []sequencer.Status{knownStatus(at, func(s *sequencer.Status) {
+		s.Checks[sequencer.CheckSyncStatus] = sequencer.CheckResult{Err: context.DeadlineExceeded, CheckedAt: at}
+	})}
+	net.detectEvents(failed, failed, at.Add(20*time.Second))
+	if stats, _ := net.HeadStats("sequencer-0"); stats.Stalled || stats.Fresh {
+		t.Errorf("HeadStats() = %+v, want a stale head that is not stalled", stats)
+	}
+}
+
+func TestNetwork_HeadStats_FollowerLag(t *testing.T) {
+	net := clusterNetwork(t, &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Heads:  map[string]uint64{"sequencer-0": 100, "sequencer-1": 90, "sequencer-2": 50},
+		Hashes: map[string]common.Hash{"sequencer-0": hashA, "sequencer-1": hashB, "sequencer-2": hashB},
+	})
+	if err := net.Update(context.Background()); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	tests := []struct {
+		id      string
+		lag     uint64
+		lagging bool
+	}{
+		{"sequencer-0", 0, false},
+		{"sequencer-1", 10, false},
+		{"sequencer-2", 50, true},
+	}
+	for _, tt := range tests {
+		stats, ok := net.HeadStats(tt.id)
+		if !ok {
+			t.Fatalf("HeadStats(%s) not ok", tt.id)
+		}
+		if stats.Lag != tt.lag || stats.Lagging != tt.lagging {
+			t.Errorf("HeadStats(%s) = lag %d, lagging %v, want %d, %v", tt.id, stats.Lag, stats.Lagging, tt.lag, tt.lagging)
+		}
+	}
+
+	if net.IsHealthy() {
+		t.Error("IsHealthy() = true with a lagging follower")
+	}
+}
+
+func TestNetwork_ContinueFrom(t *testing.T) {
+	previous := eventNetwork(t, "sequencer-0", "sequencer-1")
+	now := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	statuses := []sequencer.Status{knownStatus(now, nil), knownStatus(now, nil)}
+	previous.detectEvents(statuses, statuses, now)
+
+	// Rediscovery creates a new network, the head of the remaining member
+	// keeps its history
+	current := eventNetwork(t, "sequencer-0")
+	current.ContinueFrom(previous)
+
+	stats, ok := current.HeadStats("sequencer-0")
+	if !ok || !stats.Since.Equal(now) {
+		t.Errorf("HeadStats() = %+v, %v, want the head seen since %s", stats, ok, now)
+	}
+	if _, ok := current.HeadStats("sequencer-1"); ok {
+		t.Error("HeadStats() of a removed member is ok")
+	}
+}
@@ -0,0 +1,172 @@
+package network
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// ViolationKind identifies a broken cluster invariant
+type ViolationKind string
+
+// Invariants checked by Network.Invariants
+const (
+	ViolationMultipleLeaders    ViolationKind = "multiple_leaders"
+	ViolationMultipleActive     ViolationKind = "multiple_active_sequencers"
+	ViolationActiveNotLeader    ViolationKind = "active_not_leader"
+	ViolationNoLeader           ViolationKind = "no_leader"
+	ViolationUnsafeL2Divergence ViolationKind = "unsafe_l2_divergence"
+)
+
+// ViolationKinds lists every invariant checked by Network.Invariants
+var ViolationKinds = []ViolationKind{
+	ViolationMultipleLeaders,
+	ViolationMultipleActive,
+	ViolationActiveNotLeader,
+	ViolationNoLeader,
+	ViolationUnsafeL2Divergence,
+}
+
+// Severity of an invariant violation
+type Severity string
+
+// Violation severities
+const (
+	// SeverityCritical means the chain is at risk, e.g. split-brain
+	SeverityCritical Severity = "critical"
+	// SeverityWarning means the cluster is in an unusual but possibly
+	// transient state, e.g. during a leadership transfer
+	SeverityWarning Severity = "warning"
+)
+
+// Violation describes a broken cluster invariant
+type Violation struct {
+	Kind       ViolationKind
+	Severity   Severity
+	Message    string
+	Sequencers []string // IDs of the sequencers involved
+}
+
+// Invariants checks the network's last known state for conditions that must
+// never hold in a healthy conductor cluster, such as two leaders or two active
+// sequencers. Sequencers whose status has never been fetched are ignored, as
+// are values whose probe failed in the last update: an unreachable former
+// leader still shows leadership but must not count as a second leader.
+func (n *Network) Invariants() []Violation {
+	var (
+		known         int
+		leaders       []*sequencer.Sequencer
+		active        []*sequencer.Sequencer
+		unknownLeader []*sequencer.Sequencer                 // Active, but whether they lead is unknown
+		heads         = make(map[uint64]map[string][]string) // number -> hash -> IDs
+	)
+
+	for _, seq := range n.sequencers {
+		status := seq.Status()
+		if status.LastUpdateTime.IsZero() {
+			continue
+		}
+		known++
+
+		if status.KnownLeader() {
+			leaders = append(leaders, seq)
+		}
+		if status.KnownActive() {
+			active = append(active, seq)
+			if status.Checks[sequencer.CheckConductorLeader].Err != nil {
+				unknownLeader = append(unknownLeader, seq)
+			}
+		}
+		if status.UnsafeL2 != nil && status.Checks[sequencer.CheckSyncStatus].Err == nil {
+			hashes := heads[status.UnsafeL2.Number]
+			if hashes == nil {
+				hashes = make(map[string][]string)
+				heads[status.UnsafeL2.Number] = hashes
+			}
+			hash := status.UnsafeL2.Hash.Hex()
+			hashes[hash] = append(hashes[hash], seq.ID())
+		}
+	}
+
+	if known == 0 {
+		return nil
+	}
+
+	var violations []Violation
+
+	switch {
+	case len(leaders) == 0:
+		violations = append(violations, Violation{
+			Kind:     ViolationNoLeader,
+			Severity: SeverityCritical,
+			Message:  "No sequencer reports being conductor leader",
+		})
+	case len(leaders) > 1:
+		violations = append(violations, Violation{
+			Kind:       ViolationMultipleLeaders,
+			Severity:   SeverityCritical,
+			Message:    fmt.Sprintf("%d sequencers report being conductor leader", len(leaders)),
+			Sequencers: sequencerIDs(leaders),
+		})
+	}
+
+	if len(active) > 1 {
+		violations = append(violations, Violation{
+			Kind:       ViolationMultipleActive,
+			Severity:   SeverityCritical,
+			Message:    fmt.Sprintf("%d sequencers are actively sequencing", len(active)),
+			Sequencers: sequencerIDs(active),
+		})
+	}
+
+	for _, seq := range active {
+		if !slices.Contains(leaders, seq) && !slices.Contains(unknownLeader, seq) {
+			violations = append(violations, Violation{
+				Kind:       ViolationActiveNotLeader,
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("Sequencer %s is active but not conductor leader", seq.ID()),
+				Sequencers: []string{seq.ID()},
+			})
+		}
+	}
+
+	// Members at the same height must agree on the block
+	numbers := make([]uint64, 0, len(heads))
+	for number, hashes := range heads {
+		if len(hashes) > 1 {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	for _, number := range numbers {
+		var groups []string
+		var ids []string
+		for hash, members := range heads[number] {
+			groups = append(groups, fmt.Sprintf("%s (%s)", hash, strings.Join(members, ", ")))
+			ids = append(ids, members...)
+		}
+		sort.Strings(groups)
+		sort.Strings(ids)
+
+		violations = append(violations, Violation{
+			Kind:       ViolationUnsafeL2Divergence,
+			Severity:   SeverityCritical,
+			Message:    fmt.Sprintf("Unsafe L2 block %d differs between members: %s", number, strings.Join(groups, "; ")),
+			Sequencers: ids,
+		})
+	}
+
+	return violations
+}
+
+func sequencerIDs(sequencers []*sequencer.Sequencer) []string {
+	ids := make([]string, 0, len(sequencers))
+	for _, seq := range sequencers {
+		ids = append(ids, seq.ID())
+	}
+	return ids
+}
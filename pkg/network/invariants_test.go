@@ -0,0 +1,145 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+)
+
+var (
+	hashA = common.HexToHash("0xaa")
+	hashB = common.HexToHash("0xbb")
+)
+
+// clusterNetwork returns a network of the members of c that report a head
+func clusterNetwork(t *testing.T, c *conductortest.Cluster) *Network {
+	t.Helper()
+
+	voting := make(map[string]bool, len(c.Heads))
+	for id := range c.Heads {
+		voting[id] = true
+	}
+	return NewNetwork("devnet", c.Sequencers(t, voting))
+}
+
+func TestNetwork_Invariants(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *conductortest.Cluster
+		want    []ViolationKind
+	}{
+		{
+			name: "healthy",
+			cluster: &conductortest.Cluster{
+				Leader: "a",
+				Heads:  map[string]uint64{"a": 100, "b": 100, "c": 99},
+				Hashes: map[string]common.Hash{"a": hashA, "b": hashA, "c": hashB},
+			},
+		},
+		{
+			name: "split brain",
+			cluster: &conductortest.Cluster{
+				Leader:     "a",
+				Leading:    map[string]bool{"b": true},
+				Sequencing: map[string]bool{"b": true},
+				Heads:      map[string]uint64{"a": 100, "b": 101},
+				Hashes:     map[string]common.Hash{"a": hashA, "b": hashB},
+			},
+			want: []ViolationKind{ViolationMultipleLeaders, ViolationMultipleActive},
+		},
+		{
+			name: "no leader and active follower",
+			cluster: &conductortest.Cluster{
+				Sequencing: map[string]bool{"a": true},
+				Heads:      map[string]uint64{"a": 100, "b": 100},
+				Hashes:     map[string]common.Hash{"a": hashA, "b": hashA},
+			},
+			want: []ViolationKind{ViolationNoLeader, ViolationActiveNotLeader},
+		},
+		{
+			name: "unsafe head divergence",
+			cluster: &conductortest.Cluster{
+				Leader: "a",
+				Heads:  map[string]uint64{"a": 100, "b": 100},
+				Hashes: map[string]common.Hash{"a": hashA, "b": hashB},
+			},
+			want: []ViolationKind{ViolationUnsafeL2Divergence},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net := clusterNetwork(t, tt.cluster)
+			if err := net.Update(context.Background()); err != nil {
+				t.Fatalf("Failed to update network: %v", err)
+			}
+
+			violations := net.Invariants()
+			if len(violations) != len(tt.want) {
+				t.Fatalf("Expected %d violations, got %d: %+v", len(tt.want), len(violations), violations)
+			}
+			for i, v := range violations {
+				if v.Kind != tt.want[i] {
+					t.Errorf("Violation %d: expected %s, got %s", i, tt.want[i], v.Kind)
+				}
+			}
+		})
+	}
+}
+
+func TestNetwork_InvariantsIgnoreUnknownStatus(t *testing.T) {
+	net := clusterNetwork(t, &conductortest.Cluster{Heads: map[string]uint64{"a": 0}})
+
+	// Nothing is known before the first update, so nothing can be violated
+	if violations := net.Invariants(); len(violations) != 0 {
+		t.Errorf("Expected no violations before first update, got %+v", violations)
+	}
+}
+
+func TestNetwork_InvariantsAfterFailover(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "a",
+		Heads:  map[string]uint64{"a": 100, "b": 100},
+		Hashes: map[string]common.Hash{"a": hashA, "b": hashA},
+	}
+	net := clusterNetwork(t, c)
+	if err := net.Update(context.Background()); err != nil {
+		t.Fatalf("Failed to update network: %v", err)
+	}
+
+	// The leader goes down and b takes over. a keeps showing its last known
+	// leadership, which must not count against b.
+	c.Lock()
+	c.Down = map[string]bool{"a": true}
+	c.Leader = "b"
+	c.Heads["b"] = 101
+	c.Unlock()
+	_ = net.Update(context.Background())
+
+	if status := net.SequencerByID("a").Status(); !status.ConductorLeader || !status.SequencerActive {
+		t.Fatal("Expected a to keep its last known leadership while down")
+	}
+	if violations := net.Invariants(); len(violations) != 0 {
+		t.Errorf("Expected no violations after failover, got %+v", violations)
+	}
+	if leader := net.ConductorLeader(); leader == nil || leader.ID() != "b" {
+		t.Errorf("Expected b to be conductor leader, got %v", leader)
+	}
+	if active := net.ActiveSequencer(); active == nil || active.ID() != "b" {
+		t.Errorf("Expected b to be the active sequencer, got %v", active)
+	}
+
+	// Once b is down too, nothing is known to lead
+	c.Lock()
+	c.Down["b"] = true
+	c.Unlock()
+	_ = net.Update(context.Background())
+
+	violations := net.Invariants()
+	if len(violations) != 1 || violations[0].Kind != ViolationNoLeader {
+		t.Errorf("Expected only no_leader with every member down, got %+v", violations)
+	}
+}
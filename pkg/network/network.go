@@ -9,14 +9,31 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// StatusChange describes a sequencer whose status changed during an update
+type StatusChange struct {
+	Sequencer *sequencer.Sequencer
+	Previous  sequencer.Status
+	Current   sequencer.Status
+}
+
+// ChangeHandler is called after an update with the sequencers whose status changed
+type ChangeHandler func(net *Network, changes []StatusChange)
+
 // Network represents a network of sequencers
 type Network struct {
 	name       string
 	sequencers []*sequencer.Sequencer
 
+	// updateMu serializes updates so that every change is reported once
+	updateMu sync.Mutex
+
 	mu             sync.Mutex
 	lastUpdateTime time.Time
 	updateError    error
+	onChange       ChangeHandler
+	onEvents       EventHandler
+	head           HeadConfig
+	heads          map[string]*headProgress // Unsafe head progress, keyed by sequencer ID
 }
 
 // NewNetwork creates a new network
@@ -24,6 +41,10 @@ func NewNetwork(name string, sequencers []*sequencer.Sequencer) *Network {
 	return &Network{
 		name:       name,
 		sequencers: sequencers,
+		head: HeadConfig{
+			StallBlocks:    DefaultStallBlocks,
+			MaxFollowerLag: DefaultMaxFollowerLag,
+		},
 	}
 }
 
@@ -37,9 +58,27 @@ func (n *Network) Sequencers() []*sequencer.Sequencer {
 	return n.sequencers
 }
 
+// SetChangeHandler registers the handler invoked when an update changes the
+// status of one or more sequencers. Passing nil removes the handler.
+func (n *Network) SetChangeHandler(handler ChangeHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.onChange = handler
+}
+
 // Update updates all sequencers in the network concurrently
 func (n *Network) Update(ctx context.Context) error {
-	errg, ctx := errgroup.WithContext(ctx)
+	n.updateMu.Lock()
+	defer n.updateMu.Unlock()
+
+	// Snapshot current statuses so changes can be reported after the update
+	previous := make([]sequencer.Status, len(n.sequencers))
+	for i, seq := range n.sequencers {
+		previous[i] = seq.Status()
+	}
+
+	// A failing sequencer must not cancel the probes of the others
+	var errg errgroup.Group
 
 	for _, seq := range n.sequencers {
 		seq := seq
@@ -53,10 +92,40 @@ func (n *Network) Update(ctx context.Context) error {
 	err := errg.Wait()
 
 	// Now, acquire the lock only to update the shared fields.
+	now := time.Now()
 	n.mu.Lock()
-	defer n.mu.Unlock()
-	n.lastUpdateTime = time.Now()
+	n.lastUpdateTime = now
 	n.updateError = err
+	onChange := n.onChange
+	onEvents := n.onEvents
+	n.mu.Unlock()
+
+	current := make([]sequencer.Status, len(n.sequencers))
+	for i, seq := range n.sequencers {
+		current[i] = seq.Status()
+	}
+
+	if onChange != nil {
+		var changes []StatusChange
+		for i, seq := range n.sequencers {
+			if !current[i].Equal(previous[i]) {
+				changes = append(changes, StatusChange{
+					Sequencer: seq,
+					Previous:  previous[i],
+					Current:   current[i],
+				})
+			}
+		}
+		if len(changes) > 0 {
+			onChange(n, changes)
+		}
+	}
+
+	// Stalls are detected even when no status changed, so events are
+	// computed on every update
+	if events := n.detectEvents(previous, current, now); len(events) > 0 && onEvents != nil {
+		onEvents(n, events)
+	}
 
 	return err
 }
@@ -71,32 +140,38 @@ func (n *Network) SequencerByID(id string) *sequencer.Sequencer {
 	return nil
 }
 
-// ConductorLeader returns the sequencer that is the conductor leader or nil if none
+// ConductorLeader returns the sequencer that is the conductor leader or nil if
+// none. A sequencer whose leader probe failed is not considered leader.
 func (n *Network) ConductorLeader() *sequencer.Sequencer {
 	for _, seq := range n.sequencers {
-		if seq.ConductorLeader() {
+		if seq.Status().KnownLeader() {
 			return seq
 		}
 	}
 	return nil
 }
 
-// ActiveSequencer returns the sequencer that is active or nil if none
+// ActiveSequencer returns the sequencer that is active or nil if none. A
+// sequencer whose sequencer active probe failed is not considered active.
 func (n *Network) ActiveSequencer() *sequencer.Sequencer {
 	for _, seq := range n.sequencers {
-		if seq.SequencerActive() {
+		if seq.Status().KnownActive() {
 			return seq
 		}
 	}
 	return nil
 }
 
-// IsHealthy returns true if all sequencers are healthy
+// IsHealthy returns true if all sequencers are healthy, the active
+// sequencer's unsafe head advances and no follower lags behind it
 func (n *Network) IsHealthy() bool {
 	for _, seq := range n.sequencers {
 		if !seq.SequencerHealthy() {
 			return false
 		}
+		if stats, ok := n.HeadStats(seq.ID()); ok && (stats.Lagging || (stats.Stalled && seq.SequencerActive())) {
+			return false
+		}
 	}
 	return true
 }
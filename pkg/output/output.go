@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format is a CLI output format
+type Format string
+
+// Supported output formats
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// Formats lists every supported output format
+var Formats = []Format{FormatTable, FormatJSON, FormatYAML}
+
+// Tabular is implemented by values that can be rendered as a table
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// ParseFormat parses an output format name
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatTable, FormatJSON, FormatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (expected table, json or yaml)", name)
+	}
+}
+
+// Write renders v to w in the given format. Values rendered as a table must
+// implement Tabular.
+func Write(w io.Writer, format Format, v any) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case FormatTable:
+		table, ok := v.(Tabular)
+		if !ok {
+			return fmt.Errorf("%T cannot be rendered as a table", v)
+		}
+		return writeTable(w, table)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// writeTable writes a table with aligned columns
+func writeTable(w io.Writer, table Tabular) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(table.Header(), "\t"))
+	for _, row := range table.Rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testTable struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (t testTable) Header() []string {
+	return []string{"NAME", "COUNT"}
+}
+
+func (t testTable) Rows() [][]string {
+	return [][]string{{t.Name, "1"}, {"longer-name", "22"}}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"table", "json", "YAML"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("Expected %q to parse, got %v", name, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	v := testTable{Name: "seq-0", Count: 1}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatTable, "NAME          COUNT\nseq-0         1\nlonger-name   22\n"},
+		{FormatJSON, "{\n  \"name\": \"seq-0\",\n  \"count\": 1\n}\n"},
+		{FormatYAML, "count: 1\nname: seq-0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, v); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if err := Write(&bytes.Buffer{}, FormatTable, struct{}{}); err == nil {
+		t.Error("Expected an error for a value that is not tabular")
+	}
+}
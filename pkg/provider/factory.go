@@ -6,12 +6,50 @@ import (
 	"github.com/golem-base/seqctl/pkg/config"
 )
 
+// Provider types selectable via provider.type
+const (
+	TypeKubernetes = "kubernetes"
+	TypeStatic     = "static"
+)
+
 // NewProvider creates a provider based on the configuration
 func NewProvider(cfg *config.Config) (Provider, error) {
-	provider, err := NewK8sProvider(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes provider: %w", err)
+	switch cfg.Provider.Type {
+	case TypeStatic:
+		provider, err := NewStaticProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create static provider: %w", err)
+		}
+		return provider, nil
+
+	case TypeKubernetes, "k8s", "":
+		return newK8sProvider(cfg)
+
+	default:
+		return nil, fmt.Errorf("unknown provider type %q (expected %s or %s)",
+			cfg.Provider.Type, TypeKubernetes, TypeStatic)
 	}
+}
+
+// newK8sProvider creates a Kubernetes provider for the configured discovery mode
+func newK8sProvider(cfg *config.Config) (Provider, error) {
+	switch cfg.K8s.DiscoveryMode {
+	case DiscoveryModeInformer:
+		provider, err := NewK8sInformerProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes informer provider: %w", err)
+		}
+		return provider, nil
 
-	return provider, nil
+	case DiscoveryModeList, "":
+		provider, err := NewK8sProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes provider: %w", err)
+		}
+		return provider, nil
+
+	default:
+		return nil, fmt.Errorf("unknown Kubernetes discovery mode %q (expected %s or %s)",
+			cfg.K8s.DiscoveryMode, DiscoveryModeList, DiscoveryModeInformer)
+	}
 }
@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Discovery modes for the Kubernetes provider
+const (
+	DiscoveryModeList     = "list"
+	DiscoveryModeInformer = "informer"
+)
+
+// DefaultInformerResync is how often informers replay their cache to handlers
+const DefaultInformerResync = 10 * time.Minute
+
+// K8sInformerProvider discovers sequencers from shared informer caches
+//
+// Instead of listing namespaces, StatefulSets and Services on every discovery,
+// it keeps an in-memory index fed by watches, reports changes as they happen
+// through Watch, and reuses sequencers whose configuration has not changed.
+type K8sInformerProvider struct {
+	*K8sProvider
+
+	statefulSets []appslisters.StatefulSetLister
+	services     []corelisters.ServiceLister
+	namespaces   corelisters.NamespaceLister // nil unless a mode filter is set
+	factories    []informers.SharedInformerFactory
+	synced       []cache.InformerSynced
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+
+	// Event sink set by Watch
+	sinkMu sync.RWMutex
+	sink   chan<- Event
+
+	// Sequencers keyed by namespace/StatefulSet, reused across discoveries
+	mu         sync.Mutex
+	sequencers map[string]*sequencer.Sequencer
+}
+
+// NewK8sInformerProvider creates a Kubernetes provider backed by shared informers
+func NewK8sInformerProvider(cfg *config.Config) (*K8sInformerProvider, error) {
+	base, err := NewK8sProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newK8sInformerProvider(base)
+}
+
+// newK8sInformerProvider sets up informers on the client of a base provider
+func newK8sInformerProvider(base *K8sProvider) (*K8sInformerProvider, error) {
+	p := &K8sInformerProvider{
+		K8sProvider: base,
+		stopCh:      make(chan struct{}),
+		sequencers:  make(map[string]*sequencer.Sequencer),
+	}
+
+	if err := p.setupInformers(); err != nil {
+		return nil, fmt.Errorf("failed to set up informers: %w", err)
+	}
+
+	p.logger.Info("Kubernetes informer discovery enabled",
+		"namespaces", p.k8sConfig.Namespaces,
+		"resync", DefaultInformerResync)
+
+	return p, nil
+}
+
+// setupInformers creates StatefulSet and Service informers for each watched
+// namespace, plus a Namespace informer when a mode filter is configured
+func (p *K8sInformerProvider) setupInformers() error {
+	namespaces := p.k8sConfig.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	stsSelector := combineLabelSelectors(p.k8sConfig.StatefulSetSelector, p.k8sConfig.SequencerModeFilter)
+	svcSelector := combineLabelSelectors(p.k8sConfig.ServiceSelector, p.k8sConfig.SequencerModeFilter)
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			p.emit(EventAdded, obj)
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			if sameResourceVersion(oldObj, newObj) {
+				return // Periodic resync, nothing changed
+			}
+			p.emit(EventUpdated, newObj)
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			p.emit(EventDeleted, obj)
+		},
+	}
+
+	for _, ns := range namespaces {
+		stsFactory := informers.NewSharedInformerFactoryWithOptions(p.clientset, DefaultInformerResync,
+			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.LabelSelector = stsSelector
+			}))
+		svcFactory := informers.NewSharedInformerFactoryWithOptions(p.clientset, DefaultInformerResync,
+			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.LabelSelector = svcSelector
+			}))
+
+		stsInformer := stsFactory.Apps().V1().StatefulSets()
+		if _, err := stsInformer.Informer().AddEventHandler(handler); err != nil {
+			return fmt.Errorf("add StatefulSet handler: %w", err)
+		}
+		svcInformer := svcFactory.Core().V1().Services()
+		if _, err := svcInformer.Informer().AddEventHandler(handler); err != nil {
+			return fmt.Errorf("add Service handler: %w", err)
+		}
+
+		p.statefulSets = append(p.statefulSets, stsInformer.Lister())
+		p.services = append(p.services, svcInformer.Lister())
+		p.synced = append(p.synced, stsInformer.Informer().HasSynced, svcInformer.Informer().HasSynced)
+		p.factories = append(p.factories, stsFactory, svcFactory)
+	}
+
+	if p.k8sConfig.SequencerModeFilter != "" {
+		nsFactory := informers.NewSharedInformerFactoryWithOptions(p.clientset, DefaultInformerResync,
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.LabelSelector = p.k8sConfig.SequencerModeFilter
+			}))
+		nsInformer := nsFactory.Core().V1().Namespaces()
+		if _, err := nsInformer.Informer().AddEventHandler(handler); err != nil {
+			return fmt.Errorf("add Namespace handler: %w", err)
+		}
+
+		p.namespaces = nsInformer.Lister()
+		p.synced = append(p.synced, nsInformer.Informer().HasSynced)
+		p.factories = append(p.factories, nsFactory)
+	}
+
+	return nil
+}
+
+// start starts the informers once and waits for their caches to sync
+func (p *K8sInformerProvider) start(ctx context.Context) error {
+	p.startOnce.Do(func() {
+		for _, factory := range p.factories {
+			factory.Start(p.stopCh)
+		}
+	})
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stopCh:
+			cancel()
+		case <-syncCtx.Done():
+		}
+	}()
+
+	if !cache.WaitForCacheSync(syncCtx.Done(), p.synced...) {
+		return fmt.Errorf("timed out waiting for informer caches to sync")
+	}
+	return nil
+}
+
+// Watch starts the informers and forwards resource changes as events until
+// ctx is cancelled, after which the informers are stopped
+func (p *K8sInformerProvider) Watch(ctx context.Context, events chan<- Event) error {
+	p.sinkMu.Lock()
+	p.sink = events
+	p.sinkMu.Unlock()
+
+	defer func() {
+		p.sinkMu.Lock()
+		p.sink = nil
+		p.sinkMu.Unlock()
+	}()
+
+	if err := p.start(ctx); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	return nil
+}
+
+// emit forwards a resource change to the Watch sink without blocking the informer
+func (p *K8sInformerProvider) emit(eventType EventType, obj any) {
+	p.sinkMu.RLock()
+	defer p.sinkMu.RUnlock()
+
+	if p.sink == nil {
+		return
+	}
+
+	meta, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	event := Event{
+		Type:     eventType,
+		Network:  meta.GetLabels()[p.k8sConfig.NetworkLabel],
+		Resource: meta.GetNamespace() + "/" + meta.GetName(),
+	}
+
+	select {
+	case p.sink <- event:
+	default:
+		// The consumer already has pending events and will rediscover anyway
+		p.logger.Debug("Dropping discovery event, consumer is busy",
+			"type", event.Type, "resource", event.Resource)
+	}
+}
+
+// DiscoverNetworks builds networks from the informer caches
+func (p *K8sInformerProvider) DiscoverNetworks(ctx context.Context) (map[string]*network.Network, error) {
+	if err := p.start(ctx); err != nil {
+		return nil, err
+	}
+
+	allowed, err := p.allowedNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	statefulSets, err := p.listStatefulSets(allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := p.listServices(allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	serviceMaps := make(map[string]map[string]*corev1.Service, len(services))
+	for namespace, items := range services {
+		serviceMaps[namespace] = p.buildServiceMap(items)
+	}
+
+	networks := make(map[string]*network.Network)
+	seen := make(map[string]bool, len(statefulSets))
+
+	for _, sts := range statefulSets {
+		networkName := sts.Labels[p.k8sConfig.NetworkLabel]
+		if networkName == "" {
+			p.logger.Debug("StatefulSet has no network label",
+				"statefulset", sts.Name, "namespace", sts.Namespace)
+			continue
+		}
+
+		service, err := p.findMatchingService(sts, serviceMaps[sts.Namespace])
+		if err != nil {
+			p.logger.Warn("Failed to create sequencer",
+				"statefulset", sts.Name, "error", err)
+			continue
+		}
+
+		key := sts.Namespace + "/" + sts.Name
+		seq, err := p.reuseOrCreate(key, p.sequencerConfig(sts.Namespace, sts, service, networkName))
+		if err != nil {
+			p.logger.Warn("Failed to create sequencer",
+				"statefulset", sts.Name, "error", err)
+			continue
+		}
+		seen[key] = true
+
+		existing := []*sequencer.Sequencer{}
+		if net := networks[networkName]; net != nil {
+			existing = net.Sequencers()
+		}
+		networks[networkName] = network.NewNetwork(networkName, append(existing, seq))
+	}
+
+	// Forget sequencers whose StatefulSets are gone. They are not closed
+	// here: the repository's cached networks keep polling them until the
+	// new discovery replaces them, and it closes them after the swap.
+	for key := range p.sequencers {
+		if !seen[key] {
+			p.logger.Debug("Sequencer removed", "statefulset", key)
+			delete(p.sequencers, key)
+		}
+	}
+
+	return networks, nil
+}
+
+// reuseOrCreate returns the existing sequencer when its configuration is
+// unchanged, otherwise it creates a new one. The replaced sequencer is left
+// open for the repository, which closes it once no network uses it. Must be
+// called with mu held.
+func (p *K8sInformerProvider) reuseOrCreate(key string, cfg sequencer.Config) (*sequencer.Sequencer, error) {
+	previous, ok := p.sequencers[key]
+	if ok && previous.Config() == cfg {
+		return previous, nil
+	}
+
+	seq, err := p.newSequencer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.Debug("Sequencer discovered", "statefulset", key, "network", cfg.Network)
+	p.sequencers[key] = seq
+	return seq, nil
+}
+
+// allowedNamespaces returns the namespaces matching the mode filter, or nil
+// when every watched namespace is allowed
+func (p *K8sInformerProvider) allowedNamespaces() (map[string]bool, error) {
+	if p.namespaces == nil {
+		return nil, nil
+	}
+
+	nsList, err := p.namespaces.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces from cache: %w", err)
+	}
+
+	allowed := make(map[string]bool, len(nsList))
+	for _, ns := range nsList {
+		allowed[ns.Name] = true
+	}
+	return allowed, nil
+}
+
+// listStatefulSets returns cached StatefulSets in allowed namespaces
+func (p *K8sInformerProvider) listStatefulSets(allowed map[string]bool) ([]*appsv1.StatefulSet, error) {
+	var result []*appsv1.StatefulSet
+	for _, lister := range p.statefulSets {
+		items, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list StatefulSets from cache: %w", err)
+		}
+		for _, sts := range items {
+			if allowed == nil || allowed[sts.Namespace] {
+				result = append(result, sts)
+			}
+		}
+	}
+	return result, nil
+}
+
+// listServices returns cached Services in allowed namespaces grouped by namespace
+func (p *K8sInformerProvider) listServices(allowed map[string]bool) (map[string][]corev1.Service, error) {
+	result := make(map[string][]corev1.Service)
+	for _, lister := range p.services {
+		items, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Services from cache: %w", err)
+		}
+		for _, svc := range items {
+			if allowed == nil || allowed[svc.Namespace] {
+				result[svc.Namespace] = append(result[svc.Namespace], *svc)
+			}
+		}
+	}
+	return result, nil
+}
+
+// sameResourceVersion reports whether an update is a resync of an unchanged object
+func sameResourceVersion(oldObj, newObj any) bool {
+	oldMeta, ok1 := oldObj.(metav1.Object)
+	newMeta, ok2 := newObj.(metav1.Object)
+	return ok1 && ok2 && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion()
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// informerTestConfig watches the default namespace without selectors
+func informerTestConfig() config.K8sConfig {
+	cfg := config.New().K8s
+	cfg.Namespaces = []string{"default"}
+	cfg.StatefulSetSelector = ""
+	cfg.ServiceSelector = ""
+	cfg.SequencerModeFilter = ""
+	return cfg
+}
+
+// testStatefulSet returns a sequencer StatefulSet on network devnet
+func testStatefulSet(cfg config.K8sConfig, name, role string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Labels: map[string]string{
+				cfg.NetworkLabel:       "devnet",
+				cfg.AppLabel:           name,
+				cfg.SequencerRoleLabel: role,
+			},
+		},
+	}
+}
+
+// testService returns the Service of a sequencer StatefulSet
+func testService(cfg config.K8sConfig, name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{cfg.AppLabel: name},
+		},
+	}
+}
+
+func newTestInformerProvider(t *testing.T, cfg config.K8sConfig, clientset kubernetes.Interface) *K8sInformerProvider {
+	t.Helper()
+
+	p, err := newK8sInformerProvider(&K8sProvider{
+		clientset:  clientset,
+		k8sConfig:  cfg,
+		httpClient: http.DefaultClient,
+		logger:     slog.Default(),
+		urlBuilder: &urlBuilder{
+			config: &rest.Config{Host: "https://kubernetes.example"},
+			mode:   ConnectionModeProxy,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	return p
+}
+
+// awaitNetwork rediscovers until devnet satisfies cond
+func awaitNetwork(t *testing.T, p *K8sInformerProvider, cond func(*network.Network) bool, msg string) *network.Network {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		networks, err := p.DiscoverNetworks(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to discover networks: %v", err)
+		}
+		if net := networks["devnet"]; net != nil && cond(net) {
+			return net
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// awaitEvent waits for an event of the given type on the resource, skipping others
+func awaitEvent(t *testing.T, events <-chan Event, eventType EventType, resource string) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == eventType && event.Resource == resource {
+				if event.Network != "devnet" {
+					t.Errorf("Event network = %q, want devnet", event.Network)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatalf("No %s event for %s", eventType, resource)
+		}
+	}
+}
+
+func TestK8sInformerProvider_DiscoverNetworks(t *testing.T) {
+	cfg := informerTestConfig()
+	clientset := fake.NewClientset([]runtime.Object{
+		testStatefulSet(cfg, "sequencer-0", "voter"),
+		testService(cfg, "sequencer-0"),
+		testStatefulSet(cfg, "sequencer-1", "voter"),
+		testService(cfg, "sequencer-1"),
+	}...)
+	p := newTestInformerProvider(t, cfg, clientset)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event, 64)
+	go p.Watch(ctx, events)
+
+	initial := awaitNetwork(t, p, func(net *network.Network) bool {
+		return len(net.Sequencers()) == 2
+	}, "Initial StatefulSets never discovered")
+	seq0, seq1 := initial.SequencerByID("sequencer-0"), initial.SequencerByID("sequencer-1")
+	if seq0 == nil || seq1 == nil || !seq0.Voting() || !seq1.Voting() {
+		t.Fatalf("Discovered %v, want voting sequencer-0 and sequencer-1", initial.Sequencers())
+	}
+	if want := "sequencer-0.default.svc.cluster.local:50050"; seq0.RaftAddr() != want {
+		t.Errorf("RaftAddr() = %q, want %q", seq0.RaftAddr(), want)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		if _, err := clientset.CoreV1().Services("default").Create(ctx, testService(cfg, "sequencer-2"), metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create Service: %v", err)
+		}
+		if _, err := clientset.AppsV1().StatefulSets("default").Create(ctx, testStatefulSet(cfg, "sequencer-2", "nonvoter"), metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create StatefulSet: %v", err)
+		}
+		awaitEvent(t, events, EventAdded, "default/sequencer-2")
+
+		net := awaitNetwork(t, p, func(net *network.Network) bool {
+			return net.SequencerByID("sequencer-2") != nil
+		}, "Added StatefulSet never discovered")
+		if net.SequencerByID("sequencer-2").Voting() {
+			t.Error("sequencer-2 discovered as voting, want non-voting")
+		}
+		if net.SequencerByID("sequencer-0") != seq0 {
+			t.Error("Unchanged sequencer-0 was recreated")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		sts := testStatefulSet(cfg, "sequencer-1", "nonvoter")
+		sts.ResourceVersion = "2"
+		if _, err := clientset.AppsV1().StatefulSets("default").Update(ctx, sts, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Failed to update StatefulSet: %v", err)
+		}
+		awaitEvent(t, events, EventUpdated, "default/sequencer-1")
+
+		net := awaitNetwork(t, p, func(net *network.Network) bool {
+			seq := net.SequencerByID("sequencer-1")
+			return seq != nil && !seq.Voting()
+		}, "Updated StatefulSet never rediscovered")
+		if net.SequencerByID("sequencer-1") == seq1 {
+			t.Error("sequencer-1 kept after its configuration changed")
+		}
+		if net.SequencerByID("sequencer-0") != seq0 {
+			t.Error("Unchanged sequencer-0 was recreated")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := clientset.AppsV1().StatefulSets("default").Delete(ctx, "sequencer-2", metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("Failed to delete StatefulSet: %v", err)
+		}
+		awaitEvent(t, events, EventDeleted, "default/sequencer-2")
+
+		awaitNetwork(t, p, func(net *network.Network) bool {
+			return net.SequencerByID("sequencer-2") == nil && len(net.Sequencers()) == 2
+		}, "Deleted StatefulSet still discovered")
+
+		p.mu.Lock()
+		_, ok := p.sequencers["default/sequencer-2"]
+		p.mu.Unlock()
+		if ok {
+			t.Error("Provider still holds the deleted sequencer")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,8 +11,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -45,7 +48,7 @@ const (
 // relevant resources. The StatefulSet and its corresponding Service must share the
 // same app label (configured via k8s.app_label) for matching.
 type K8sProvider struct {
-	clientset   *kubernetes.Clientset
+	clientset   kubernetes.Interface
 	config      *rest.Config
 	k8sConfig   config.K8sConfig
 	httpClient  *http.Client
@@ -173,7 +176,7 @@ func isDirectInCluster(mode string, isInCluster bool) bool {
 
 // Name returns the provider type
 func (p *K8sProvider) Name() string {
-	return "kubernetes"
+	return TypeKubernetes
 }
 
 // DiscoverNetworks discovers all networks and their sequencers
@@ -201,6 +204,71 @@ func (p *K8sProvider) DiscoverNetworks(ctx context.Context) (map[string]*network
 	return networks, nil
 }
 
+// RecordVoting sets the role label of the sequencer's StatefulSet to the
+// first voter value, or to the non-voter value, so that rediscovery derives
+// the new suffrage
+func (p *K8sProvider) RecordVoting(ctx context.Context, seq *sequencer.Sequencer, voting bool) error {
+	value := p.k8sConfig.SequencerNonvoterValue
+	if voting {
+		if len(p.k8sConfig.SequencerVoterValues) == 0 {
+			return fmt.Errorf("no sequencer voter values configured")
+		}
+		value = p.k8sConfig.SequencerVoterValues[0]
+	}
+	if p.k8sConfig.SequencerRoleLabel == "" || value == "" {
+		return fmt.Errorf("sequencer role label and values must be configured to record suffrage")
+	}
+
+	sts, err := p.findStatefulSet(ctx, seq)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{p.k8sConfig.SequencerRoleLabel: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.clientset.AppsV1().StatefulSets(sts.Namespace).Patch(ctx, sts.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to label StatefulSet %s/%s: %w", sts.Namespace, sts.Name, err)
+	}
+
+	p.logger.Info("Sequencer role label updated",
+		"sequencer", seq.ID(),
+		"namespace", sts.Namespace,
+		"label", p.k8sConfig.SequencerRoleLabel,
+		"value", value)
+	return nil
+}
+
+// findStatefulSet returns the StatefulSet a sequencer was discovered from
+func (p *K8sProvider) findStatefulSet(ctx context.Context, seq *sequencer.Sequencer) (*appsv1.StatefulSet, error) {
+	namespaces, err := p.getNamespacesToScan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces {
+		sts, err := p.clientset.AppsV1().StatefulSets(namespace).Get(ctx, seq.ID(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get StatefulSet %s/%s: %w", namespace, seq.ID(), err)
+		}
+		if sts.Labels[p.k8sConfig.NetworkLabel] == seq.Network() &&
+			matchesLabelSelector(sts.Labels, p.k8sConfig.StatefulSetSelector) {
+			return sts, nil
+		}
+	}
+	return nil, fmt.Errorf("no StatefulSet found for sequencer %s", seq.ID())
+}
+
 // getNamespacesToScan returns the list of namespaces to scan
 func (p *K8sProvider) getNamespacesToScan(ctx context.Context) ([]string, error) {
 	// If specific namespaces are configured, use those
@@ -435,6 +503,16 @@ func (p *K8sProvider) createSequencer(
 	svc *corev1.Service,
 	networkName string,
 ) (*sequencer.Sequencer, error) {
+	return p.newSequencer(p.sequencerConfig(namespace, sts, svc, networkName))
+}
+
+// sequencerConfig derives a sequencer's configuration from Kubernetes resources
+func (p *K8sProvider) sequencerConfig(
+	namespace string,
+	sts *appsv1.StatefulSet,
+	svc *corev1.Service,
+	networkName string,
+) sequencer.Config {
 	ports := p.extractPorts(svc)
 	urls := p.buildURLs(namespace, svc.Name, ports)
 
@@ -460,7 +538,7 @@ func (p *K8sProvider) createSequencer(
 			"label_key", p.k8sConfig.SequencerRoleLabel)
 	}
 
-	cfg := sequencer.Config{
+	return sequencer.Config{
 		ID:           sts.Name,
 		RaftAddr:     p.buildRaftAddress(namespace, svc.Name),
 		ConductorURL: urls.conductor,
@@ -468,13 +546,16 @@ func (p *K8sProvider) createSequencer(
 		Voting:       isVoter,
 		Network:      networkName,
 	}
+}
 
+// newSequencer creates a sequencer using the provider's HTTP client
+func (p *K8sProvider) newSequencer(cfg sequencer.Config) (*sequencer.Sequencer, error) {
 	seq, err := sequencer.New(context.Background(), cfg,
 		rpc.WithHTTPClient(p.selectHTTPClient()),
 		rpc.WithTimeout(DefaultSequencerTimeout),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sequencer %s: %w", sts.Name, err)
+		return nil, fmt.Errorf("failed to create sequencer %s: %w", cfg.ID, err)
 	}
 
 	return seq, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
 )
 
 // Provider defines the interface for discovering sequencer infrastructure
@@ -14,3 +15,59 @@ type Provider interface {
 	// DiscoverNetworks returns all available networks with their sequencers
 	DiscoverNetworks(ctx context.Context) (map[string]*network.Network, error)
 }
+
+// EventType describes the kind of change reported by a Watcher
+type EventType string
+
+// Event types reported by watching providers
+const (
+	EventAdded   EventType = "added"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// Event reports a change to the infrastructure backing a network
+type Event struct {
+	Type     EventType
+	Network  string // Network label of the resource, empty if unknown
+	Resource string // Name of the changed resource
+}
+
+// Watcher is implemented by providers that push discovery changes as they
+// happen instead of relying solely on periodic rediscovery
+type Watcher interface {
+	// Watch sends events to the channel until ctx is cancelled
+	Watch(ctx context.Context, events chan<- Event) error
+}
+
+// Outcomes of recording a sequencer's suffrage
+const (
+	VotingUnchanged   = "unchanged"   // The provider already had the sequencer's suffrage
+	VotingRecorded    = "recorded"    // The provider was updated
+	VotingUnsupported = "unsupported" // The provider cannot record suffrage
+	VotingFailed      = "failed"      // Recording failed, reported by callers along with the error
+)
+
+// VotingRecorder is implemented by providers that can record whether a
+// sequencer votes where discovery reads Voting from, so that a suffrage
+// change made in Raft survives rediscovery
+type VotingRecorder interface {
+	// RecordVoting records the suffrage of the sequencer
+	RecordVoting(ctx context.Context, seq *sequencer.Sequencer, voting bool) error
+}
+
+// RecordVoting brings the provider's view of whether the sequencer votes in
+// line with voting and returns the outcome
+func RecordVoting(ctx context.Context, p Provider, seq *sequencer.Sequencer, voting bool) (string, error) {
+	if seq.Voting() == voting {
+		return VotingUnchanged, nil
+	}
+	recorder, ok := p.(VotingRecorder)
+	if !ok {
+		return VotingUnsupported, nil
+	}
+	if err := recorder.RecordVoting(ctx, seq, voting); err != nil {
+		return "", err
+	}
+	return VotingRecorded, nil
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// votingRecorder wraps a provider and records suffrage in memory
+type votingRecorder struct {
+	Provider
+	recorded map[string]bool
+}
+
+func (r *votingRecorder) RecordVoting(_ context.Context, seq *sequencer.Sequencer, voting bool) error {
+	r.recorded[seq.ID()] = voting
+	return nil
+}
+
+func TestRecordVoting(t *testing.T) {
+	static, err := NewProvider(staticTestConfig())
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+
+	ctx := context.Background()
+	networks, err := static.DiscoverNetworks(ctx)
+	if err != nil {
+		t.Fatalf("Failed to discover networks: %v", err)
+	}
+	voter := networks["devnet"].SequencerByID("sequencer-0")
+
+	recorder := &votingRecorder{Provider: static, recorded: make(map[string]bool)}
+
+	tests := []struct {
+		name     string
+		provider Provider
+		voting   bool
+		want     string
+	}{
+		{"already voting", recorder, true, VotingUnchanged},
+		{"static config", static, false, VotingUnsupported},
+		{"recorder", recorder, false, VotingRecorded},
+	}
+	for _, tt := range tests {
+		got, err := RecordVoting(ctx, tt.provider, voter, tt.voting)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: RecordVoting() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if voting, ok := recorder.recorded["sequencer-0"]; !ok || voting {
+		t.Errorf("Recorded %v, %v, want sequencer-0 recorded as non-voting", voting, ok)
+	}
+}
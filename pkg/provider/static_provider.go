@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/rpc"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// StaticProvider serves sequencers defined in the configuration file
+//
+// It is intended for conductors running outside Kubernetes, such as bare VMs or
+// docker-compose devnets. Sequencers are declared under [[networks]] with one
+// [[networks.sequencers]] table per member.
+type StaticProvider struct {
+	networks []config.NetworkConfig
+	logger   *slog.Logger
+
+	// Sequencers are created once and reused across discoveries
+	mu         sync.Mutex
+	sequencers map[string]*sequencer.Sequencer
+}
+
+// NewStaticProvider creates a provider from the networks in the configuration
+func NewStaticProvider(cfg *config.Config) (*StaticProvider, error) {
+	if err := validateStaticNetworks(cfg.Networks); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	provider := &StaticProvider{
+		networks:   cfg.Networks,
+		logger:     slog.Default().With(slog.String("provider", "static")),
+		sequencers: make(map[string]*sequencer.Sequencer),
+	}
+
+	provider.logger.Info("Static provider initialized",
+		"networks", len(cfg.Networks))
+
+	return provider, nil
+}
+
+// Name returns the provider type
+func (p *StaticProvider) Name() string {
+	return TypeStatic
+}
+
+// DiscoverNetworks returns the configured networks and their sequencers
+func (p *StaticProvider) DiscoverNetworks(ctx context.Context) (map[string]*network.Network, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	networks := make(map[string]*network.Network, len(p.networks))
+
+	for _, netCfg := range p.networks {
+		sequencers := make([]*sequencer.Sequencer, 0, len(netCfg.Sequencers))
+
+		for _, seqCfg := range netCfg.Sequencers {
+			seq, err := p.getOrCreateSequencer(ctx, netCfg.Name, seqCfg)
+			if err != nil {
+				// Skip for now, creation is retried on the next discovery
+				p.logger.Warn("Failed to create sequencer",
+					"network", netCfg.Name, "sequencer", seqCfg.ID, "error", err)
+				continue
+			}
+			sequencers = append(sequencers, seq)
+		}
+
+		networks[netCfg.Name] = network.NewNetwork(netCfg.Name, sequencers)
+	}
+
+	return networks, nil
+}
+
+// getOrCreateSequencer returns the cached sequencer or creates it on first use
+func (p *StaticProvider) getOrCreateSequencer(
+	ctx context.Context,
+	networkName string,
+	seqCfg config.StaticSequencerConfig,
+) (*sequencer.Sequencer, error) {
+	if seq, ok := p.sequencers[seqCfg.ID]; ok {
+		return seq, nil
+	}
+
+	seq, err := sequencer.New(ctx, sequencer.Config{
+		ID:           seqCfg.ID,
+		RaftAddr:     seqCfg.RaftAddr,
+		ConductorURL: seqCfg.ConductorURL,
+		NodeURL:      seqCfg.NodeURL,
+		Voting:       seqCfg.Voting,
+		Network:      networkName,
+	}, rpc.WithTimeout(DefaultSequencerTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	p.sequencers[seqCfg.ID] = seq
+	return seq, nil
+}
+
+// validateStaticNetworks checks that static networks are complete and that
+// sequencer IDs are unique, since the API addresses sequencers by ID alone
+func validateStaticNetworks(networks []config.NetworkConfig) error {
+	if len(networks) == 0 {
+		return fmt.Errorf("static provider requires at least one [[networks]] entry")
+	}
+
+	networkNames := make(map[string]bool, len(networks))
+	sequencerIDs := make(map[string]string)
+
+	for i, netCfg := range networks {
+		if netCfg.Name == "" {
+			return fmt.Errorf("networks[%d]: name is required", i)
+		}
+		if networkNames[netCfg.Name] {
+			return fmt.Errorf("networks[%d]: duplicate network name %q", i, netCfg.Name)
+		}
+		networkNames[netCfg.Name] = true
+
+		if len(netCfg.Sequencers) == 0 {
+			return fmt.Errorf("network %q: at least one sequencer is required", netCfg.Name)
+		}
+
+		for j, seqCfg := range netCfg.Sequencers {
+			switch {
+			case seqCfg.ID == "":
+				return fmt.Errorf("network %q: sequencers[%d]: id is required", netCfg.Name, j)
+			case seqCfg.RaftAddr == "":
+				return fmt.Errorf("network %q: sequencer %q: raft_addr is required", netCfg.Name, seqCfg.ID)
+			case seqCfg.ConductorURL == "":
+				return fmt.Errorf("network %q: sequencer %q: conductor_url is required", netCfg.Name, seqCfg.ID)
+			case seqCfg.NodeURL == "":
+				return fmt.Errorf("network %q: sequencer %q: node_url is required", netCfg.Name, seqCfg.ID)
+			}
+
+			if other, ok := sequencerIDs[seqCfg.ID]; ok {
+				return fmt.Errorf("network %q: sequencer id %q is already used in network %q",
+					netCfg.Name, seqCfg.ID, other)
+			}
+			sequencerIDs[seqCfg.ID] = netCfg.Name
+		}
+	}
+
+	return nil
+}
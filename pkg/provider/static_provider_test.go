@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+func staticTestConfig() *config.Config {
+	cfg := config.New()
+	cfg.Provider.Type = TypeStatic
+	cfg.Networks = []config.NetworkConfig{
+		{
+			Name: "devnet",
+			Sequencers: []config.StaticSequencerConfig{
+				{
+					ID:           "sequencer-0",
+					RaftAddr:     "sequencer-0:50050",
+					ConductorURL: "http://127.0.0.1:8545",
+					NodeURL:      "http://127.0.0.1:9545",
+					Voting:       true,
+				},
+				{
+					ID:           "sequencer-1",
+					RaftAddr:     "sequencer-1:50050",
+					ConductorURL: "http://127.0.0.1:8546",
+					NodeURL:      "http://127.0.0.1:9546",
+				},
+			},
+		},
+	}
+	return cfg
+}
+
+func TestStaticProvider_DiscoverNetworks(t *testing.T) {
+	provider, err := NewProvider(staticTestConfig())
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	if provider.Name() != TypeStatic {
+		t.Errorf("Expected provider %q, got %q", TypeStatic, provider.Name())
+	}
+
+	ctx := context.Background()
+	networks, err := provider.DiscoverNetworks(ctx)
+	if err != nil {
+		t.Fatalf("Failed to discover networks: %v", err)
+	}
+
+	net, ok := networks["devnet"]
+	if !ok {
+		t.Fatal("Expected network devnet to be discovered")
+	}
+	if len(net.Sequencers()) != 2 {
+		t.Fatalf("Expected 2 sequencers, got %d", len(net.Sequencers()))
+	}
+
+	seq := net.SequencerByID("sequencer-0")
+	if seq == nil {
+		t.Fatal("Expected sequencer-0 to be discovered")
+	}
+	if !seq.Voting() || seq.RaftAddr() != "sequencer-0:50050" || seq.Network() != "devnet" {
+		t.Errorf("Unexpected sequencer configuration: voting=%t raft=%s network=%s",
+			seq.Voting(), seq.RaftAddr(), seq.Network())
+	}
+
+	// Sequencers must be reused across discoveries rather than re-dialed
+	networks, err = provider.DiscoverNetworks(ctx)
+	if err != nil {
+		t.Fatalf("Failed to rediscover networks: %v", err)
+	}
+	if networks["devnet"].SequencerByID("sequencer-0") != seq {
+		t.Error("Expected sequencer instance to be reused")
+	}
+}
+
+func TestStaticProvider_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(cfg *config.Config)
+		want   string
+	}{
+		{
+			name:   "no networks",
+			mutate: func(cfg *config.Config) { cfg.Networks = nil },
+			want:   "at least one [[networks]]",
+		},
+		{
+			name:   "missing network name",
+			mutate: func(cfg *config.Config) { cfg.Networks[0].Name = "" },
+			want:   "name is required",
+		},
+		{
+			name:   "missing conductor url",
+			mutate: func(cfg *config.Config) { cfg.Networks[0].Sequencers[1].ConductorURL = "" },
+			want:   "conductor_url is required",
+		},
+		{
+			name: "duplicate sequencer id across networks",
+			mutate: func(cfg *config.Config) {
+				cfg.Networks = append(cfg.Networks, config.NetworkConfig{
+					Name:       "testnet",
+					Sequencers: cfg.Networks[0].Sequencers[:1],
+				})
+			},
+			want: "already used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := staticTestConfig()
+			tt.mutate(cfg)
+
+			_, err := NewStaticProvider(cfg)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewProvider_UnknownType(t *testing.T) {
+	cfg := config.New()
+	cfg.Provider.Type = "consul"
+
+	if _, err := NewProvider(cfg); err == nil {
+		t.Error("Expected error for unknown provider type")
+	}
+}
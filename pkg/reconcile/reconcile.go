@@ -0,0 +1,207 @@
+// Package reconcile compares the Raft membership of a network against the
+// suffrage its discovered sequencers should have, and plans and applies the
+// changes that bring the two in line
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/membership"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// Step actions, in the order a plan applies them. Members are added and
+// promoted before any are demoted or removed, so that the cluster never has
+// fewer voters on the way than at either end.
+const (
+	ActionAddVoter    = "add_voter"
+	ActionAddNonvoter = "add_nonvoter"
+	ActionPromote     = "promote"
+	ActionDemote      = "demote"
+	ActionRemove      = "remove"
+)
+
+// Step outcomes, set when a plan is applied
+const (
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped" // Not attempted after an earlier step failed
+)
+
+// Errors returned by Apply and Check before any change is made
+var (
+	ErrNoLeader = errors.New("network has no conductor leader")
+	ErrOutdated = errors.New("membership changed since the plan was made")
+	ErrChanged  = errors.New("planned changes differ from the reviewed plan")
+)
+
+// Step is one change to the Raft membership
+type Step struct {
+	Action string
+	ID     string
+	Addr   string
+	Reason string
+	Status string // Outcome once applied, empty before
+	Detail string // Error of a failed step
+}
+
+// Plan lists the changes that bring the Raft membership of a network in line
+// with the suffrage of its discovered sequencers
+type Plan struct {
+	Network    string
+	Version    uint64 // Membership version the plan was made against
+	Source     string // Sequencer the membership was read from
+	FromLeader bool   // Source is the conductor leader
+	Steps      []Step
+}
+
+// InSync reports whether the membership needs no changes
+func (p *Plan) InSync() bool {
+	return len(p.Steps) == 0
+}
+
+// Check refuses the plan unless it was made against the reviewed membership
+// version and makes exactly the reviewed changes, compared by action, member
+// and address in order. The version only covers the Raft membership: a
+// sequencer that stops being discovered after the review, even for a moment,
+// turns into a removal that must not be applied unseen.
+func (p *Plan) Check(version uint64, reviewed []Step) error {
+	if version != p.Version {
+		return fmt.Errorf("%w: reviewed at version %d, now at %d", ErrOutdated, version, p.Version)
+	}
+
+	for i, step := range p.Steps {
+		if i >= len(reviewed) || !sameChange(step, reviewed[i]) {
+			return fmt.Errorf("%w: %s %s (%s) was not reviewed", ErrChanged, step.Action, step.ID, step.Reason)
+		}
+	}
+	if len(reviewed) > len(p.Steps) {
+		extra := reviewed[len(p.Steps)]
+		return fmt.Errorf("%w: reviewed %s %s is no longer planned", ErrChanged, extra.Action, extra.ID)
+	}
+	return nil
+}
+
+// sameChange reports whether two steps make the same change
+func sameChange(a, b Step) bool {
+	return a.Action == b.Action && a.ID == b.ID && a.Addr == b.Addr
+}
+
+// NewPlan reads the membership of a network and plans the changes it needs.
+// Discovered sequencers that are not members are added with the suffrage
+// their Voting flag gives, members whose suffrage differs from it are
+// promoted or demoted, and members no discovered sequencer matches are
+// removed.
+func NewPlan(ctx context.Context, net *network.Network) (*Plan, error) {
+	m, err := membership.Get(ctx, net)
+	if err != nil {
+		return nil, err
+	}
+	return planFor(m), nil
+}
+
+// planFor plans the changes the membership needs
+func planFor(m *membership.Membership) *Plan {
+	plan := &Plan{
+		Network:    m.Network,
+		Version:    m.Version,
+		Source:     m.Source,
+		FromLeader: m.FromLeader,
+	}
+
+	var adds, promotions, demotions, removals []Step
+	for _, seq := range m.Missing {
+		if seq.Voting() {
+			adds = append(adds, Step{Action: ActionAddVoter, ID: seq.ID(), Addr: seq.RaftAddr(),
+				Reason: "discovered voter is not a member"})
+		} else {
+			adds = append(adds, Step{Action: ActionAddNonvoter, ID: seq.ID(), Addr: seq.RaftAddr(),
+				Reason: "discovered non-voter is not a member"})
+		}
+	}
+	for _, member := range m.Members {
+		switch {
+		case member.Sequencer == nil:
+			removals = append(removals, Step{Action: ActionRemove, ID: member.ID, Addr: member.Addr,
+				Reason: "member matches no discovered sequencer"})
+		case member.Sequencer.Voting() && !member.Voter:
+			promotions = append(promotions, Step{Action: ActionPromote, ID: member.ID, Addr: member.Addr,
+				Reason: "discovered as a voter but is a non-voting member"})
+		case !member.Sequencer.Voting() && member.Voter:
+			demotions = append(demotions, Step{Action: ActionDemote, ID: member.ID, Addr: member.Addr,
+				Reason: "discovered as a non-voter but is a voting member"})
+		}
+	}
+
+	for _, steps := range [][]Step{adds, promotions, demotions, removals} {
+		plan.Steps = append(plan.Steps, steps...)
+	}
+	return plan
+}
+
+// Apply makes the changes of the plan in order through the network's
+// conductor leader and stops at the first failure, marking the remaining
+// steps skipped. Each change goes through the same checks and membership
+// versioning as the equivalent manual action, and a plan made against an
+// older membership version is refused. The membership after the last change
+// is returned.
+func Apply(ctx context.Context, net *network.Network, plan *Plan) (*membership.Membership, error) {
+	leader := net.ConductorLeader()
+	if leader == nil {
+		return nil, ErrNoLeader
+	}
+
+	current, err := membership.Read(ctx, net, leader)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version != plan.Version {
+		return nil, fmt.Errorf("%w: planned against version %d, now at %d", ErrOutdated, plan.Version, current.Version)
+	}
+
+	var failed error
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		if failed != nil {
+			step.Status = StepSkipped
+			continue
+		}
+
+		m, err := applyStep(ctx, net, step)
+		if err != nil {
+			step.Status, step.Detail = StepFailed, err.Error()
+			failed = fmt.Errorf("%s %s: %w", step.Action, step.ID, err)
+			continue
+		}
+		step.Status = StepSucceeded
+		current = m
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	return current, nil
+}
+
+// applyStep makes one change through the network's conductor leader
+func applyStep(ctx context.Context, net *network.Network, step *Step) (*membership.Membership, error) {
+	switch step.Action {
+	case ActionAddVoter, ActionAddNonvoter:
+		return action.AddMember(ctx, net, net.ConductorLeader(), step.ID, step.Addr, step.Action == ActionAddVoter)
+	case ActionRemove:
+		return action.RemoveMember(ctx, net, net.ConductorLeader(), step.ID)
+	case ActionPromote, ActionDemote:
+		seq := net.SequencerByID(step.ID)
+		if seq == nil {
+			return nil, fmt.Errorf("sequencer %s is no longer discovered", step.ID)
+		}
+		if step.Action == ActionPromote {
+			return action.PromoteMember(ctx, net, seq)
+		}
+		return action.DemoteMember(ctx, net, seq)
+	default:
+		return nil, fmt.Errorf("unknown step action %q", step.Action)
+	}
+}
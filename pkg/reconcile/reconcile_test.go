@@ -0,0 +1,145 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+func newTestNetwork(t *testing.T, c *conductortest.Cluster, voting map[string]bool) *network.Network {
+	t.Helper()
+
+	net := network.NewNetwork("devnet", c.Sequencers(t, voting))
+	_ = net.Update(context.Background())
+	return net
+}
+
+func TestPlanAndApply(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Servers: []consensus.ServerInfo{
+			{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-1", Addr: "sequencer-1:50050", Suffrage: consensus.Nonvoter},
+			{ID: "sequencer-2", Addr: "sequencer-2:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-9", Addr: "sequencer-9:50050", Suffrage: consensus.Nonvoter},
+		},
+		Version: 4,
+	}
+	net := newTestNetwork(t, c, map[string]bool{
+		"sequencer-0": true,
+		"sequencer-1": true,  // Non-voting member to promote
+		"sequencer-2": false, // Voting member to demote
+		"sequencer-3": true,  // Not a member yet
+	})
+
+	plan, err := NewPlan(context.Background(), net)
+	if err != nil {
+		t.Fatalf("NewPlan() = %v", err)
+	}
+
+	var got []string
+	for _, step := range plan.Steps {
+		got = append(got, step.Action+" "+step.ID)
+	}
+	want := []string{
+		"add_voter sequencer-3",
+		"promote sequencer-1",
+		"demote sequencer-2",
+		"remove sequencer-9",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("NewPlan() steps = %v, want %v", got, want)
+	}
+
+	m, err := Apply(context.Background(), net, plan)
+	if err != nil {
+		t.Fatalf("Apply() = %v", err)
+	}
+	for _, step := range plan.Steps {
+		if step.Status != StepSucceeded {
+			t.Errorf("Step %s %s = %s (%s), want succeeded", step.Action, step.ID, step.Status, step.Detail)
+		}
+	}
+	if m.Voters() != 3 || len(m.Members) != 4 {
+		t.Errorf("Apply() = %d members with %d voters, want 4 with 3", len(m.Members), m.Voters())
+	}
+
+	plan, err = NewPlan(context.Background(), net)
+	if err != nil {
+		t.Fatalf("NewPlan() = %v", err)
+	}
+	if !plan.InSync() {
+		t.Errorf("NewPlan() after Apply() = %+v, want in sync", plan.Steps)
+	}
+}
+
+func TestApply_Outdated(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader:  "sequencer-0",
+		Servers: []consensus.ServerInfo{{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter}},
+	}
+	net := newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": false})
+
+	plan, err := NewPlan(context.Background(), net)
+	if err != nil {
+		t.Fatalf("NewPlan() = %v", err)
+	}
+
+	// Someone else changes the membership after the plan was made
+	c.Lock()
+	c.Version++
+	c.Unlock()
+
+	if _, err := Apply(context.Background(), net, plan); !errors.Is(err, ErrOutdated) {
+		t.Errorf("Apply() = %v, want ErrOutdated", err)
+	}
+	if plan.Steps[0].Status != "" {
+		t.Errorf("Step status = %q, want the step not attempted", plan.Steps[0].Status)
+	}
+}
+
+func TestPlan_Check(t *testing.T) {
+	c := &conductortest.Cluster{
+		Leader: "sequencer-0",
+		Servers: []consensus.ServerInfo{
+			{ID: "sequencer-0", Addr: "sequencer-0:50050", Suffrage: consensus.Voter},
+			{ID: "sequencer-1", Addr: "sequencer-1:50050", Suffrage: consensus.Voter},
+		},
+		Version: 7,
+	}
+
+	reviewed, err := NewPlan(context.Background(), newTestNetwork(t, c, map[string]bool{"sequencer-0": true, "sequencer-1": true}))
+	if err != nil {
+		t.Fatalf("NewPlan() = %v", err)
+	}
+	if err := reviewed.Check(7, reviewed.Steps); err != nil {
+		t.Errorf("Check() of the reviewed plan = %v", err)
+	}
+	if err := reviewed.Check(6, reviewed.Steps); !errors.Is(err, ErrOutdated) {
+		t.Errorf("Check() at an older version = %v, want ErrOutdated", err)
+	}
+
+	// sequencer-1 drops out of discovery after the review, at the same
+	// membership version
+	plan, err := NewPlan(context.Background(), newTestNetwork(t, c, map[string]bool{"sequencer-0": true}))
+	if err != nil {
+		t.Fatalf("NewPlan() = %v", err)
+	}
+	if len(plan.Steps) != 1 || plan.Steps[0].Action != ActionRemove {
+		t.Fatalf("NewPlan() steps = %+v, want sequencer-1 removed", plan.Steps)
+	}
+	if err := plan.Check(7, reviewed.Steps); !errors.Is(err, ErrChanged) {
+		t.Errorf("Check() with an unreviewed removal = %v, want ErrChanged", err)
+	}
+
+	// A reviewed step that is no longer planned is refused as well
+	if err := reviewed.Check(7, plan.Steps); !errors.Is(err, ErrChanged) {
+		t.Errorf("Check() with a step no longer planned = %v, want ErrChanged", err)
+	}
+}
@@ -3,11 +3,27 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/golem-base/seqctl/pkg/network"
 	"github.com/golem-base/seqctl/pkg/provider"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// discoveryKey is the singleflight key used to coalesce discovery refreshes
+const discoveryKey = "\x00discovery"
+
+// Timeouts of the discoveries and status updates shared by concurrent callers,
+// which do not end with the caller that started them
+const (
+	discoveryTimeout = 30 * time.Second
+	statusTimeout    = 10 * time.Second
 )
 
 // NetworkRepository provides access to networks with caching capabilities
@@ -21,11 +37,24 @@ type NetworkRepository interface {
 	// RefreshCache forces a cache refresh from the provider
 	RefreshCache(ctx context.Context) error
 
+	// RecordVoting records the suffrage of a sequencer with the provider and
+	// rediscovers networks if it changed. It returns one of the
+	// provider.Voting* outcomes.
+	RecordVoting(ctx context.Context, seq *sequencer.Sequencer, voting bool) (string, error)
+
 	// InvalidateNetwork removes a specific network from cache
 	InvalidateNetwork(name string)
 
 	// InvalidateAll clears the entire cache
 	InvalidateAll()
+
+	// Subscribe registers a handler notified whenever a network update
+	// changes the status of its sequencers
+	Subscribe(handler network.ChangeHandler)
+
+	// SubscribeEvents registers a handler notified of the events detected
+	// by network updates and rediscovery
+	SubscribeEvents(handler network.EventHandler)
 }
 
 // CachedNetworkRepository implements NetworkRepository with caching
@@ -40,16 +69,28 @@ type CachedNetworkRepository struct {
 	discoveryTTL time.Duration // How long to cache network discovery
 	statusTTL    time.Duration // How long before updating network status
 
+	// Unsafe head tracking thresholds applied to discovered networks
+	head network.HeadConfig
+
+	// Status change and event subscribers
+	handlers      []network.ChangeHandler
+	eventHandlers []network.EventHandler
+
+	// Background polling state, see Run
+	polling atomic.Bool
+	group   singleflight.Group
+	logger  *slog.Logger
+
 	// Thread safety
 	mu sync.RWMutex
 }
 
 // NewCachedNetworkRepository creates a new repository with caching
-func NewCachedNetworkRepository(provider provider.Provider, discoveryTTL, statusTTL time.Duration) *CachedNetworkRepository {
-	if discoveryTTL == 0 {
+func NewCachedNetworkRepository(provider provider.Provider, discoveryTTL, statusTTL time.Duration, head network.HeadConfig) *CachedNetworkRepository {
+	if discoveryTTL <= 0 {
 		discoveryTTL = 5 * time.Minute
 	}
-	if statusTTL == 0 {
+	if statusTTL <= 0 {
 		statusTTL = 10 * time.Second
 	}
 
@@ -58,15 +99,23 @@ func NewCachedNetworkRepository(provider provider.Provider, discoveryTTL, status
 		networks:     make(map[string]*network.Network),
 		discoveryTTL: discoveryTTL,
 		statusTTL:    statusTTL,
+		head:         head,
+		logger:       slog.Default().With(slog.String("component", "repository")),
 	}
 }
 
 // GetNetwork returns a network by name with updated status
 func (r *CachedNetworkRepository) GetNetwork(ctx context.Context, name string) (*network.Network, error) {
+	// The background poller keeps the cache fresh, serve the snapshot as-is
+	if r.polling.Load() {
+		return r.snapshotNetwork(ctx, name)
+	}
+
 	// Check if we need to refresh discovery
 	if r.shouldRefreshDiscovery() {
 		if err := r.RefreshCache(ctx); err != nil {
-			// Log error but continue with stale data if available
+			// Continue with stale data if available
+			r.logger.Warn("Failed to refresh network discovery", "error", err)
 		}
 	}
 
@@ -101,6 +150,11 @@ func (r *CachedNetworkRepository) GetNetwork(ctx context.Context, name string) (
 
 // ListNetworks returns all available networks
 func (r *CachedNetworkRepository) ListNetworks(ctx context.Context) (map[string]*network.Network, error) {
+	// The background poller keeps the cache fresh, serve the snapshot as-is
+	if r.polling.Load() {
+		return r.snapshotNetworks(ctx)
+	}
+
 	if r.shouldRefreshDiscovery() {
 		if err := r.RefreshCache(ctx); err != nil {
 			r.mu.RLock()
@@ -136,30 +190,197 @@ func (r *CachedNetworkRepository) ListNetworks(ctx context.Context) (map[string]
 	return result, nil
 }
 
-// RefreshCache forces a cache refresh from the provider
+// RefreshCache forces a cache refresh from the provider.
+// Concurrent calls share a single discovery.
 func (r *CachedNetworkRepository) RefreshCache(ctx context.Context) error {
-	networks, err := r.provider.DiscoverNetworks(ctx)
+	return r.shared(ctx, discoveryKey, discoveryTimeout, r.refreshCache)
+}
+
+// RecordVoting records the suffrage of a sequencer with the provider. Networks
+// are rediscovered after a change so that Voting reflects it.
+func (r *CachedNetworkRepository) RecordVoting(ctx context.Context, seq *sequencer.Sequencer, voting bool) (string, error) {
+	outcome, err := provider.RecordVoting(ctx, r.provider, seq, voting)
+	if err != nil || outcome != provider.VotingRecorded {
+		return outcome, err
+	}
+
+	if err := r.RefreshCache(ctx); err != nil {
+		r.logger.Warn("Failed to rediscover networks after recording suffrage",
+			"sequencer", seq.ID(), "error", err)
+	}
+	return outcome, nil
+}
+
+// refreshCache discovers networks from the provider and merges them into the
+// cache. Networks and sequencers whose configuration is unchanged are kept,
+// with their status and connections, and sequencers that are replaced or gone
+// are closed.
+func (r *CachedNetworkRepository) refreshCache(ctx context.Context) error {
+	discovered, err := r.provider.DiscoverNetworks(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to discover networks using %s provider: %w", r.provider.Name(), err)
 	}
 
-	// Update status for all networks to populate timestamps
-	for _, net := range networks {
-		// Update in a separate goroutine with timeout to avoid blocking
-		if err := r.updateNetworkStatus(ctx, net); err != nil {
-			// Log error but continue with other networks
-			// Networks will still be cached even if status update fails
+	r.mu.RLock()
+	cached := r.networks
+	r.mu.RUnlock()
+
+	networks := make(map[string]*network.Network, len(discovered))
+	var changed []*network.Network
+	for name, net := range discovered {
+		if kept := cached[name]; kept != nil && sameSequencers(kept, net) {
+			closeUnused(net.Sequencers(), kept.Sequencers())
+			networks[name] = kept
+			continue
 		}
+
+		net = reuseSequencers(net, cached[name])
+		net.SetChangeHandler(r.notify)
+		net.SetEventHandler(r.notifyEvents)
+		net.SetHeadConfig(r.head)
+		net.ContinueFrom(cached[name])
+		networks[name] = net
+		changed = append(changed, net)
 	}
 
+	// Update the status of new and changed networks to populate timestamps.
+	// Networks are cached even if their status update fails.
+	var wg sync.WaitGroup
+	for _, net := range changed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.updateNetworkStatus(ctx, net); err != nil {
+				r.logger.Warn("Failed to update network status",
+					"network", net.Name(), "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
 	r.mu.Lock()
+	previous, discoveredBefore := r.networks, !r.lastDiscovery.IsZero()
 	r.networks = networks
 	r.lastDiscovery = time.Now()
 	r.mu.Unlock()
 
+	var current []*sequencer.Sequencer
+	for _, net := range networks {
+		current = append(current, net.Sequencers()...)
+	}
+	for _, net := range previous {
+		closeUnused(net.Sequencers(), current)
+	}
+
+	// Membership is only reported as changed relative to an earlier discovery
+	if discoveredBefore {
+		r.notifyMemberChanges(previous, networks)
+	}
+
 	return nil
 }
 
+// sameSequencers reports whether two networks consist of the same sequencers
+// with the same configuration, in the same order
+func sameSequencers(a, b *network.Network) bool {
+	as, bs := a.Sequencers(), b.Sequencers()
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i].Config() != bs[i].Config() {
+			return false
+		}
+	}
+	return true
+}
+
+// reuseSequencers returns a discovered network in which the sequencers that
+// were already cached with the same configuration are replaced by the cached
+// ones. The discovered duplicates are closed.
+func reuseSequencers(net, cached *network.Network) *network.Network {
+	if cached == nil {
+		return net
+	}
+
+	sequencers := make([]*sequencer.Sequencer, len(net.Sequencers()))
+	for i, seq := range net.Sequencers() {
+		sequencers[i] = seq
+		if existing := cached.SequencerByID(seq.ID()); existing != nil && existing != seq && existing.Config() == seq.Config() {
+			seq.Close()
+			sequencers[i] = existing
+		}
+	}
+	return network.NewNetwork(net.Name(), sequencers)
+}
+
+// closeUnused closes the sequencers that are not in use
+func closeUnused(sequencers, inUse []*sequencer.Sequencer) {
+	for _, seq := range sequencers {
+		if !slices.Contains(inUse, seq) {
+			seq.Close()
+		}
+	}
+}
+
+// notifyMemberChanges reports sequencers that joined or left a network
+// between two discoveries
+func (r *CachedNetworkRepository) notifyMemberChanges(previous, current map[string]*network.Network) {
+	now := time.Now()
+	for name, net := range current {
+		if events := network.MemberEvents(previous[name], net, now); len(events) > 0 {
+			r.notifyEvents(net, events)
+		}
+	}
+	for name, net := range previous {
+		if _, ok := current[name]; !ok {
+			if events := network.MemberEvents(net, nil, now); len(events) > 0 {
+				r.notifyEvents(net, events)
+			}
+		}
+	}
+}
+
+// Subscribe registers a handler notified whenever a network update
+// changes the status of its sequencers
+func (r *CachedNetworkRepository) Subscribe(handler network.ChangeHandler) {
+	r.mu.Lock()
+	r.handlers = append(r.handlers, handler)
+	r.mu.Unlock()
+}
+
+// notify fans a network's status changes out to all subscribers
+func (r *CachedNetworkRepository) notify(net *network.Network, changes []network.StatusChange) {
+	r.mu.RLock()
+	handlers := make([]network.ChangeHandler, len(r.handlers))
+	copy(handlers, r.handlers)
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(net, changes)
+	}
+}
+
+// SubscribeEvents registers a handler notified of the events detected by
+// network updates and rediscovery
+func (r *CachedNetworkRepository) SubscribeEvents(handler network.EventHandler) {
+	r.mu.Lock()
+	r.eventHandlers = append(r.eventHandlers, handler)
+	r.mu.Unlock()
+}
+
+// notifyEvents fans a network's events out to all subscribers
+func (r *CachedNetworkRepository) notifyEvents(net *network.Network, events []network.Event) {
+	r.mu.RLock()
+	handlers := make([]network.EventHandler, len(r.eventHandlers))
+	copy(handlers, r.eventHandlers)
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(net, events)
+	}
+}
+
 // InvalidateNetwork removes a specific network from cache
 func (r *CachedNetworkRepository) InvalidateNetwork(name string) {
 	r.mu.Lock()
@@ -188,13 +409,28 @@ func (r *CachedNetworkRepository) shouldUpdateStatus(net *network.Network) bool
 	return time.Since(net.LastUpdateTime()) > r.statusTTL
 }
 
-// updateNetworkStatus updates a single network's status
+// updateNetworkStatus updates a single network's status.
+// Concurrent updates of the same network share a single round of RPCs.
 func (r *CachedNetworkRepository) updateNetworkStatus(ctx context.Context, net *network.Network) error {
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+	return r.shared(ctx, net.Name(), statusTimeout, net.Update)
+}
+
+// shared runs fn once for the concurrent callers with the same key. fn runs
+// without the cancellation of the caller that started it, bounded by timeout
+// instead, so that one caller going away does not fail the others; each
+// caller stops waiting when its own ctx is done.
+func (r *CachedNetworkRepository) shared(ctx context.Context, key string, timeout time.Duration, fn func(context.Context) error) error {
+	result := r.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
 		defer cancel()
-	}
 
-	return net.Update(ctx)
+		return nil, fn(ctx)
+	})
+
+	select {
+	case res := <-result:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
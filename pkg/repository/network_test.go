@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// testProvider discovers the members of a cluster as network devnet. Like the
+// Kubernetes provider in list mode, it creates new sequencers on every
+// discovery.
+type testProvider struct {
+	t       *testing.T
+	cluster *conductortest.Cluster
+
+	mu      sync.Mutex
+	voting  map[string]bool
+	release chan struct{} // Discoveries wait for it to be closed when set
+
+	discoveries atomic.Int32
+}
+
+func (p *testProvider) Name() string {
+	return "test"
+}
+
+func (p *testProvider) DiscoverNetworks(ctx context.Context) (map[string]*network.Network, error) {
+	p.discoveries.Add(1)
+
+	p.mu.Lock()
+	voting, release := maps.Clone(p.voting), p.release
+	p.mu.Unlock()
+
+	if release != nil {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return map[string]*network.Network{
+		"devnet": network.NewNetwork("devnet", p.cluster.Sequencers(p.t, voting)),
+	}, nil
+}
+
+func newTestRepository(p *testProvider, statusTTL time.Duration) *CachedNetworkRepository {
+	return NewCachedNetworkRepository(p, time.Hour, statusTTL, network.HeadConfig{
+		StallBlocks:    network.DefaultStallBlocks,
+		MaxFollowerLag: network.DefaultMaxFollowerLag,
+	})
+}
+
+func TestRefreshCache_KeepsUnchanged(t *testing.T) {
+	p := &testProvider{
+		t:       t,
+		cluster: &conductortest.Cluster{Leader: "sequencer-0"},
+		voting:  conductortest.Voters("sequencer-0", "sequencer-1"),
+	}
+	repo := newTestRepository(p, time.Hour)
+
+	var changes atomic.Int32
+	repo.Subscribe(func(*network.Network, []network.StatusChange) {
+		changes.Add(1)
+	})
+
+	if err := repo.RefreshCache(context.Background()); err != nil {
+		t.Fatalf("RefreshCache() = %v", err)
+	}
+	first, err := repo.GetNetwork(context.Background(), "devnet")
+	if err != nil {
+		t.Fatalf("GetNetwork() = %v", err)
+	}
+	if changes.Load() != 1 {
+		t.Fatalf("%d change notifications after the first discovery, want 1", changes.Load())
+	}
+
+	// Rediscovering the same members keeps the network and its status
+	if err := repo.RefreshCache(context.Background()); err != nil {
+		t.Fatalf("RefreshCache() = %v", err)
+	}
+	second, _ := repo.GetNetwork(context.Background(), "devnet")
+	if second != first {
+		t.Error("Rediscovery replaced an unchanged network")
+	}
+	if changes.Load() != 1 {
+		t.Errorf("%d change notifications after an unchanged rediscovery, want 1", changes.Load())
+	}
+
+	// A changed member is replaced while the other one is kept
+	p.mu.Lock()
+	p.voting["sequencer-1"] = false
+	p.mu.Unlock()
+
+	if err := repo.RefreshCache(context.Background()); err != nil {
+		t.Fatalf("RefreshCache() = %v", err)
+	}
+	third, _ := repo.GetNetwork(context.Background(), "devnet")
+	if third == first {
+		t.Fatal("Rediscovery kept a network whose members changed")
+	}
+	if third.SequencerByID("sequencer-0") != first.SequencerByID("sequencer-0") {
+		t.Error("Rediscovery replaced an unchanged sequencer")
+	}
+	if seq := third.SequencerByID("sequencer-1"); seq == first.SequencerByID("sequencer-1") || seq.Voting() {
+		t.Error("Rediscovery kept a sequencer whose configuration changed")
+	}
+	if third.ConductorLeader() == nil {
+		t.Error("Kept sequencer lost its status")
+	}
+}
+
+func TestRefreshCache_SharesDiscovery(t *testing.T) {
+	release := make(chan struct{})
+	p := &testProvider{
+		t:       t,
+		cluster: &conductortest.Cluster{Leader: "sequencer-0"},
+		voting:  conductortest.Voters("sequencer-0"),
+		release: release,
+	}
+	repo := newTestRepository(p, time.Hour)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := repo.RefreshCache(context.Background()); err != nil {
+				t.Errorf("RefreshCache() = %v", err)
+			}
+		}()
+	}
+
+	// Let the callers pile up on the first discovery before releasing it
+	deadline := time.Now().Add(5 * time.Second)
+	for p.discoveries.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := p.discoveries.Load(); got != 1 {
+		t.Errorf("%d discoveries for concurrent refreshes, want 1", got)
+	}
+}
+
+func TestRefreshCache_OutlivesCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	p := &testProvider{
+		t:       t,
+		cluster: &conductortest.Cluster{Leader: "sequencer-0"},
+		voting:  conductortest.Voters("sequencer-0"),
+		release: release,
+	}
+	repo := newTestRepository(p, time.Hour)
+
+	// The first caller starts the discovery and goes away while it runs
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		first <- repo.RefreshCache(ctx)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for p.discoveries.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan error, 1)
+	go func() {
+		second <- repo.RefreshCache(context.Background())
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-first:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("RefreshCache() of the canceled caller = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Canceled caller kept waiting for the discovery")
+	}
+
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("RefreshCache() of the other caller = %v, want the shared discovery to succeed", err)
+	}
+	if got := p.discoveries.Load(); got != 1 {
+		t.Errorf("%d discoveries, want the one shared by both callers", got)
+	}
+	if _, err := repo.GetNetwork(context.Background(), "devnet"); err != nil {
+		t.Errorf("GetNetwork() = %v, want devnet discovered", err)
+	}
+}
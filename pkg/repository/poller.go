@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/provider"
+)
+
+const (
+	// pollJitter is the fraction by which poll intervals are randomly varied so
+	// that networks do not hit their conductors in lockstep
+	pollJitter = 0.1
+
+	// watchDebounce delays rediscovery after a provider event so that a burst
+	// of changes, such as a rolling restart, triggers a single refresh
+	watchDebounce = 500 * time.Millisecond
+
+	// watchMaxDelay bounds how long a steady stream of provider events can
+	// postpone rediscovery
+	watchMaxDelay = 2 * time.Second
+
+	// watchBufferSize is the capacity of the provider event channel
+	watchBufferSize = 64
+)
+
+// Run polls network discovery and status in the background until ctx is cancelled.
+//
+// Discovery is refreshed every discovery TTL and each network's status every
+// status TTL, both with jitter. While Run is active GetNetwork and ListNetworks
+// serve the latest snapshot without refreshing inline.
+//
+// If the provider implements provider.Watcher, its events additionally trigger
+// a debounced rediscovery so that changes are picked up without waiting for
+// the discovery TTL. Rediscovery happens at most watchMaxDelay after the first
+// event of a burst.
+func (r *CachedNetworkRepository) Run(ctx context.Context) error {
+	r.polling.Store(true)
+	defer r.polling.Store(false)
+
+	r.logger.Info("Starting background poller",
+		"discovery_ttl", r.discoveryTTL,
+		"status_ttl", r.statusTTL)
+
+	var wg sync.WaitGroup
+	pollers := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range pollers {
+			cancel()
+		}
+		wg.Wait()
+		r.logger.Info("Background poller stopped")
+	}()
+
+	events := r.watch(ctx, &wg)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	// Rediscovery is due by this time once an event arrived, however many follow
+	var deadline time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-events:
+			r.logger.Debug("Provider event received",
+				"type", event.Type, "network", event.Network, "resource", event.Resource)
+			if deadline.IsZero() {
+				deadline = time.Now().Add(watchMaxDelay)
+			}
+			timer.Reset(min(watchDebounce, time.Until(deadline)))
+			continue
+		case <-timer.C:
+		}
+		deadline = time.Time{}
+
+		r.supervise("discovery", func() error {
+			return r.RefreshCache(ctx)
+		})
+		r.syncPollers(ctx, pollers, &wg)
+
+		timer.Reset(jitter(r.discoveryTTL))
+	}
+}
+
+// watch starts the provider watcher if the provider supports one. The returned
+// channel is nil, and therefore never ready, otherwise.
+func (r *CachedNetworkRepository) watch(ctx context.Context, wg *sync.WaitGroup) <-chan provider.Event {
+	watcher, ok := r.provider.(provider.Watcher)
+	if !ok {
+		return nil
+	}
+
+	events := make(chan provider.Event, watchBufferSize)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := watcher.Watch(ctx, events); err != nil {
+			r.logger.Error("Provider watch stopped, falling back to periodic discovery", "error", err)
+		}
+	}()
+
+	return events
+}
+
+// syncPollers starts pollers for newly discovered networks and stops pollers
+// for networks that have disappeared
+func (r *CachedNetworkRepository) syncPollers(ctx context.Context, pollers map[string]context.CancelFunc, wg *sync.WaitGroup) {
+	r.mu.RLock()
+	current := make(map[string]struct{}, len(r.networks))
+	for name := range r.networks {
+		current[name] = struct{}{}
+	}
+	r.mu.RUnlock()
+
+	for name, cancel := range pollers {
+		if _, ok := current[name]; !ok {
+			r.logger.Debug("Stopping status poller", "network", name)
+			cancel()
+			delete(pollers, name)
+		}
+	}
+
+	for name := range current {
+		if _, ok := pollers[name]; ok {
+			continue
+		}
+
+		r.logger.Debug("Starting status poller", "network", name)
+		pollCtx, cancel := context.WithCancel(ctx)
+		pollers[name] = cancel
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.pollNetwork(pollCtx, name)
+		}()
+	}
+}
+
+// pollNetwork refreshes a single network's status on its own schedule
+func (r *CachedNetworkRepository) pollNetwork(ctx context.Context, name string) {
+	// Spread the first poll of each network across the status interval
+	timer := time.NewTimer(time.Duration(rand.Int64N(int64(r.statusTTL))))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		r.mu.RLock()
+		net, ok := r.networks[name]
+		r.mu.RUnlock()
+
+		if ok {
+			r.supervise("status", func() error {
+				return r.updateNetworkStatus(ctx, net)
+			})
+		}
+
+		timer.Reset(jitter(r.statusTTL))
+	}
+}
+
+// supervise runs a poll step, logging failures and recovering from panics so
+// that a single misbehaving network cannot take the poller down
+func (r *CachedNetworkRepository) supervise(step string, fn func() error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			r.logger.Error("Poller step panicked", "step", step, "panic", fmt.Sprint(rec))
+		}
+	}()
+
+	if err := fn(); err != nil {
+		r.logger.Warn("Poller step failed", "step", step, "error", err)
+	}
+}
+
+// snapshotNetwork returns a cached network without refreshing it
+func (r *CachedNetworkRepository) snapshotNetwork(ctx context.Context, name string) (*network.Network, error) {
+	if err := r.awaitFirstDiscovery(ctx); err != nil {
+		return nil, fmt.Errorf("failed to discover networks: %w", err)
+	}
+
+	r.mu.RLock()
+	net, exists := r.networks[name]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("network %s not found", name)
+	}
+	return net, nil
+}
+
+// snapshotNetworks returns all cached networks without refreshing them
+func (r *CachedNetworkRepository) snapshotNetworks(ctx context.Context) (map[string]*network.Network, error) {
+	if err := r.awaitFirstDiscovery(ctx); err != nil {
+		return nil, fmt.Errorf("failed to discover networks and cache is empty: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*network.Network, len(r.networks))
+	for name, net := range r.networks {
+		result[name] = net
+	}
+	return result, nil
+}
+
+// awaitFirstDiscovery joins the initial discovery if it has not completed yet
+func (r *CachedNetworkRepository) awaitFirstDiscovery(ctx context.Context) error {
+	r.mu.RLock()
+	discovered := !r.lastDiscovery.IsZero()
+	r.mu.RUnlock()
+
+	if discovered {
+		return nil
+	}
+	return r.RefreshCache(ctx)
+}
+
+// jitter randomly varies an interval by up to pollJitter in either direction
+func jitter(d time.Duration) time.Duration {
+	delta := float64(d) * pollJitter
+	return d + time.Duration((rand.Float64()*2-1)*delta)
+}
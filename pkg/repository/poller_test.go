@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/provider"
+)
+
+// eventually fails the test unless cond holds within a few seconds
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRun_PollsStatus(t *testing.T) {
+	c := &conductortest.Cluster{Leader: "sequencer-0"}
+	p := &testProvider{t: t, cluster: c, voting: conductortest.Voters("sequencer-0", "sequencer-1")}
+	repo := newTestRepository(p, 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- repo.Run(ctx)
+	}()
+
+	leader := func() string {
+		net, err := repo.GetNetwork(ctx, "devnet")
+		if err != nil || net.ConductorLeader() == nil {
+			return ""
+		}
+		return net.ConductorLeader().ID()
+	}
+	eventually(t, func() bool { return leader() == "sequencer-0" }, "Poller never reported sequencer-0 as leader")
+
+	// Reads serve the snapshot while the poller picks up the new leader
+	c.Lock()
+	c.Leader = "sequencer-1"
+	c.Unlock()
+	eventually(t, func() bool { return leader() == "sequencer-1" }, "Poller never picked up the new leader")
+
+	if got := p.discoveries.Load(); got != 1 {
+		t.Errorf("%d discoveries within the discovery TTL, want 1", got)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not stop after cancellation")
+	}
+}
+
+func TestNewCachedNetworkRepository_DefaultsInvalidTTL(t *testing.T) {
+	repo := NewCachedNetworkRepository(&testProvider{}, -time.Second, 0, network.HeadConfig{})
+	if repo.discoveryTTL <= 0 || repo.statusTTL <= 0 {
+		t.Errorf("TTLs = %s, %s, want positive defaults", repo.discoveryTTL, repo.statusTTL)
+	}
+}
+
+// watchingProvider reports a change every few milliseconds, faster than the
+// watch debounce
+type watchingProvider struct {
+	*testProvider
+}
+
+func (p watchingProvider) Watch(ctx context.Context, events chan<- provider.Event) error {
+	ticker := time.NewTicker(watchDebounce / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			select {
+			case events <- provider.Event{Type: provider.EventUpdated, Network: "devnet"}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+func TestRun_WatchMaxDelay(t *testing.T) {
+	p := &testProvider{t: t, cluster: &conductortest.Cluster{Leader: "sequencer-0"}, voting: conductortest.Voters("sequencer-0")}
+	repo := NewCachedNetworkRepository(watchingProvider{p}, time.Hour, time.Hour, network.HeadConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go repo.Run(ctx)
+
+	// Events keep postponing the debounce, but not past the max delay
+	start := time.Now()
+	eventually(t, func() bool { return p.discoveries.Load() >= 2 }, "Steady provider events postponed rediscovery")
+	if elapsed := time.Since(start); elapsed < watchMaxDelay/2 {
+		t.Errorf("Rediscovered after %s, want the debounce to absorb events for about %s", elapsed, watchMaxDelay)
+	}
+}
@@ -16,6 +16,21 @@ import (
 	seqrpc "github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
+// Methods that change a conductor or node, shared with the dry runs that
+// describe them
+const (
+	MethodPause                  = "conductor_pause"
+	MethodResume                 = "conductor_resume"
+	MethodTransferLeader         = "conductor_transferLeader"
+	MethodTransferLeaderToServer = "conductor_transferLeaderToServer"
+	MethodOverrideLeader         = "conductor_overrideLeader"
+	MethodAddServerAsVoter       = "conductor_addServerAsVoter"
+	MethodAddServerAsNonvoter    = "conductor_addServerAsNonvoter"
+	MethodRemoveServer           = "conductor_removeServer"
+	MethodStopSequencer          = "admin_stopSequencer"
+	MethodStartSequencer         = "admin_startSequencer"
+)
+
 // Client provides a unified interface for conductor and node RPC operations
 type Client struct {
 	conductorURL string
@@ -123,156 +138,149 @@ func (c *Client) withTimeout(ctx context.Context) (context.Context, context.Canc
 	return context.WithTimeout(ctx, c.timeout)
 }
 
+// observe runs an RPC call with the client timeout and records its latency
+// and outcome
+func observe[T any](ctx context.Context, c *Client, endpoint, method string, call func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	result, err := call(ctx)
+	recordCall(endpoint, method, time.Since(start), err)
+
+	return result, err
+}
+
+// observeErr runs an RPC call that returns no result, see observe
+func (c *Client) observeErr(ctx context.Context, endpoint, method string, call func(context.Context) error) error {
+	_, err := observe(ctx, c, endpoint, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, call(ctx)
+	})
+	return err
+}
+
 // --- Conductor Status Methods ---
 
 // Active returns whether the conductor is active
 func (c *Client) Active(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Active(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_active", c.conductor.Active)
 }
 
 // Leader returns whether the conductor is the leader
 func (c *Client) Leader(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Leader(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_leader", c.conductor.Leader)
 }
 
 // Paused returns whether the conductor is paused
 func (c *Client) Paused(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Paused(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_paused", c.conductor.Paused)
 }
 
 // Stopped returns whether the conductor is stopped
 func (c *Client) Stopped(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Stopped(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_stopped", c.conductor.Stopped)
 }
 
 // SequencerHealthy returns whether the sequencer is healthy
 func (c *Client) SequencerHealthy(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.SequencerHealthy(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_sequencerHealthy", c.conductor.SequencerHealthy)
 }
 
 // --- Conductor Control Methods ---
 
 // Pause pauses the conductor
 func (c *Client) Pause(ctx context.Context) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Pause(ctx)
+	return c.observeErr(ctx, EndpointConductor, MethodPause, c.conductor.Pause)
 }
 
 // Resume resumes the conductor
 func (c *Client) Resume(ctx context.Context) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.Resume(ctx)
+	return c.observeErr(ctx, EndpointConductor, MethodResume, c.conductor.Resume)
 }
 
 // --- Conductor Leadership Methods ---
 
 // TransferLeader transfers leadership to another node
 func (c *Client) TransferLeader(ctx context.Context) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.TransferLeader(ctx)
+	return c.observeErr(ctx, EndpointConductor, MethodTransferLeader, c.conductor.TransferLeader)
 }
 
 // TransferLeaderToServer transfers leadership to a specific server
 func (c *Client) TransferLeaderToServer(ctx context.Context, id, addr string) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.TransferLeaderToServer(ctx, id, addr)
+	return c.observeErr(ctx, EndpointConductor, MethodTransferLeaderToServer, func(ctx context.Context) error {
+		return c.conductor.TransferLeaderToServer(ctx, id, addr)
+	})
 }
 
 // OverrideLeader overrides the leader status
 func (c *Client) OverrideLeader(ctx context.Context, override bool) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.OverrideLeader(ctx, override)
+	return c.observeErr(ctx, EndpointConductor, MethodOverrideLeader, func(ctx context.Context) error {
+		return c.conductor.OverrideLeader(ctx, override)
+	})
 }
 
 // LeaderWithID returns the current leader's server info
 func (c *Client) LeaderWithID(ctx context.Context) (*consensus.ServerInfo, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.LeaderWithID(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_leaderWithID", c.conductor.LeaderWithID)
 }
 
 // --- Conductor Cluster Management Methods ---
 
 // ClusterMembership returns the current cluster membership
 func (c *Client) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.ClusterMembership(ctx)
+	return observe(ctx, c, EndpointConductor, "conductor_clusterMembership", c.conductor.ClusterMembership)
 }
 
 // AddServerAsVoter adds a server as a voting member
 func (c *Client) AddServerAsVoter(ctx context.Context, id, addr string, prevIndex uint64) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.AddServerAsVoter(ctx, id, addr, prevIndex)
+	return c.observeErr(ctx, EndpointConductor, MethodAddServerAsVoter, func(ctx context.Context) error {
+		return c.conductor.AddServerAsVoter(ctx, id, addr, prevIndex)
+	})
 }
 
 // AddServerAsNonvoter adds a server as a non-voting member
 func (c *Client) AddServerAsNonvoter(ctx context.Context, id, addr string, prevIndex uint64) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.AddServerAsNonvoter(ctx, id, addr, prevIndex)
+	return c.observeErr(ctx, EndpointConductor, MethodAddServerAsNonvoter, func(ctx context.Context) error {
+		return c.conductor.AddServerAsNonvoter(ctx, id, addr, prevIndex)
+	})
 }
 
 // RemoveServer removes a server from the cluster
 func (c *Client) RemoveServer(ctx context.Context, id string, prevIndex uint64) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.conductor.RemoveServer(ctx, id, prevIndex)
+	return c.observeErr(ctx, EndpointConductor, MethodRemoveServer, func(ctx context.Context) error {
+		return c.conductor.RemoveServer(ctx, id, prevIndex)
+	})
 }
 
 // --- Node Status Methods ---
 
 // SequencerActive returns whether the sequencer is active
 func (c *Client) SequencerActive(ctx context.Context) (bool, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.sequencer.SequencerActive(ctx)
+	return observe(ctx, c, EndpointNode, "admin_sequencerActive", c.sequencer.SequencerActive)
 }
 
 // SyncStatus returns the sync status of the node
 func (c *Client) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.sequencer.SyncStatus(ctx)
+	return observe(ctx, c, EndpointNode, "optimism_syncStatus", c.sequencer.SyncStatus)
 }
 
 // --- Node Control Methods ---
 
 // StopSequencer stops the sequencer and returns the stop hash
 func (c *Client) StopSequencer(ctx context.Context) (common.Hash, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.sequencer.StopSequencer(ctx)
+	return observe(ctx, c, EndpointNode, MethodStopSequencer, c.sequencer.StopSequencer)
 }
 
 // StartSequencer starts the sequencer with the given hash
 func (c *Client) StartSequencer(ctx context.Context, hash common.Hash) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.sequencer.StartSequencer(ctx, hash)
+	return c.observeErr(ctx, EndpointNode, MethodStartSequencer, func(ctx context.Context) error {
+		return c.sequencer.StartSequencer(ctx, hash)
+	})
 }
 
 // OverrideNodeLeader overrides the node's leader status
 func (c *Client) OverrideNodeLeader(ctx context.Context) error {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-	return c.sequencer.OverrideLeader(ctx)
+	return c.observeErr(ctx, EndpointNode, "admin_overrideLeader", c.sequencer.OverrideLeader)
 }
 
 // Close closes the client connections
@@ -286,5 +294,9 @@ func (c *Client) Close() error {
 	if c.sequencer != nil {
 		c.sequencer.Close()
 	}
+	// Closing an HTTP RPC client does not release its connections
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
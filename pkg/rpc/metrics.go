@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Endpoints an RPC call can be sent to
+const (
+	EndpointConductor = "conductor"
+	EndpointNode      = "node"
+)
+
+var (
+	callDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "seqctl",
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of RPC calls to conductors and nodes.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "method"})
+
+	callErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seqctl",
+		Subsystem: "rpc",
+		Name:      "errors_total",
+		Help:      "RPC calls to conductors and nodes that returned an error.",
+	}, []string{"endpoint", "method"})
+)
+
+// Collectors returns the Prometheus collectors for RPC call metrics
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{callDuration, callErrors}
+}
+
+// recordCall records the latency and outcome of an RPC call
+func recordCall(endpoint, method string, duration time.Duration, err error) {
+	callDuration.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+	if err != nil {
+		callErrors.WithLabelValues(endpoint, method).Inc()
+	}
+}
@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -9,7 +10,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -26,9 +26,219 @@ type Status struct {
 	SequencerHealthy bool
 	SequencerActive  bool
 	UnsafeL2         *eth.L2BlockRef
+	SafeL2           *eth.L2BlockRef
+	FinalizedL2      *eth.L2BlockRef
+	CurrentL1        *eth.L1BlockRef // L1 block the derivation pipeline has reached
+	HeadL1           *eth.L1BlockRef
+	SafeL1           *eth.L1BlockRef
+	FinalizedL1      *eth.L1BlockRef
+	Checks           map[Check]CheckResult // Outcome of each probe of the last update
 	LastUpdateTime   time.Time
 }
 
+// Check names one of the probes run by Update
+type Check string
+
+// Probes run by Update
+const (
+	CheckConductorActive  Check = "conductor_active"
+	CheckConductorLeader  Check = "conductor_leader"
+	CheckConductorPaused  Check = "conductor_paused"
+	CheckConductorStopped Check = "conductor_stopped"
+	CheckSequencerHealthy Check = "sequencer_healthy"
+	CheckSequencerActive  Check = "sequencer_active"
+	CheckSyncStatus       Check = "sync_status"
+)
+
+// Checks lists every probe in the order they are reported
+var Checks = []Check{
+	CheckConductorActive,
+	CheckConductorLeader,
+	CheckConductorPaused,
+	CheckConductorStopped,
+	CheckSequencerHealthy,
+	CheckSequencerActive,
+	CheckSyncStatus,
+}
+
+// CheckResult is the outcome of a probe. When a probe fails the status keeps
+// the value of its last successful run.
+type CheckResult struct {
+	Err         error
+	CheckedAt   time.Time
+	SucceededAt time.Time // Zero if the probe never succeeded
+}
+
+// OK reports whether the last run of the probe succeeded
+func (r CheckResult) OK() bool {
+	return r.Err == nil && !r.CheckedAt.IsZero()
+}
+
+// Stale reports whether the probe failed but an earlier value is still shown
+func (r CheckResult) Stale() bool {
+	return r.Err != nil && !r.SucceededAt.IsZero()
+}
+
+// Reachability summarizes how many probes of a sequencer succeeded
+type Reachability string
+
+// Reachability values
+const (
+	ReachabilityUnknown     Reachability = "unknown"     // Never updated
+	ReachabilityReachable   Reachability = "reachable"   // Every probe succeeded
+	ReachabilityDegraded    Reachability = "degraded"    // Some probes failed
+	ReachabilityUnreachable Reachability = "unreachable" // Every probe failed
+)
+
+// Reachability summarizes the probes of the last update
+func (s Status) Reachability() Reachability {
+	if len(s.Checks) == 0 {
+		return ReachabilityUnknown
+	}
+
+	failed := len(s.Failing())
+	switch {
+	case failed == 0:
+		return ReachabilityReachable
+	case failed == len(s.Checks):
+		return ReachabilityUnreachable
+	default:
+		return ReachabilityDegraded
+	}
+}
+
+// FetchedAt returns the time since which every value of the status has been
+// fetched successfully at least once, or zero if some value never was. It
+// falls back to LastUpdateTime for statuses without probe results.
+func (s Status) FetchedAt() time.Time {
+	if len(s.Checks) == 0 {
+		return s.LastUpdateTime
+	}
+
+	var fetched time.Time
+	for _, check := range Checks {
+		succeeded := s.Checks[check].SucceededAt
+		if succeeded.IsZero() {
+			return time.Time{}
+		}
+		if fetched.IsZero() || succeeded.Before(fetched) {
+			fetched = succeeded
+		}
+	}
+	return fetched
+}
+
+// KnownLeader reports whether the conductor reported leadership in its last
+// leader probe. A leadership kept from before a failed probe is unknown, the
+// node may have lost it while unreachable.
+func (s Status) KnownLeader() bool {
+	return s.ConductorLeader && s.Checks[CheckConductorLeader].Err == nil
+}
+
+// KnownActive reports whether the node reported sequencing in its last
+// sequencer active probe. Like KnownLeader it ignores a value kept from before
+// a failed probe.
+func (s Status) KnownActive() bool {
+	return s.SequencerActive && s.Checks[CheckSequencerActive].Err == nil
+}
+
+// Failing returns the probes that failed in the last update
+func (s Status) Failing() []Check {
+	var failing []Check
+	for _, check := range Checks {
+		if result, ok := s.Checks[check]; ok && result.Err != nil {
+			failing = append(failing, check)
+		}
+	}
+	return failing
+}
+
+// Equal reports whether two statuses describe the same sequencer state,
+// ignoring the time at which they were fetched and the text of probe errors
+func (s Status) Equal(other Status) bool {
+	if s.ConductorActive != other.ConductorActive ||
+		s.ConductorLeader != other.ConductorLeader ||
+		s.ConductorPaused != other.ConductorPaused ||
+		s.ConductorStopped != other.ConductorStopped ||
+		s.SequencerHealthy != other.SequencerHealthy ||
+		s.SequencerActive != other.SequencerActive {
+		return false
+	}
+
+	for _, check := range Checks {
+		if (s.Checks[check].Err == nil) != (other.Checks[check].Err == nil) {
+			return false
+		}
+	}
+
+	return refEqual(s.UnsafeL2, other.UnsafeL2) &&
+		refEqual(s.SafeL2, other.SafeL2) &&
+		refEqual(s.FinalizedL2, other.FinalizedL2) &&
+		refEqual(s.CurrentL1, other.CurrentL1) &&
+		refEqual(s.HeadL1, other.HeadL1) &&
+		refEqual(s.SafeL1, other.SafeL1) &&
+		refEqual(s.FinalizedL1, other.FinalizedL1)
+}
+
+// UnsafeSafeGap returns how many blocks the unsafe head is ahead of the safe
+// head. It grows while the batcher is not getting batches onto L1.
+func (s Status) UnsafeSafeGap() (uint64, bool) {
+	if s.UnsafeL2 == nil || s.SafeL2 == nil {
+		return 0, false
+	}
+	if s.UnsafeL2.Number < s.SafeL2.Number {
+		return 0, true
+	}
+	return s.UnsafeL2.Number - s.SafeL2.Number, true
+}
+
+// SafeFinalizedGap returns how many blocks the safe head is ahead of the
+// finalized head
+func (s Status) SafeFinalizedGap() (uint64, bool) {
+	if s.SafeL2 == nil || s.FinalizedL2 == nil {
+		return 0, false
+	}
+	if s.SafeL2.Number < s.FinalizedL2.Number {
+		return 0, true
+	}
+	return s.SafeL2.Number - s.FinalizedL2.Number, true
+}
+
+// L1OriginAge returns the age of the L1 block the derivation pipeline has
+// reached, as of the time the sync status was fetched. It grows while
+// derivation is stalled.
+func (s Status) L1OriginAge() (time.Duration, bool) {
+	fetched := s.LastUpdateTime
+	if result, ok := s.Checks[CheckSyncStatus]; ok {
+		fetched = result.SucceededAt
+	}
+	if s.CurrentL1 == nil || s.CurrentL1.Time == 0 || fetched.IsZero() {
+		return 0, false
+	}
+	age := fetched.Sub(time.Unix(int64(s.CurrentL1.Time), 0))
+	return max(age, 0), true
+}
+
+// L1DerivationLag returns how many blocks the derivation pipeline trails the
+// L1 head
+func (s Status) L1DerivationLag() (uint64, bool) {
+	if s.HeadL1 == nil || s.CurrentL1 == nil {
+		return 0, false
+	}
+	if s.HeadL1.Number < s.CurrentL1.Number {
+		return 0, true
+	}
+	return s.HeadL1.Number - s.CurrentL1.Number, true
+}
+
+// refEqual compares two optional block references
+func refEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // Config holds the configuration for a sequencer
 type Config struct {
 	ID           string
@@ -54,6 +264,7 @@ type Sequencer struct {
 	mu            sync.Mutex
 	lastError     error
 	lastErrorTime time.Time
+	closed        bool
 }
 
 // New creates a new initialized sequencer instance
@@ -80,116 +291,138 @@ func New(ctx context.Context, cfg Config, rpcOpts ...rpc.ClientOption) (*Sequenc
 	return s, nil
 }
 
-// Update fetches the current status of the sequencer
+// Update fetches the current status of the sequencer. Every probe runs on
+// its own: a failed probe keeps its last known value and is recorded in
+// Status.Checks, while the others are still updated. The returned error joins
+// the errors of all failed probes.
 func (s *Sequencer) Update(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	slog.Debug("Updating sequencer status", "sequencer", s.config.ID)
 
-	// Fetch status concurrently
-	g, ctx := errgroup.WithContext(ctx)
+	// Start from the previous status so failed probes keep their values
+	previous := s.Status()
+	status := previous
+	status.Checks = make(map[Check]CheckResult, len(Checks))
+
+	var (
+		wg      sync.WaitGroup
+		checkMu sync.Mutex
+	)
+
+	// probe runs fetch concurrently and records its outcome. fetch only
+	// stores its value on success.
+	probe := func(check Check, fetch func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := fetch()
+			result := CheckResult{CheckedAt: time.Now(), SucceededAt: previous.Checks[check].SucceededAt}
+			if err != nil {
+				slog.Debug("Sequencer check failed",
+					"sequencer", s.config.ID,
+					"check", check,
+					"error", err)
+				result.Err = fmt.Errorf("%s check failed for sequencer %s: %w", check, s.config.ID, err)
+			} else {
+				result.SucceededAt = result.CheckedAt
+			}
+
+			checkMu.Lock()
+			status.Checks[check] = result
+			checkMu.Unlock()
+		}()
+	}
 
-	var status Status
-	g.Go(func() error {
+	probe(CheckConductorActive, func() error {
 		active, err := s.client.Active(ctx)
-		if err != nil {
-			slog.Debug("Conductor active check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("conductor active check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.ConductorActive = active
 		}
-		status.ConductorActive = active
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckConductorLeader, func() error {
 		leader, err := s.client.Leader(ctx)
-		if err != nil {
-			slog.Debug("Conductor leader check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("conductor leader check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.ConductorLeader = leader
 		}
-		status.ConductorLeader = leader
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckConductorPaused, func() error {
 		paused, err := s.client.Paused(ctx)
-		if err != nil {
-			slog.Debug("Conductor paused check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("conductor paused check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.ConductorPaused = paused
 		}
-		status.ConductorPaused = paused
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckConductorStopped, func() error {
 		stopped, err := s.client.Stopped(ctx)
-		if err != nil {
-			slog.Debug("Conductor stopped check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("conductor stopped check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.ConductorStopped = stopped
 		}
-		status.ConductorStopped = stopped
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckSequencerHealthy, func() error {
 		healthy, err := s.client.SequencerHealthy(ctx)
-		if err != nil {
-			slog.Debug("Sequencer healthy check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("sequencer healthy check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.SequencerHealthy = healthy
 		}
-		status.SequencerHealthy = healthy
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckSequencerActive, func() error {
 		active, err := s.client.SequencerActive(ctx)
-		if err != nil {
-			slog.Debug("Sequencer active check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("sequencer active check failed for sequencer %s: %w", s.config.ID, err)
+		if err == nil {
+			status.SequencerActive = active
 		}
-		status.SequencerActive = active
-		return nil
+		return err
 	})
 
-	g.Go(func() error {
+	probe(CheckSyncStatus, func() error {
 		syncStatus, err := s.client.SyncStatus(ctx)
 		if err != nil {
-			slog.Debug("Sync status check failed",
-				"sequencer", s.config.ID,
-				"error", err)
-			return fmt.Errorf("sync status check failed for sequencer %s: %w", s.config.ID, err)
+			return err
 		}
 
 		if syncStatus != nil {
 			status.UnsafeL2 = &syncStatus.UnsafeL2
+			status.SafeL2 = &syncStatus.SafeL2
+			status.FinalizedL2 = &syncStatus.FinalizedL2
+			status.CurrentL1 = &syncStatus.CurrentL1
+			status.HeadL1 = &syncStatus.HeadL1
+			status.SafeL1 = &syncStatus.SafeL1
+			status.FinalizedL1 = &syncStatus.FinalizedL1
 		}
 		return nil
 	})
 
-	if err := g.Wait(); err != nil {
+	wg.Wait()
+
+	// Store whatever was fetched, even if some probes failed
+	status.LastUpdateTime = time.Now()
+	s.status.Store(&status)
+
+	var errs []error
+	for _, check := range status.Failing() {
+		errs = append(errs, status.Checks[check].Err)
+	}
+	if err := errors.Join(errs...); err != nil {
 		s.lastError = err
-		s.lastErrorTime = time.Now()
+		s.lastErrorTime = status.LastUpdateTime
 		slog.Error("Failed to update sequencer status",
 			"sequencer", s.config.ID,
+			"reachability", status.Reachability(),
+			"failing", status.Failing(),
 			"error", err)
 		return err
 	}
 
-	// Update status and track update time
-	status.LastUpdateTime = time.Now()
-	s.status.Store(&status)
 	s.lastError = nil
 	s.lastErrorTime = time.Time{}
 
@@ -229,6 +462,21 @@ func (s *Sequencer) ResetClients() {
 	}
 }
 
+// Close releases the RPC connections of a sequencer that is no longer used,
+// after waiting for a call in progress. Closing twice is a no-op.
+func (s *Sequencer) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.client == nil {
+		return
+	}
+	s.client.Close()
+	s.closed = true
+
+	slog.Debug("Sequencer closed", "sequencer", s.config.ID)
+}
+
 // GetClusterMembership returns the cluster membership
 func (s *Sequencer) GetClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
 	s.mu.Lock()
@@ -303,12 +551,13 @@ func (s *Sequencer) OverrideLeader(ctx context.Context, override bool) error {
 	return nil
 }
 
-// AddServerAsVoter adds a server as a voter
-func (s *Sequencer) AddServerAsVoter(ctx context.Context, id, addr string) error {
+// AddServerAsVoter adds a server as a voter. The change only applies if the
+// cluster membership is still at version, 0 applies it unconditionally.
+func (s *Sequencer) AddServerAsVoter(ctx context.Context, id, addr string, version uint64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if err := s.client.AddServerAsVoter(ctx, id, addr, 0); err != nil {
+	if err := s.client.AddServerAsVoter(ctx, id, addr, version); err != nil {
 		slog.Error("Failed to add server as voter",
 			"sequencer", s.config.ID,
 			"server", id,
@@ -319,12 +568,14 @@ func (s *Sequencer) AddServerAsVoter(ctx context.Context, id, addr string) error
 	return nil
 }
 
-// AddServerAsNonvoter adds a server as a non-voter
-func (s *Sequencer) AddServerAsNonvoter(ctx context.Context, id, addr string) error {
+// AddServerAsNonvoter adds a server as a non-voter. The change only applies
+// if the cluster membership is still at version, 0 applies it
+// unconditionally.
+func (s *Sequencer) AddServerAsNonvoter(ctx context.Context, id, addr string, version uint64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if err := s.client.AddServerAsNonvoter(ctx, id, addr, 0); err != nil {
+	if err := s.client.AddServerAsNonvoter(ctx, id, addr, version); err != nil {
 		slog.Error("Failed to add server as non-voter",
 			"sequencer", s.config.ID,
 			"server", id,
@@ -335,12 +586,13 @@ func (s *Sequencer) AddServerAsNonvoter(ctx context.Context, id, addr string) er
 	return nil
 }
 
-// RemoveServer removes a server from the cluster
-func (s *Sequencer) RemoveServer(ctx context.Context, id string) error {
+// RemoveServer removes a server from the cluster. The change only applies if
+// the cluster membership is still at version, 0 applies it unconditionally.
+func (s *Sequencer) RemoveServer(ctx context.Context, id string, version uint64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if err := s.client.RemoveServer(ctx, id, 0); err != nil {
+	if err := s.client.RemoveServer(ctx, id, version); err != nil {
 		slog.Error("Failed to remove server",
 			"sequencer", s.config.ID,
 			"server", id,
@@ -453,6 +705,11 @@ func (s *Sequencer) TransferLeader(ctx context.Context) error {
 	return nil
 }
 
+// Config returns the sequencer's configuration
+func (s *Sequencer) Config() Config {
+	return s.config
+}
+
 // ID returns the sequencer ID
 func (s *Sequencer) ID() string {
 	return s.config.ID
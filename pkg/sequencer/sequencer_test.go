@@ -0,0 +1,122 @@
+package sequencer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+func TestUpdate_PartialFailure(t *testing.T) {
+	c := &conductortest.Cluster{Leader: "sequencer-0", Heads: map[string]uint64{"sequencer-0": 42}}
+	seq := c.Sequencer(t, "sequencer-0", true)
+
+	if got := seq.Status().Reachability(); got != sequencer.ReachabilityUnknown {
+		t.Errorf("Reachability() before update = %s, want %s", got, sequencer.ReachabilityUnknown)
+	}
+
+	if err := seq.Update(context.Background()); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if got := seq.Status().Reachability(); got != sequencer.ReachabilityReachable {
+		t.Errorf("Reachability() = %s, want %s", got, sequencer.ReachabilityReachable)
+	}
+
+	c.Lock()
+	c.NodeDown = map[string]bool{"sequencer-0": true}
+	c.Unlock()
+	err := seq.Update(context.Background())
+	if err == nil {
+		t.Fatal("Update() succeeded with the node down")
+	}
+
+	status := seq.Status()
+	if got := status.Reachability(); got != sequencer.ReachabilityDegraded {
+		t.Errorf("Reachability() = %s, want %s", got, sequencer.ReachabilityDegraded)
+	}
+	if !status.ConductorLeader || !status.ConductorActive {
+		t.Error("Conductor state was not kept while the node was down")
+	}
+	if status.UnsafeL2 == nil || status.UnsafeL2.Number != 42 {
+		t.Errorf("UnsafeL2 = %v, want the last known head 42", status.UnsafeL2)
+	}
+
+	sync := status.Checks[sequencer.CheckSyncStatus]
+	if sync.OK() || !sync.Stale() {
+		t.Errorf("sync status check ok=%v stale=%v, want failing and stale", sync.OK(), sync.Stale())
+	}
+	if !errors.Is(err, sync.Err) {
+		t.Errorf("Update() error does not include the sync status failure: %v", err)
+	}
+	if !status.Checks[sequencer.CheckConductorLeader].OK() {
+		t.Error("conductor leader check failed although the conductor is up")
+	}
+	if failing := status.Failing(); len(failing) != 2 {
+		t.Errorf("Failing() = %v, want the two node checks", failing)
+	}
+}
+
+func TestStatus_Lag(t *testing.T) {
+	fetched := time.Unix(1_700_000_100, 0)
+	status := sequencer.Status{
+		UnsafeL2:       &eth.L2BlockRef{Number: 120},
+		SafeL2:         &eth.L2BlockRef{Number: 100},
+		FinalizedL2:    &eth.L2BlockRef{Number: 40},
+		CurrentL1:      &eth.L1BlockRef{Number: 50, Time: 1_700_000_076},
+		HeadL1:         &eth.L1BlockRef{Number: 53},
+		LastUpdateTime: fetched,
+	}
+
+	if gap, ok := status.UnsafeSafeGap(); !ok || gap != 20 {
+		t.Errorf("UnsafeSafeGap() = %d, %v, want 20, true", gap, ok)
+	}
+	if gap, ok := status.SafeFinalizedGap(); !ok || gap != 60 {
+		t.Errorf("SafeFinalizedGap() = %d, %v, want 60, true", gap, ok)
+	}
+	if age, ok := status.L1OriginAge(); !ok || age != 24*time.Second {
+		t.Errorf("L1OriginAge() = %v, %v, want 24s, true", age, ok)
+	}
+	if lag, ok := status.L1DerivationLag(); !ok || lag != 3 {
+		t.Errorf("L1DerivationLag() = %d, %v, want 3, true", lag, ok)
+	}
+}
+
+func TestStatus_LagUnknown(t *testing.T) {
+	var status sequencer.Status
+
+	if _, ok := status.UnsafeSafeGap(); ok {
+		t.Error("UnsafeSafeGap() reported a value without heads")
+	}
+	if _, ok := status.SafeFinalizedGap(); ok {
+		t.Error("SafeFinalizedGap() reported a value without heads")
+	}
+	if _, ok := status.L1OriginAge(); ok {
+		t.Error("L1OriginAge() reported a value without an L1 origin")
+	}
+	if _, ok := status.L1DerivationLag(); ok {
+		t.Error("L1DerivationLag() reported a value without L1 heads")
+	}
+}
+
+func TestStatus_Equal(t *testing.T) {
+	a := sequencer.Status{UnsafeL2: &eth.L2BlockRef{Number: 1}, SafeL2: &eth.L2BlockRef{Number: 1}}
+	b := sequencer.Status{UnsafeL2: &eth.L2BlockRef{Number: 1}, SafeL2: &eth.L2BlockRef{Number: 1}}
+	if !a.Equal(b) {
+		t.Error("Equal() = false for identical heads")
+	}
+
+	b.SafeL2 = &eth.L2BlockRef{Number: 2}
+	if a.Equal(b) {
+		t.Error("Equal() = true after the safe head moved")
+	}
+
+	b.SafeL2 = nil
+	if a.Equal(b) {
+		t.Error("Equal() = true with a missing safe head")
+	}
+}
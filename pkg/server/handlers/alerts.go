@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/alert"
+	"github.com/golem-base/seqctl/pkg/auth"
+)
+
+// AlertsResponse represents the pending and firing alerts
+type AlertsResponse struct {
+	Alerts []AlertResponse `json:"alerts"`
+}
+
+// AlertResponse represents an alert in API responses
+type AlertResponse struct {
+	Fingerprint string     `json:"fingerprint"`
+	Rule        string     `json:"rule"`
+	Condition   string     `json:"condition" example:"no_leader"`
+	Severity    string     `json:"severity" example:"critical"`
+	Network     string     `json:"network"`
+	Sequencer   string     `json:"sequencer,omitempty"`
+	Summary     string     `json:"summary"`
+	State       string     `json:"state" example:"firing"`
+	ActiveSince time.Time  `json:"active_since"`
+	FiredAt     *time.Time `json:"fired_at,omitempty"`
+	Silenced    bool       `json:"silenced"`
+}
+
+// SilencesResponse represents the active silences
+type SilencesResponse struct {
+	Silences []SilenceResponse `json:"silences"`
+}
+
+// SilenceResponse represents a silence in API responses
+type SilenceResponse struct {
+	ID        string    `json:"id"`
+	Rule      string    `json:"rule,omitempty"`
+	Network   string    `json:"network,omitempty"`
+	Sequencer string    `json:"sequencer,omitempty"`
+	Until     time.Time `json:"until"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SilenceRequest represents a request to silence alerts. Either duration or
+// until must be given.
+type SilenceRequest struct {
+	Rule      string     `json:"rule,omitempty"`
+	Network   string     `json:"network,omitempty"`
+	Sequencer string     `json:"sequencer,omitempty"`
+	Duration  string     `json:"duration,omitempty" example:"2h"`
+	Until     *time.Time `json:"until,omitempty"`
+	Comment   string     `json:"comment,omitempty"`
+}
+
+// Alerts returns the pending and firing alerts
+// @Summary List alerts
+// @Description Get the alerts whose condition currently holds, pending ones included. Alerts of networks the caller cannot view are omitted.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Success 200 {object} AlertsResponse "Alerts"
+// @Failure 404 {object} ErrorResponse "Alerting disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /alerts [get]
+func (h *APIHandler) Alerts(w http.ResponseWriter, r *http.Request) {
+	if !h.alertingEnabled(w) {
+		return
+	}
+
+	principal := principalFromRequest(r)
+	resp := AlertsResponse{Alerts: []AlertResponse{}}
+	for _, a := range h.alerts.Alerts() {
+		if h.canView(principal, a.Network) {
+			resp.Alerts = append(resp.Alerts, alertToResponse(a))
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// Silences returns the active silences
+// @Summary List silences
+// @Description Get the silences that have not expired, soonest to expire first
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Success 200 {object} SilencesResponse "Silences"
+// @Failure 404 {object} ErrorResponse "Alerting disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /alerts/silences [get]
+func (h *APIHandler) Silences(w http.ResponseWriter, r *http.Request) {
+	if !h.alertingEnabled(w) {
+		return
+	}
+
+	principal := principalFromRequest(r)
+	resp := SilencesResponse{Silences: []SilenceResponse{}}
+	for _, s := range h.alerts.Silences() {
+		if h.canView(principal, s.Network) {
+			resp.Silences = append(resp.Silences, silenceToResponse(s))
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// CreateSilence silences matching alerts
+// @Summary Create a silence
+// @Description Suppress notifications of alerts matching a rule, network and/or sequencer until the silence expires. Silencing a single network requires the operator role on it, otherwise on all networks.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Param request body SilenceRequest true "Silence"
+// @Success 201 {object} SilenceResponse "Silence created"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Alerting disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /alerts/silences [post]
+func (h *APIHandler) CreateSilence(w http.ResponseWriter, r *http.Request) {
+	if !h.alertingEnabled(w) {
+		return
+	}
+
+	var req SilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	principal := principalFromRequest(r)
+	if !h.canSilence(w, principal, req.Network) {
+		return
+	}
+
+	silence := alert.Silence{
+		Rule:      req.Rule,
+		Network:   req.Network,
+		Sequencer: req.Sequencer,
+		Comment:   req.Comment,
+		CreatedBy: principal.Name,
+	}
+	switch {
+	case req.Until != nil && req.Duration != "":
+		h.sendError(w, http.StatusBadRequest, "Invalid request", "Only one of duration and until may be given")
+		return
+	case req.Until != nil:
+		silence.Until = *req.Until
+	case req.Duration != "":
+		d, err := time.ParseDuration(req.Duration)
+		if err != nil || d <= 0 {
+			h.sendError(w, http.StatusBadRequest, "Invalid request",
+				fmt.Sprintf("Invalid duration '%s'", req.Duration))
+			return
+		}
+		silence.Until = time.Now().Add(d).UTC()
+	default:
+		h.sendError(w, http.StatusBadRequest, "Invalid request", "One of duration and until is required")
+		return
+	}
+
+	created, err := h.alerts.AddSilence(silence)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, silenceToResponse(created))
+}
+
+// DeleteSilence removes a silence
+// @Summary Remove a silence
+// @Description Remove a silence so that matching alerts are notified again
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Param silence path string true "Silence ID"
+// @Success 200 {object} SilenceResponse "Silence removed"
+// @Failure 404 {object} ErrorResponse "Silence not found or alerting disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /alerts/silences/{silence} [delete]
+func (h *APIHandler) DeleteSilence(w http.ResponseWriter, r *http.Request) {
+	if !h.alertingEnabled(w) {
+		return
+	}
+
+	id := chi.URLParam(r, "silence")
+	principal := principalFromRequest(r)
+
+	var existing *alert.Silence
+	for _, s := range h.alerts.Silences() {
+		if s.ID == id && h.canView(principal, s.Network) {
+			existing = &s
+			break
+		}
+	}
+	if existing == nil {
+		h.sendError(w, http.StatusNotFound, "Silence not found",
+			fmt.Sprintf("Silence '%s' does not exist or has expired", id))
+		return
+	}
+	if !h.canSilence(w, principal, existing.Network) {
+		return
+	}
+
+	removed, ok := h.alerts.RemoveSilence(id)
+	if !ok {
+		h.sendError(w, http.StatusNotFound, "Silence not found",
+			fmt.Sprintf("Silence '%s' does not exist or has expired", id))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, silenceToResponse(removed))
+}
+
+// alertingEnabled sends an error and returns false when alerting is disabled
+func (h *APIHandler) alertingEnabled(w http.ResponseWriter) bool {
+	if h.alerts == nil || !h.alerts.Enabled() {
+		h.sendError(w, http.StatusNotFound, "Alerting disabled",
+			"No alerting rules are configured on this server")
+		return false
+	}
+	return true
+}
+
+// canSilence checks that the principal may manage silences of a network, or
+// of all networks when networkName is empty
+func (h *APIHandler) canSilence(w http.ResponseWriter, principal *auth.Principal, networkName string) bool {
+	if role := h.auth.RoleFor(principal, networkName); role < auth.RoleOperator {
+		scope := "all networks"
+		if networkName != "" {
+			scope = networkName
+		}
+		h.sendError(w, http.StatusForbidden, "Forbidden",
+			fmt.Sprintf("Role %s on %s is required, %s has %s", auth.RoleOperator, scope, principal.Name, role))
+		return false
+	}
+	return true
+}
+
+func alertToResponse(a alert.Alert) AlertResponse {
+	resp := AlertResponse{
+		Fingerprint: a.Fingerprint,
+		Rule:        a.Rule,
+		Condition:   string(a.Condition),
+		Severity:    string(a.Severity),
+		Network:     a.Network,
+		Sequencer:   a.Sequencer,
+		Summary:     a.Summary,
+		State:       string(a.State),
+		ActiveSince: a.ActiveSince,
+		Silenced:    a.Silenced,
+	}
+	if !a.FiredAt.IsZero() {
+		resp.FiredAt = &a.FiredAt
+	}
+	return resp
+}
+
+func silenceToResponse(s alert.Silence) SilenceResponse {
+	return SilenceResponse{
+		ID:        s.ID,
+		Rule:      s.Rule,
+		Network:   s.Network,
+		Sequencer: s.Sequencer,
+		Until:     s.Until,
+		Comment:   s.Comment,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+	}
+}
@@ -3,14 +3,24 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/go-chi/chi/v5"
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/alert"
 	"github.com/golem-base/seqctl/pkg/app"
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/events"
+	"github.com/golem-base/seqctl/pkg/history"
 	"github.com/golem-base/seqctl/pkg/network"
 	"github.com/golem-base/seqctl/pkg/sequencer"
 	"github.com/gorilla/websocket"
@@ -18,22 +28,56 @@ import (
 
 // APIHandler handles API requests
 type APIHandler struct {
-	app      *app.App
-	logger   *slog.Logger
-	upgrader websocket.Upgrader
+	app       *app.App
+	auth      *auth.Authenticator
+	audit     *audit.Store    // Nil when the audit log is disabled
+	history   *history.Store  // Nil when the status history is disabled
+	alerts    *alert.Manager  // Nil when alerting is disabled
+	approvals *approval.Store // Nil when dangerous actions need no approval
+	logger    *slog.Logger
+	upgrader  websocket.Upgrader
+	hub       *Hub
+	events    *events.Log
+	auditing  sync.WaitGroup // Audit entries being completed after their response
 }
 
 // NewAPIHandler creates a new API handler
-func NewAPIHandler(application *app.App, logger *slog.Logger) *APIHandler {
-	return &APIHandler{
-		app:    application,
-		logger: logger.With(slog.String("component", "api")),
+func NewAPIHandler(application *app.App, authenticator *auth.Authenticator, auditLog *audit.Store, historyStore *history.Store, alerts *alert.Manager, approvals *approval.Store, logger *slog.Logger) *APIHandler {
+	h := &APIHandler{
+		app:       application,
+		auth:      authenticator,
+		audit:     auditLog,
+		history:   historyStore,
+		alerts:    alerts,
+		approvals: approvals,
+		logger:    logger.With(slog.String("component", "api")),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(_ *http.Request) bool {
 				return true // Allow all origins for now
 			},
 		},
 	}
+
+	// Push status changes to WebSocket clients
+	h.hub = newHub(h)
+	application.Subscribe(h.hub.Publish)
+
+	// Keep recent events for the timeline and its stream
+	h.events = events.NewLog(events.DefaultCapacity)
+	application.SubscribeEvents(h.events.Publish)
+
+	return h
+}
+
+// Run runs the handler's background tasks until ctx is cancelled
+func (h *APIHandler) Run(ctx context.Context) {
+	h.hub.Run(ctx)
+}
+
+// WaitAudit waits until the audit entries of requests already served have
+// been recorded
+func (h *APIHandler) WaitAudit() {
+	h.auditing.Wait()
 }
 
 // ErrorResponse represents an error response following RFC 7807
@@ -51,32 +95,112 @@ type NetworkResponse struct {
 	ID         string              `json:"id"`
 	Name       string              `json:"name"`
 	Healthy    bool                `json:"healthy"`
+	Violations []ViolationResponse `json:"violations"`
 	Sequencers []SequencerResponse `json:"sequencers"`
 	UpdatedAt  time.Time           `json:"updated_at"`
 	Links      NetworkLinks        `json:"_links"`
 }
 
+// ViolationResponse represents a broken cluster invariant in API responses
+type ViolationResponse struct {
+	Kind       string   `json:"kind" example:"multiple_leaders"`
+	Severity   string   `json:"severity" example:"critical"`
+	Message    string   `json:"message"`
+	Sequencers []string `json:"sequencers,omitempty"`
+}
+
 // NetworkLinks represents HATEOAS links for a network
 type NetworkLinks struct {
-	Self       Link `json:"self"`
-	Sequencers Link `json:"sequencers"`
+	Self            Link `json:"self"`
+	Sequencers      Link `json:"sequencers"`
+	Membership      Link `json:"membership"`
+	MembershipPlan  Link `json:"membership_plan"`
+	ApplyMembership Link `json:"apply_membership"`
+	Handover        Link `json:"handover"`
 }
 
 // SequencerResponse represents a sequencer in API responses
 type SequencerResponse struct {
-	ID               string         `json:"id"`
-	NetworkID        string         `json:"network_id"`
-	RaftAddr         string         `json:"raft_addr"`
-	ConductorActive  bool           `json:"conductor_active"`
-	ConductorLeader  bool           `json:"conductor_leader"`
-	ConductorPaused  bool           `json:"conductor_paused"`
-	ConductorStopped bool           `json:"conductor_stopped"`
-	SequencerHealthy bool           `json:"sequencer_healthy"`
-	SequencerActive  bool           `json:"sequencer_active"`
-	UnsafeL2         uint64         `json:"unsafe_l2"`
-	Voting           bool           `json:"voting"`
-	UpdatedAt        time.Time      `json:"updated_at"`
-	Links            SequencerLinks `json:"_links"`
+	ID               string                   `json:"id"`
+	NetworkID        string                   `json:"network_id"`
+	RaftAddr         string                   `json:"raft_addr"`
+	ConductorActive  bool                     `json:"conductor_active"`
+	ConductorLeader  bool                     `json:"conductor_leader"`
+	ConductorPaused  bool                     `json:"conductor_paused"`
+	ConductorStopped bool                     `json:"conductor_stopped"`
+	SequencerHealthy bool                     `json:"sequencer_healthy"`
+	SequencerActive  bool                     `json:"sequencer_active"`
+	UnsafeL2         uint64                   `json:"unsafe_l2"`
+	SyncStatus       *SyncStatusResponse      `json:"sync_status,omitempty"`
+	Lag              *LagResponse             `json:"lag,omitempty"`
+	Head             *HeadResponse            `json:"head,omitempty"`
+	Reachability     string                   `json:"reachability" example:"degraded"`
+	Checks           map[string]CheckResponse `json:"checks,omitempty"`
+	Voting           bool                     `json:"voting"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Links            SequencerLinks           `json:"_links"`
+}
+
+// CheckResponse represents the outcome of one status probe of a sequencer.
+// A stale check failed, and the value shown comes from its last success.
+type CheckResponse struct {
+	OK          bool       `json:"ok"`
+	Stale       bool       `json:"stale"`
+	Error       string     `json:"error,omitempty"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	SucceededAt *time.Time `json:"succeeded_at,omitempty"`
+}
+
+// SyncStatusResponse represents the L1 and L2 heads reported by a sequencer's
+// op-node
+type SyncStatusResponse struct {
+	UnsafeL2    BlockRefResponse `json:"unsafe_l2"`
+	SafeL2      BlockRefResponse `json:"safe_l2"`
+	FinalizedL2 BlockRefResponse `json:"finalized_l2"`
+	CurrentL1   BlockRefResponse `json:"current_l1"`
+	HeadL1      BlockRefResponse `json:"head_l1"`
+	SafeL1      BlockRefResponse `json:"safe_l1"`
+	FinalizedL1 BlockRefResponse `json:"finalized_l1"`
+}
+
+// BlockRefResponse represents a block reference in API responses
+type BlockRefResponse struct {
+	Number    uint64           `json:"number" example:"1024"`
+	Hash      string           `json:"hash" example:"0x2c3b5d2e1f0a..."`
+	Timestamp uint64           `json:"timestamp" example:"1718000000"`
+	L1Origin  *BlockIDResponse `json:"l1_origin,omitempty"`
+}
+
+// BlockIDResponse represents the L1 origin of an L2 block
+type BlockIDResponse struct {
+	Number uint64 `json:"number" example:"512"`
+	Hash   string `json:"hash" example:"0x9a8b7c6d5e4f..."`
+}
+
+// LagResponse represents lag values derived from the sync status. A growing
+// unsafe/safe gap points at the batcher, a growing L1 origin age or
+// derivation lag at the derivation pipeline.
+type LagResponse struct {
+	UnsafeSafeBlocks    *uint64  `json:"unsafe_safe_blocks,omitempty" example:"12"`
+	SafeFinalizedBlocks *uint64  `json:"safe_finalized_blocks,omitempty" example:"64"`
+	L1OriginAgeSeconds  *float64 `json:"l1_origin_age_seconds,omitempty" example:"14.5"`
+	L1DerivationBlocks  *uint64  `json:"l1_derivation_blocks,omitempty" example:"1"`
+}
+
+// HeadResponse represents the progress of a sequencer's unsafe head over
+// successive updates. A stalled active sequencer or a lagging follower makes
+// the network unhealthy.
+type HeadResponse struct {
+	Number                uint64    `json:"number" example:"120345"`
+	Since                 time.Time `json:"since"`
+	BlockTimeSeconds      *float64  `json:"block_time_seconds,omitempty" example:"2"`
+	BlocksPerSecond       *float64  `json:"blocks_per_second,omitempty" example:"0.5"`
+	StallThresholdSeconds float64   `json:"stall_threshold_seconds" example:"20"`
+	Stalled               bool      `json:"stalled"`
+	Reference             string    `json:"reference,omitempty"`
+	LagBlocks             uint64    `json:"lag_blocks"`
+	Lagging               bool      `json:"lagging"`
+	Stale                 bool      `json:"stale"`
 }
 
 // SequencerLinks represents HATEOAS links for a sequencer
@@ -92,6 +216,8 @@ type SequencerLinks struct {
 	ForceActive    *Link `json:"force_active,omitempty"`
 	RemoveMember   *Link `json:"remove_member,omitempty"`
 	UpdateMember   *Link `json:"update_member,omitempty"`
+	Promote        *Link `json:"promote,omitempty"`
+	Demote         *Link `json:"demote,omitempty"`
 }
 
 // Link represents a HATEOAS link
@@ -135,6 +261,10 @@ func (h *APIHandler) sendError(w http.ResponseWriter, status int, title string,
 	switch status {
 	case http.StatusBadRequest:
 		errorType = "/errors/bad-request"
+	case http.StatusUnauthorized:
+		errorType = "/errors/unauthorized"
+	case http.StatusForbidden:
+		errorType = "/errors/forbidden"
 	case http.StatusNotFound:
 		errorType = "/errors/not-found"
 	case http.StatusConflict:
@@ -153,6 +283,20 @@ func (h *APIHandler) sendError(w http.ResponseWriter, status int, title string,
 	})
 }
 
+// sendActionError sends the response for a failed action. State and argument
+// checks map to 409 and 422, anything else means the RPC failed.
+func (h *APIHandler) sendActionError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, action.ErrInvalidState):
+		h.sendError(w, http.StatusConflict, "Invalid state", err.Error())
+	case errors.Is(err, action.ErrInvalidArgument):
+		h.sendError(w, http.StatusUnprocessableEntity, "Validation failed", err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, "Operation failed",
+			fmt.Sprintf("%s: %v", operation, err))
+	}
+}
+
 // ListNetworks returns all available networks
 // @Summary List all networks
 // @Description Get a list of all sequencer networks in the environment
@@ -161,6 +305,9 @@ func (h *APIHandler) sendError(w http.ResponseWriter, status int, title string,
 // @Produce json
 // @Success 200 {array} NetworkResponse "List of networks"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /networks [get]
 func (h *APIHandler) ListNetworks(w http.ResponseWriter, r *http.Request) {
 	networks, err := h.app.ListNetworks(r.Context())
@@ -169,9 +316,13 @@ func (h *APIHandler) ListNetworks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	principal := principalFromRequest(r)
+
 	response := make([]NetworkResponse, 0, len(networks))
 	for _, net := range networks {
-		response = append(response, h.networkToResponse(net))
+		if h.canView(principal, net.Name()) {
+			response = append(response, h.networkToResponse(net))
+		}
 	}
 
 	h.sendJSON(w, http.StatusOK, response)
@@ -186,6 +337,9 @@ func (h *APIHandler) ListNetworks(w http.ResponseWriter, r *http.Request) {
 // @Param network path string true "Network name"
 // @Success 200 {object} NetworkResponse "Network details"
 // @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /networks/{network} [get]
 func (h *APIHandler) GetNetwork(w http.ResponseWriter, r *http.Request) {
 	networkName := chi.URLParam(r, "network")
@@ -209,6 +363,9 @@ func (h *APIHandler) GetNetwork(w http.ResponseWriter, r *http.Request) {
 // @Param network path string true "Network name"
 // @Success 200 {array} SequencerResponse "List of sequencers"
 // @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /networks/{network}/sequencers [get]
 func (h *APIHandler) GetSequencers(w http.ResponseWriter, r *http.Request) {
 	networkName := chi.URLParam(r, "network")
@@ -222,7 +379,7 @@ func (h *APIHandler) GetSequencers(w http.ResponseWriter, r *http.Request) {
 
 	sequencers := make([]SequencerResponse, 0, len(net.Sequencers()))
 	for _, seq := range net.Sequencers() {
-		sequencers = append(sequencers, h.sequencerToResponse(seq, networkName))
+		sequencers = append(sequencers, h.sequencerToResponse(seq, net))
 	}
 
 	h.sendJSON(w, http.StatusOK, sequencers)
@@ -235,36 +392,46 @@ func (h *APIHandler) GetSequencers(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Success 200 {object} SequencerResponse "Updated sequencer state"
+// @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 409 {object} ErrorResponse "Conductor already paused"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/pause [post]
 func (h *APIHandler) PauseSequencer(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
 	}
 
-	if !seq.ConductorActive() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Conductor is already paused")
+	if dryRun {
+		preview, err := action.PreviewPause(seq)
+		h.sendPreview(w, "Failed to pause conductor", preview, err)
 		return
 	}
 
-	if err := seq.Pause(ctx); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to pause conductor: %v", err))
+	if err := action.Pause(ctx, seq); err != nil {
+		h.sendActionError(w, "Failed to pause conductor", err)
 		return
 	}
 
 	// Return updated sequencer state
 	// State will be updated on next refresh
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, net))
 }
 
 // ResumeSequencer resumes a sequencer's conductor
@@ -274,36 +441,46 @@ func (h *APIHandler) PauseSequencer(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Success 200 {object} SequencerResponse "Updated sequencer state"
+// @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 409 {object} ErrorResponse "Conductor already active"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/resume [post]
 func (h *APIHandler) ResumeSequencer(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
 	}
 
-	if seq.ConductorActive() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Conductor is already active")
+	if dryRun {
+		preview, err := action.PreviewResume(seq)
+		h.sendPreview(w, "Failed to resume conductor", preview, err)
 		return
 	}
 
-	if err := seq.Resume(ctx); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to resume conductor: %v", err))
+	if err := action.Resume(ctx, seq); err != nil {
+		h.sendActionError(w, "Failed to resume conductor", err)
 		return
 	}
 
 	// Return updated sequencer state
 	// State will be updated on next refresh
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, net))
 }
 
 // TransferLeaderRequest represents the request body for leader transfer
@@ -319,6 +496,7 @@ type TransferLeaderRequest struct {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Param request body TransferLeaderRequest true "Transfer target details"
 // @Success 202 {object} map[string]interface{} "Leadership transfer initiated"
 // @Failure 400 {object} ErrorResponse "Invalid request"
@@ -326,8 +504,17 @@ type TransferLeaderRequest struct {
 // @Failure 409 {object} ErrorResponse "Cannot transfer from current leader"
 // @Failure 422 {object} ErrorResponse "Validation failed"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/transfer-leader [post]
 func (h *APIHandler) TransferLeader(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
@@ -337,27 +524,20 @@ func (h *APIHandler) TransferLeader(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if seq.ConductorLeader() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Cannot transfer leadership from current leader")
-		return
-	}
-
 	var req TransferLeaderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
-	if req.TargetID == "" || req.TargetAddr == "" {
-		h.sendError(w, http.StatusUnprocessableEntity, "Validation failed",
-			"target_id and target_addr are required")
+	if dryRun {
+		preview, err := action.PreviewTransferLeader(seq, req.TargetID, req.TargetAddr)
+		h.sendPreview(w, "Failed to transfer leadership", preview, err)
 		return
 	}
 
-	if err := seq.TransferLeaderToServer(ctx, req.TargetID, req.TargetAddr); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to transfer leadership: %v", err))
+	if err := action.TransferLeader(ctx, seq, req.TargetID, req.TargetAddr); err != nil {
+		h.sendActionError(w, "Failed to transfer leadership", err)
 		return
 	}
 
@@ -375,34 +555,44 @@ func (h *APIHandler) TransferLeader(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Success 202 {object} SequencerResponse "Leadership resignation accepted"
+// @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 409 {object} ErrorResponse "Sequencer is not the current leader"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/resign-leader [post]
 func (h *APIHandler) ResignLeader(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
 	}
 
-	if !seq.ConductorLeader() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Sequencer is not the current leader")
+	if dryRun {
+		preview, err := action.PreviewResignLeader(seq)
+		h.sendPreview(w, "Failed to resign leadership", preview, err)
 		return
 	}
 
-	if err := seq.TransferLeader(ctx); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to resign leadership: %v", err))
+	if err := action.ResignLeader(ctx, seq); err != nil {
+		h.sendActionError(w, "Failed to resign leadership", err)
 		return
 	}
 
-	h.sendJSON(w, http.StatusAccepted, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusAccepted, h.sequencerToResponse(seq, net))
 }
 
 // OverrideLeaderRequest represents the request body for leader override
@@ -413,21 +603,34 @@ type OverrideLeaderRequest struct {
 // OverrideLeader overrides the leader status
 // @Summary Override leader status
 // @Description Force override the leader status of a sequencer (WARNING: Can cause split-brain)
+// @Description When two-person approval is enabled the override is checked and held as a pending request instead, which a second operator
+// @Description runs through POST /approvals/{approval}.
 // @Tags Actions
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Param request body OverrideLeaderRequest true "Override configuration"
 // @Success 200 {object} SequencerResponse "Leader status overridden"
+// @Success 202 {object} PendingApprovalResponse "Held for approval"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/override-leader [post]
 func (h *APIHandler) OverrideLeader(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
@@ -439,13 +642,24 @@ func (h *APIHandler) OverrideLeader(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := seq.OverrideLeader(ctx, req.Override); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to override leader: %v", err))
+	if dryRun {
+		preview, err := action.PreviewOverrideLeader(seq, req.Override)
+		h.sendPreview(w, "Failed to override leader", preview, err)
+		return
+	}
+
+	if h.approvals != nil {
+		preview, err := action.PreviewOverrideLeader(seq, req.Override)
+		h.requestApproval(w, r, approval.ActionOverrideLeader, seq, net, req, preview, err)
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	if err := action.OverrideLeader(ctx, seq, req.Override); err != nil {
+		h.sendActionError(w, "Failed to override leader", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, net))
 }
 
 // HaltSequencer halts a sequencer
@@ -455,36 +669,46 @@ func (h *APIHandler) OverrideLeader(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Success 200 {object} SequencerResponse "Sequencer halted"
+// @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 409 {object} ErrorResponse "Sequencer already halted"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/halt [post]
 func (h *APIHandler) HaltSequencer(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
 	}
 
-	if !seq.SequencerActive() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Sequencer is already halted")
+	if dryRun {
+		preview, err := action.PreviewHalt(seq)
+		h.sendPreview(w, "Failed to halt sequencer", preview, err)
 		return
 	}
 
-	if _, err := seq.StopSequencer(ctx); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to halt sequencer: %v", err))
+	if err := action.Halt(ctx, seq); err != nil {
+		h.sendActionError(w, "Failed to halt sequencer", err)
 		return
 	}
 
 	// Return updated sequencer state
 	// State will be updated on next refresh
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, net))
 }
 
 // ForceActiveRequest represents the request body for forcing a sequencer active
@@ -495,50 +719,64 @@ type ForceActiveRequest struct {
 // ForceActive forces a sequencer to become active
 // @Summary Force sequencer active
 // @Description Force a sequencer to become the active sequencer (WARNING: Use only in emergencies)
+// @Description When two-person approval is enabled the activation is checked and held as a pending request instead, which a second
+// @Description operator runs through POST /approvals/{approval}.
 // @Tags Actions
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them"
 // @Param request body ForceActiveRequest false "Optional block hash to start from"
 // @Success 200 {object} SequencerResponse "Sequencer activated"
+// @Success 202 {object} PendingApprovalResponse "Held for approval"
+// @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
 // @Failure 409 {object} ErrorResponse "Sequencer already active"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/force-active [post]
 func (h *APIHandler) ForceActive(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
 	}
 
-	if seq.SequencerActive() {
-		h.sendError(w, http.StatusConflict, "Invalid state",
-			"Sequencer is already active")
-		return
-	}
-
 	var req ForceActiveRequest
 	// Allow empty body - will use zero hash
 	json.NewDecoder(r.Body).Decode(&req)
 
-	var hash common.Hash
-	if req.BlockHash != "" {
-		hash = common.HexToHash(req.BlockHash)
+	if dryRun {
+		preview, err := action.PreviewForceActive(seq, req.BlockHash)
+		h.sendPreview(w, "Failed to activate sequencer", preview, err)
+		return
+	}
+
+	if h.approvals != nil {
+		preview, err := action.PreviewForceActive(seq, req.BlockHash)
+		h.requestApproval(w, r, approval.ActionForceActive, seq, net, req, preview, err)
+		return
 	}
 
-	if err := seq.StartSequencer(ctx, hash); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to activate sequencer: %v", err))
+	if err := action.ForceActive(ctx, seq, req.BlockHash); err != nil {
+		h.sendActionError(w, "Failed to activate sequencer", err)
 		return
 	}
 
 	// Return updated sequencer state
 	// State will be updated on next refresh
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, net))
 }
 
 // RemoveMemberRequest represents the request body for removing a member
@@ -548,23 +786,35 @@ type RemoveMemberRequest struct {
 
 // RemoveFromCluster removes a sequencer from the cluster
 // @Summary Remove server from cluster
-// @Description Remove a server from the Raft cluster membership
+// @Description Remove a server from the Raft cluster membership. The change is made against the current membership version and fails if the membership changed concurrently.
+// @Description Removing the conductor leader, or a voter without which the remaining reachable voters lose quorum, is refused.
 // @Tags Actions
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID (must be leader)"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks"
 // @Param request body RemoveMemberRequest true "Server to remove"
-// @Success 204 "Server removed successfully"
+// @Success 200 {object} MembershipResponse "New cluster membership"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
+// @Failure 409 {object} ErrorResponse "Sequencer is not the leader, or the removal would break quorum"
 // @Failure 422 {object} ErrorResponse "Validation failed"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/membership [delete]
 func (h *APIHandler) RemoveFromCluster(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, _, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
@@ -576,19 +826,19 @@ func (h *APIHandler) RemoveFromCluster(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.ServerID == "" {
-		h.sendError(w, http.StatusUnprocessableEntity, "Validation failed",
-			"server_id is required")
+	if dryRun {
+		preview, err := action.PreviewRemoveMember(ctx, net, seq, req.ServerID)
+		h.sendPreview(w, "Failed to remove server from cluster", preview, err)
 		return
 	}
 
-	if err := seq.RemoveServer(ctx, req.ServerID); err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to remove server from cluster: %v", err))
+	m, err := action.RemoveMember(ctx, net, seq, req.ServerID)
+	if err != nil {
+		h.sendActionError(w, "Failed to remove server from cluster", err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	h.sendJSON(w, http.StatusOK, NewMembershipResponse(m))
 }
 
 // UpdateMembershipRequest represents the request body for updating membership
@@ -600,23 +850,35 @@ type UpdateMembershipRequest struct {
 
 // UpdateMembership updates cluster membership
 // @Summary Update cluster membership
-// @Description Add a new server to the Raft cluster as either a voting or non-voting member
+// @Description Add a new server to the Raft cluster as either a voting or non-voting member.
+// @Description The change is made against the current membership version and fails if the membership changed concurrently.
 // @Tags Actions
 // @Accept json
 // @Produce json
 // @Param id path string true "Sequencer ID (must be leader)"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks"
 // @Param request body UpdateMembershipRequest true "New member details"
-// @Success 200 {object} SequencerResponse "Membership updated"
+// @Success 200 {object} MembershipResponse "New cluster membership"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 404 {object} ErrorResponse "Sequencer not found"
+// @Failure 409 {object} ErrorResponse "Sequencer is not the leader, or the server is already a member"
 // @Failure 422 {object} ErrorResponse "Validation failed"
 // @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
 // @Router /sequencers/{id}/membership [put]
 func (h *APIHandler) UpdateMembership(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
 
-	seq, network, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
 		return
@@ -628,81 +890,243 @@ func (h *APIHandler) UpdateMembership(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.ServerID == "" || req.ServerAddr == "" {
-		h.sendError(w, http.StatusUnprocessableEntity, "Validation failed",
-			"server_id and server_addr are required")
+	if dryRun {
+		preview, err := action.PreviewAddMember(ctx, net, seq, req.ServerID, req.ServerAddr, req.Voting)
+		h.sendPreview(w, "Failed to update membership", preview, err)
 		return
 	}
 
-	if req.Voting {
-		err = seq.AddServerAsVoter(ctx, req.ServerID, req.ServerAddr)
-	} else {
-		err = seq.AddServerAsNonvoter(ctx, req.ServerID, req.ServerAddr)
-	}
-
+	m, err := action.AddMember(ctx, net, seq, req.ServerID, req.ServerAddr, req.Voting)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Operation failed",
-			fmt.Sprintf("Failed to update membership: %v", err))
+		h.sendActionError(w, "Failed to update membership", err)
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, h.sequencerToResponse(seq, network))
+	h.sendJSON(w, http.StatusOK, NewMembershipResponse(m))
 }
 
 // WebSocket handles WebSocket connections for real-time updates
+// @Summary Subscribe to real-time updates
+// @Description Upgrade to a WebSocket that streams network snapshots and sequencer status changes.
+// @Description Clients may send {"action":"subscribe","networks":[...]} or {"action":"unsubscribe","networks":[...]};
+// @Description an empty network list means all networks.
+// @Tags Networks
+// @Param network query []string false "Networks to subscribe to initially (default: all)" collectionFormat(multi)
+// @Success 101 {object} WSMessage "Switching protocols"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /ws [get]
 func (h *APIHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		h.logger.Error("WebSocket upgrade failed", slog.String("error", err.Error()))
 		return
 	}
-	defer conn.Close()
 
-	// TODO: Implement WebSocket handling for real-time updates
-	h.logger.Info("WebSocket connection established")
+	networkNames := r.URL.Query()["network"]
+	client := newWSClient(h.hub, conn, principalFromRequest(r), networkNames)
+
+	go client.writePump()
+	go client.readPump()
+
+	// Send the initial state before registering for updates so that
+	// clients always receive a snapshot first
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	h.hub.sendSnapshots(ctx, client, networkNames)
+	h.hub.register(client)
+
+	h.logger.Info("WebSocket connection established",
+		slog.String("remote", conn.RemoteAddr().String()),
+		slog.Any("networks", networkNames))
+}
+
+// Health status values
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthUnknown  = "unknown"
+)
+
+// HealthResponse represents the overall service health
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"`
+}
+
+// NetworksHealthResponse represents the health of the networks visible to the
+// caller and any broken invariants
+type NetworksHealthResponse struct {
+	Status   string                  `json:"status" example:"ok"`
+	Error    string                  `json:"error,omitempty"`
+	Networks []NetworkHealthResponse `json:"networks"`
+}
+
+// NetworkHealthResponse represents the health of a single network
+type NetworkHealthResponse struct {
+	Name       string              `json:"name"`
+	Healthy    bool                `json:"healthy"`
+	Violations []ViolationResponse `json:"violations"`
+}
+
+// Health reports the overall service health without naming networks or
+// sequencers, as it is served without authentication. It always responds 200
+// so that it remains usable as a liveness probe, unless strict=true is given,
+// in which case a degraded or unknown state yields 503.
+func (h *APIHandler) Health(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	health := h.networksHealth(ctx, func(string) bool { return true })
+	if health.Error != "" {
+		h.logger.Error("Health check failed to list networks", slog.String("error", health.Error))
+	}
+
+	status := http.StatusOK
+	if health.Status != HealthOK && r.URL.Query().Get("strict") == "true" {
+		status = http.StatusServiceUnavailable
+	}
+
+	h.sendJSON(w, status, HealthResponse{Status: health.Status})
+}
+
+// NetworksHealth reports the health of the networks visible to the caller
+// @Summary Get network health
+// @Description Get the health and invariant violations of each network the caller may view.
+// @Description The status covers these networks only; the unauthenticated /health covers all of them without details.
+// @Tags Networks
+// @Produce json
+// @Success 200 {object} NetworksHealthResponse "Network health"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /health [get]
+func (h *APIHandler) NetworksHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	principal := principalFromRequest(r)
+	h.sendJSON(w, http.StatusOK, h.networksHealth(ctx, func(networkName string) bool {
+		return h.canView(principal, networkName)
+	}))
+}
+
+// networksHealth evaluates the invariants of the networks accepted by include
+func (h *APIHandler) networksHealth(ctx context.Context, include func(networkName string) bool) NetworksHealthResponse {
+	resp := NetworksHealthResponse{Status: HealthOK, Networks: []NetworkHealthResponse{}}
+
+	networks, err := h.app.ListNetworks(ctx)
+	if err != nil {
+		resp.Status = HealthUnknown
+		resp.Error = err.Error()
+	}
+
+	for _, net := range networks {
+		if !include(net.Name()) {
+			continue
+		}
+
+		violations := violationsToResponse(net.Invariants())
+		healthy := networkHealthy(net, violations)
+		if !healthy {
+			resp.Status = HealthDegraded
+		}
+
+		resp.Networks = append(resp.Networks, NetworkHealthResponse{
+			Name:       net.Name(),
+			Healthy:    healthy,
+			Violations: violations,
+		})
+	}
+	sort.Slice(resp.Networks, func(i, j int) bool {
+		return resp.Networks[i].Name < resp.Networks[j].Name
+	})
+
+	return resp
 }
 
 // Helper methods
 
-func (h *APIHandler) getSequencer(ctx context.Context, sequencerID string) (*sequencer.Sequencer, string, error) {
+func (h *APIHandler) getSequencer(ctx context.Context, sequencerID string) (*sequencer.Sequencer, *network.Network, error) {
 	networks, err := h.app.ListNetworks(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to list networks: %w", err)
+		return nil, nil, fmt.Errorf("failed to list networks: %w", err)
 	}
 
 	for _, net := range networks {
 		for _, seq := range net.Sequencers() {
 			if seq.ID() == sequencerID {
-				return seq, net.Name(), nil
+				return seq, net, nil
 			}
 		}
 	}
 
-	return nil, "", fmt.Errorf("sequencer not found: %s", sequencerID)
+	return nil, nil, fmt.Errorf("sequencer not found: %s", sequencerID)
 }
 
 func (h *APIHandler) networkToResponse(net *network.Network) NetworkResponse {
 	sequencers := make([]SequencerResponse, 0, len(net.Sequencers()))
 	for _, seq := range net.Sequencers() {
-		sequencers = append(sequencers, h.sequencerToResponse(seq, net.Name()))
+		sequencers = append(sequencers, h.sequencerToResponse(seq, net))
 	}
 
+	violations := violationsToResponse(net.Invariants())
+
 	return NetworkResponse{
 		ID:         net.Name(),
 		Name:       net.Name(),
-		Healthy:    net.IsHealthy(),
+		Healthy:    networkHealthy(net, violations),
+		Violations: violations,
 		Sequencers: sequencers,
 		UpdatedAt:  net.UpdatedAt(),
-		Links: NetworkLinks{
-			Self:       Link{Href: fmt.Sprintf("/api/v1/networks/%s", net.Name())},
-			Sequencers: Link{Href: fmt.Sprintf("/api/v1/networks/%s/sequencers", net.Name())},
-		},
+		Links:      networkLinks(net.Name()),
 	}
 }
 
-func (h *APIHandler) sequencerToResponse(seq *sequencer.Sequencer, networkName string) SequencerResponse {
+func violationsToResponse(violations []network.Violation) []ViolationResponse {
+	resp := make([]ViolationResponse, 0, len(violations))
+	for _, v := range violations {
+		resp = append(resp, ViolationResponse{
+			Kind:       string(v.Kind),
+			Severity:   string(v.Severity),
+			Message:    v.Message,
+			Sequencers: v.Sequencers,
+		})
+	}
+	return resp
+}
+
+// networkHealthy reports a network as healthy only if all sequencers are
+// healthy, the unsafe heads progress and no invariant is critically violated
+func networkHealthy(net *network.Network, violations []ViolationResponse) bool {
+	if !net.IsHealthy() {
+		return false
+	}
+	for _, v := range violations {
+		if v.Severity == string(network.SeverityCritical) {
+			return false
+		}
+	}
+	return true
+}
+
+func networkLinks(networkName string) NetworkLinks {
+	baseURL := fmt.Sprintf("/api/v1/networks/%s", networkName)
+
+	return NetworkLinks{
+		Self:            Link{Href: baseURL},
+		Sequencers:      Link{Href: baseURL + "/sequencers"},
+		Membership:      Link{Href: baseURL + "/membership"},
+		MembershipPlan:  Link{Href: baseURL + "/membership/plan"},
+		ApplyMembership: Link{Href: baseURL + "/membership/apply", Method: "POST"},
+		Handover:        Link{Href: baseURL + "/handover", Method: "POST"},
+	}
+}
+
+func (h *APIHandler) sequencerToResponse(seq *sequencer.Sequencer, net *network.Network) SequencerResponse {
 	// Get status once for consistent snapshot and better performance
 	status := seq.Status()
+	networkName := net.Name()
 
 	resp := SequencerResponse{
 		ID:               seq.ID(),
@@ -720,8 +1144,13 @@ func (h *APIHandler) sequencerToResponse(seq *sequencer.Sequencer, networkName s
 			}
 			return 0
 		}(),
-		Voting:    seq.Voting(),
-		UpdatedAt: time.Now(),
+		SyncStatus:   NewSyncStatusResponse(status),
+		Lag:          NewLagResponse(status),
+		Head:         NewHeadResponse(net, seq.ID()),
+		Reachability: string(status.Reachability()),
+		Checks:       NewChecksResponse(status),
+		Voting:       seq.Voting(),
+		UpdatedAt:    time.Now(),
 		Links: SequencerLinks{
 			Self:    Link{Href: fmt.Sprintf("/api/v1/sequencers/%s", seq.ID())},
 			Network: Link{Href: fmt.Sprintf("/api/v1/networks/%s", networkName)},
@@ -755,5 +1184,121 @@ func (h *APIHandler) sequencerToResponse(seq *sequencer.Sequencer, networkName s
 		resp.Links.RemoveMember = &Link{Href: baseURL + "/membership", Method: "DELETE"}
 	}
 
+	// The Raft suffrage of the sequencer is not part of its status, only the
+	// leader is known not to be demotable
+	resp.Links.Promote = &Link{Href: baseURL + "/membership/promote", Method: "POST"}
+	if !status.ConductorLeader {
+		resp.Links.Demote = &Link{Href: baseURL + "/membership/demote", Method: "POST"}
+	}
+
+	return resp
+}
+
+// NewChecksResponse converts the probe outcomes of a status, keyed by probe
+// name
+func NewChecksResponse(status sequencer.Status) map[string]CheckResponse {
+	if len(status.Checks) == 0 {
+		return nil
+	}
+
+	checks := make(map[string]CheckResponse, len(status.Checks))
+	for check, result := range status.Checks {
+		resp := CheckResponse{
+			OK:        result.OK(),
+			Stale:     result.Stale(),
+			CheckedAt: result.CheckedAt,
+		}
+		if result.Err != nil {
+			resp.Error = result.Err.Error()
+		}
+		if !result.SucceededAt.IsZero() {
+			succeededAt := result.SucceededAt
+			resp.SucceededAt = &succeededAt
+		}
+		checks[string(check)] = resp
+	}
+	return checks
+}
+
+// NewSyncStatusResponse converts the heads of a status, or returns nil if the
+// sync status could not be fetched
+func NewSyncStatusResponse(status sequencer.Status) *SyncStatusResponse {
+	if status.UnsafeL2 == nil || status.SafeL2 == nil || status.FinalizedL2 == nil ||
+		status.CurrentL1 == nil || status.HeadL1 == nil || status.SafeL1 == nil || status.FinalizedL1 == nil {
+		return nil
+	}
+	return &SyncStatusResponse{
+		UnsafeL2:    l2RefToResponse(*status.UnsafeL2),
+		SafeL2:      l2RefToResponse(*status.SafeL2),
+		FinalizedL2: l2RefToResponse(*status.FinalizedL2),
+		CurrentL1:   l1RefToResponse(*status.CurrentL1),
+		HeadL1:      l1RefToResponse(*status.HeadL1),
+		SafeL1:      l1RefToResponse(*status.SafeL1),
+		FinalizedL1: l1RefToResponse(*status.FinalizedL1),
+	}
+}
+
+func l2RefToResponse(ref eth.L2BlockRef) BlockRefResponse {
+	return BlockRefResponse{
+		Number:    ref.Number,
+		Hash:      ref.Hash.Hex(),
+		Timestamp: ref.Time,
+		L1Origin:  &BlockIDResponse{Number: ref.L1Origin.Number, Hash: ref.L1Origin.Hash.Hex()},
+	}
+}
+
+func l1RefToResponse(ref eth.L1BlockRef) BlockRefResponse {
+	return BlockRefResponse{Number: ref.Number, Hash: ref.Hash.Hex(), Timestamp: ref.Time}
+}
+
+// NewLagResponse derives the lag values of a status, or returns nil if none
+// can be computed
+func NewLagResponse(status sequencer.Status) *LagResponse {
+	var lag LagResponse
+	if gap, ok := status.UnsafeSafeGap(); ok {
+		lag.UnsafeSafeBlocks = &gap
+	}
+	if gap, ok := status.SafeFinalizedGap(); ok {
+		lag.SafeFinalizedBlocks = &gap
+	}
+	if age, ok := status.L1OriginAge(); ok {
+		seconds := age.Seconds()
+		lag.L1OriginAgeSeconds = &seconds
+	}
+	if blocks, ok := status.L1DerivationLag(); ok {
+		lag.L1DerivationBlocks = &blocks
+	}
+	if lag == (LagResponse{}) {
+		return nil
+	}
+	return &lag
+}
+
+// NewHeadResponse creates the unsafe head progress of a sequencer, or nil
+// before its head was fetched once
+func NewHeadResponse(net *network.Network, sequencerID string) *HeadResponse {
+	stats, ok := net.HeadStats(sequencerID)
+	if !ok {
+		return nil
+	}
+
+	resp := &HeadResponse{
+		Number:                stats.Number,
+		Since:                 stats.Since,
+		StallThresholdSeconds: stats.StallThreshold.Seconds(),
+		Stalled:               stats.Stalled,
+		Reference:             stats.Reference,
+		LagBlocks:             stats.Lag,
+		Lagging:               stats.Lagging,
+		Stale:                 !stats.Fresh,
+	}
+	if stats.BlockTime > 0 {
+		seconds := stats.BlockTime.Seconds()
+		resp.BlockTimeSeconds = &seconds
+	}
+	if stats.RateWindow > 0 {
+		rate := stats.Rate
+		resp.BlocksPerSecond = &rate
+	}
 	return resp
 }
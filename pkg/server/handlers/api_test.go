@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/golem-base/seqctl/pkg/app"
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/config"
+	"github.com/golem-base/seqctl/pkg/internal/conductortest"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/provider"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// testRepository serves a fixed set of networks
+type testRepository struct {
+	networks map[string]*network.Network
+}
+
+func (r *testRepository) GetNetwork(_ context.Context, name string) (*network.Network, error) {
+	net, ok := r.networks[name]
+	if !ok {
+		return nil, fmt.Errorf("network not found: %s", name)
+	}
+	return net, nil
+}
+
+func (r *testRepository) ListNetworks(context.Context) (map[string]*network.Network, error) {
+	return maps.Clone(r.networks), nil
+}
+
+func (r *testRepository) RefreshCache(context.Context) error {
+	return nil
+}
+
+func (r *testRepository) RecordVoting(context.Context, *sequencer.Sequencer, bool) (string, error) {
+	return provider.VotingUnsupported, nil
+}
+
+func (r *testRepository) InvalidateNetwork(string)             {}
+func (r *testRepository) InvalidateAll()                       {}
+func (r *testRepository) Subscribe(network.ChangeHandler)      {}
+func (r *testRepository) SubscribeEvents(network.EventHandler) {}
+
+// testAuthConfig grants the viewer, operator and admin tokens their role on
+// devnet only. Other callers have no access.
+func testAuthConfig() config.AuthConfig {
+	cfg := config.New().Auth
+	cfg.Enabled = true
+	cfg.DefaultRole = "none"
+	cfg.Tokens = []config.APITokenConfig{
+		{Name: "viewer", Token: "viewer-token"},
+		{Name: "operator", Token: "operator-token"},
+		{Name: "admin", Token: "admin-token"},
+		{Name: "admin-2", Token: "admin-2-token"},
+	}
+	cfg.Bindings = []config.RoleBindingConfig{
+		{Role: "viewer", Principals: []string{"viewer"}, Networks: []string{"devnet"}},
+		{Role: "operator", Principals: []string{"operator"}, Networks: []string{"devnet"}},
+		{Role: "admin", Principals: []string{"admin", "admin-2"}, Networks: []string{"devnet"}},
+	}
+	return cfg
+}
+
+// newTestHandler returns a handler over network devnet, whose cluster has
+// the voters sequencer-0 and sequencer-1 led by sequencer-0, and network
+// testnet with the single voter testnet-0. Statuses are probed once.
+func newTestHandler(t *testing.T, authCfg config.AuthConfig) (*APIHandler, *conductortest.Cluster) {
+	t.Helper()
+
+	devnet := &conductortest.Cluster{Leader: "sequencer-0"}
+	testnet := &conductortest.Cluster{Leader: "testnet-0"}
+
+	repo := &testRepository{networks: map[string]*network.Network{
+		"devnet":  network.NewNetwork("devnet", devnet.Sequencers(t, conductortest.Voters("sequencer-0", "sequencer-1"))),
+		"testnet": network.NewNetwork("testnet", testnet.Sequencers(t, conductortest.Voters("testnet-0"))),
+	}}
+	for _, net := range repo.networks {
+		if err := net.Update(context.Background()); err != nil {
+			t.Fatalf("Failed to update network %s: %v", net.Name(), err)
+		}
+	}
+
+	authenticator, err := auth.New(context.Background(), authCfg)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	h := NewAPIHandler(app.New(config.New(), repo), authenticator, nil, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return h, devnet
+}
+
+// serve sends a request through the handler with the given bearer token and
+// JSON body, both optional
+func serve(handler http.Handler, method, target, token string, body any) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = strings.NewReader(string(data))
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode unmarshals a JSON response body
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestHealth_OmitsNetworkDetails(t *testing.T) {
+	h, devnet := newTestHandler(t, testAuthConfig())
+
+	// Split brain on devnet
+	devnet.Lock()
+	devnet.Leading = map[string]bool{"sequencer-1": true}
+	devnet.Unlock()
+	net, _ := h.app.GetNetwork(context.Background(), "devnet")
+	_ = net.Update(context.Background())
+
+	for _, target := range []string{"/health", "/health?strict=true"} {
+		rec := serve(http.HandlerFunc(h.Health), http.MethodGet, target, "", nil)
+
+		wantStatus := http.StatusOK
+		if strings.Contains(target, "strict") {
+			wantStatus = http.StatusServiceUnavailable
+		}
+		if rec.Code != wantStatus {
+			t.Errorf("GET %s = %d, want %d", target, rec.Code, wantStatus)
+		}
+
+		if body := rec.Body.String(); strings.Contains(body, "devnet") || strings.Contains(body, "sequencer-") {
+			t.Errorf("GET %s = %s, names networks or sequencers", target, body)
+		}
+		if got := decode[map[string]any](t, rec); len(got) != 1 || got["status"] != HealthDegraded {
+			t.Errorf("GET %s = %v, want only status %q", target, got, HealthDegraded)
+		}
+	}
+}
+
+func TestNetworksHealth_FiltersNetworks(t *testing.T) {
+	h, devnet := newTestHandler(t, testAuthConfig())
+
+	devnet.Lock()
+	devnet.Leading = map[string]bool{"sequencer-1": true}
+	devnet.Unlock()
+	net, _ := h.app.GetNetwork(context.Background(), "devnet")
+	_ = net.Update(context.Background())
+
+	router := h.Authenticate(h.Authorize(auth.RoleViewer)(http.HandlerFunc(h.NetworksHealth)))
+
+	if rec := serve(router, http.MethodGet, "/api/v1/health", "", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Unauthenticated request = %d, want 401", rec.Code)
+	}
+
+	rec := serve(router, http.MethodGet, "/api/v1/health", "viewer-token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Viewer request = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	resp := decode[NetworksHealthResponse](t, rec)
+	if resp.Status != HealthDegraded || len(resp.Networks) != 1 || resp.Networks[0].Name != "devnet" {
+		t.Fatalf("Health = %+v, want degraded devnet only", resp)
+	}
+	if !slices.ContainsFunc(resp.Networks[0].Violations, func(v ViolationResponse) bool {
+		return v.Kind == string(network.ViolationMultipleLeaders)
+	}) {
+		t.Errorf("Violations = %+v, want multiple leaders", resp.Networks[0].Violations)
+	}
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// ApprovalsResponse represents the pending and recently settled approval
+// requests, newest first
+type ApprovalsResponse struct {
+	Approvals []ApprovalResponse `json:"approvals"`
+}
+
+// ApprovalResponse represents a dangerous action held for a second operator
+type ApprovalResponse struct {
+	ID                string          `json:"id"`
+	Action            string          `json:"action" example:"force-active"`
+	Network           string          `json:"network"`
+	Sequencer         string          `json:"sequencer"`
+	Request           json.RawMessage `json:"request,omitempty" swaggertype:"object"`
+	Effect            string          `json:"effect"`
+	Status            string          `json:"status" example:"pending"`
+	RequestedBy       string          `json:"requested_by"`
+	RequestedByMethod string          `json:"requested_by_method" example:"oidc"`
+	RequestedAt       time.Time       `json:"requested_at"`
+	ExpiresAt         time.Time       `json:"expires_at"`
+	ApprovedBy        string          `json:"approved_by,omitempty"`
+	ApprovedByMethod  string          `json:"approved_by_method,omitempty" example:"token"`
+	RejectedBy        string          `json:"rejected_by,omitempty"`
+	RejectedByMethod  string          `json:"rejected_by_method,omitempty"`
+	DecidedAt         *time.Time      `json:"decided_at,omitempty"`
+	Error             string          `json:"error,omitempty"`
+	Links             ApprovalLinks   `json:"_links"`
+}
+
+// ApprovalLinks represents HATEOAS links for an approval request. Approve
+// and reject are only offered while the request is pending.
+type ApprovalLinks struct {
+	Self    Link  `json:"self"`
+	Network Link  `json:"network"`
+	Approve *Link `json:"approve,omitempty"`
+	Reject  *Link `json:"reject,omitempty"`
+}
+
+// PendingApprovalResponse is returned instead of running an action that
+// needs a second operator's approval
+type PendingApprovalResponse struct {
+	Approval ApprovalResponse `json:"approval"`
+}
+
+// ApprovedActionResponse is the outcome of approving a request: the request
+// and the sequencer after its action ran
+type ApprovedActionResponse struct {
+	Approval  ApprovalResponse  `json:"approval"`
+	Sequencer SequencerResponse `json:"sequencer"`
+}
+
+// Approvals returns the approval requests
+// @Summary List approval requests
+// @Description Get the dangerous actions waiting for a second operator, and those approved, rejected or expired within the last day, newest first.
+// @Description Requests on networks the caller cannot view are omitted.
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param status query string false "Only requests with this status (pending, approved, executed, failed, rejected, expired)"
+// @Success 200 {object} ApprovalsResponse "Approval requests"
+// @Failure 404 {object} ErrorResponse "Approval disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /approvals [get]
+func (h *APIHandler) Approvals(w http.ResponseWriter, r *http.Request) {
+	if !h.approvalEnabled(w) {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	principal := principalFromRequest(r)
+	resp := ApprovalsResponse{Approvals: []ApprovalResponse{}}
+	for _, req := range h.approvals.List() {
+		if (status == "" || string(req.Status) == status) && h.canView(principal, req.Network) {
+			resp.Approvals = append(resp.Approvals, approvalToResponse(req))
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// GetApproval returns an approval request
+// @Summary Get approval request
+// @Description Get a dangerous action held for a second operator
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param approval path string true "Approval request ID"
+// @Success 200 {object} ApprovalResponse "Approval request"
+// @Failure 404 {object} ErrorResponse "Approval request not found or approval disabled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /approvals/{approval} [get]
+func (h *APIHandler) GetApproval(w http.ResponseWriter, r *http.Request) {
+	if !h.approvalEnabled(w) {
+		return
+	}
+
+	id := chi.URLParam(r, "approval")
+	req, ok := h.approvals.Get(id)
+	if !ok {
+		h.sendError(w, http.StatusNotFound, "Approval request not found",
+			fmt.Sprintf("Approval request '%s' does not exist", id))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, approvalToResponse(req))
+}
+
+// Approve approves a pending request and runs its action
+// @Summary Approve and run an action
+// @Description Approve a dangerous action held for a second operator and run it. The approver needs the admin role on the network
+// @Description and must not be the operator who requested the action. The action's checks are made again against the current
+// @Description state, and the audit log records both operators.
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param approval path string true "Approval request ID"
+// @Success 200 {object} ApprovedActionResponse "Action approved and run"
+// @Failure 403 {object} ErrorResponse "Forbidden, or the approver requested the action"
+// @Failure 404 {object} ErrorResponse "Approval request or sequencer not found, or approval disabled"
+// @Failure 409 {object} ErrorResponse "Request no longer pending, or the action is refused in the current state"
+// @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Security BearerAuth
+// @Router /approvals/{approval} [post]
+func (h *APIHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	if !h.approvalEnabled(w) {
+		return
+	}
+
+	id := chi.URLParam(r, "approval")
+	if existing, ok := h.approvals.Get(id); ok {
+		auditApproval(r, existing)
+	}
+
+	req, err := h.approvals.Approve(id, identityFromRequest(r))
+	if err != nil {
+		h.sendApprovalError(w, id, err)
+		return
+	}
+	auditApproval(r, req)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	seq, net, err := h.getSequencer(ctx, req.Sequencer)
+	if err == nil {
+		err = runApproved(ctx, seq, req)
+	}
+
+	done, completeErr := h.approvals.Complete(id, err)
+	if completeErr != nil {
+		h.sendError(w, http.StatusInternalServerError, "Operation failed", completeErr.Error())
+		return
+	}
+	auditApproval(r, done)
+
+	switch {
+	case seq == nil:
+		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
+	case err != nil:
+		h.sendActionError(w, fmt.Sprintf("Failed to run %s", req.Action), err)
+	default:
+		h.sendJSON(w, http.StatusOK, ApprovedActionResponse{
+			Approval:  approvalToResponse(done),
+			Sequencer: h.sequencerToResponse(seq, net),
+		})
+	}
+}
+
+// Reject rejects a pending request
+// @Summary Reject an action
+// @Description Reject a dangerous action held for a second operator so that it can no longer be approved. The operator who requested it may
+// @Description withdraw it this way too.
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param approval path string true "Approval request ID"
+// @Success 200 {object} ApprovalResponse "Request rejected"
+// @Failure 404 {object} ErrorResponse "Approval request not found or approval disabled"
+// @Failure 409 {object} ErrorResponse "Request no longer pending"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /approvals/{approval} [delete]
+func (h *APIHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	if !h.approvalEnabled(w) {
+		return
+	}
+
+	id := chi.URLParam(r, "approval")
+	if existing, ok := h.approvals.Get(id); ok {
+		auditApproval(r, existing)
+	}
+
+	req, err := h.approvals.Reject(id, identityFromRequest(r))
+	if err != nil {
+		h.sendApprovalError(w, id, err)
+		return
+	}
+	auditApproval(r, req)
+
+	h.sendJSON(w, http.StatusOK, approvalToResponse(req))
+}
+
+// requestApproval holds an action on a sequencer for a second operator once
+// its preview, made with the same checks as the action, has passed
+func (h *APIHandler) requestApproval(w http.ResponseWriter, r *http.Request, name string, seq *sequencer.Sequencer, net *network.Network, body any, preview *action.Preview, err error) {
+	if err != nil {
+		h.sendActionError(w, fmt.Sprintf("Failed to request %s", name), err)
+		return
+	}
+
+	params, err := json.Marshal(body)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "Operation failed", err.Error())
+		return
+	}
+
+	req, err := h.approvals.Create(approval.Request{
+		Action:      name,
+		Network:     net.Name(),
+		Sequencer:   seq.ID(),
+		Params:      params,
+		Effect:      preview.Effect,
+		RequestedBy: identityFromRequest(r),
+	})
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "Operation failed", err.Error())
+		return
+	}
+	auditApproval(r, req)
+
+	h.sendJSON(w, http.StatusAccepted, PendingApprovalResponse{Approval: approvalToResponse(req)})
+}
+
+// sendApprovalError sends the response for a failed approval decision
+func (h *APIHandler) sendApprovalError(w http.ResponseWriter, id string, err error) {
+	switch {
+	case errors.Is(err, approval.ErrNotFound):
+		h.sendError(w, http.StatusNotFound, "Approval request not found",
+			fmt.Sprintf("Approval request '%s' does not exist", id))
+	case errors.Is(err, approval.ErrSelfApproval):
+		h.sendError(w, http.StatusForbidden, "Forbidden", err.Error())
+	case errors.Is(err, approval.ErrNotPending):
+		h.sendError(w, http.StatusConflict, "Invalid state", err.Error())
+	default:
+		h.sendError(w, http.StatusInternalServerError, "Operation failed", err.Error())
+	}
+}
+
+// identityFromRequest returns the authenticated caller as an approval
+// identity, so that an API token and a JWT user of the same name count as
+// different operators
+func identityFromRequest(r *http.Request) approval.Identity {
+	principal := principalFromRequest(r)
+	return approval.Identity{Name: principal.Name, Method: string(principal.Method)}
+}
+
+// approvalEnabled sends an error and returns false when approval is disabled
+func (h *APIHandler) approvalEnabled(w http.ResponseWriter) bool {
+	if h.approvals == nil {
+		h.sendError(w, http.StatusNotFound, "Approval disabled",
+			"Two-person approval is not enabled on this server")
+		return false
+	}
+	return true
+}
+
+// runApproved runs the action of an approved request
+func runApproved(ctx context.Context, seq *sequencer.Sequencer, req approval.Request) error {
+	switch req.Action {
+	case approval.ActionOverrideLeader:
+		var params OverrideLeaderRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return fmt.Errorf("invalid request parameters: %w", err)
+		}
+		return action.OverrideLeader(ctx, seq, params.Override)
+	case approval.ActionForceActive:
+		var params ForceActiveRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return fmt.Errorf("invalid request parameters: %w", err)
+		}
+		return action.ForceActive(ctx, seq, params.BlockHash)
+	default:
+		return fmt.Errorf("unknown action %q", req.Action)
+	}
+}
+
+func approvalToResponse(req approval.Request) ApprovalResponse {
+	resp := ApprovalResponse{
+		ID:                req.ID,
+		Action:            req.Action,
+		Network:           req.Network,
+		Sequencer:         req.Sequencer,
+		Request:           req.Params,
+		Effect:            req.Effect,
+		Status:            string(req.Status),
+		RequestedBy:       req.RequestedBy.Name,
+		RequestedByMethod: req.RequestedBy.Method,
+		RequestedAt:       req.RequestedAt,
+		ExpiresAt:         req.ExpiresAt,
+		ApprovedBy:        req.ApprovedBy.Name,
+		ApprovedByMethod:  req.ApprovedBy.Method,
+		RejectedBy:        req.RejectedBy.Name,
+		RejectedByMethod:  req.RejectedBy.Method,
+		Error:             req.Error,
+		Links: ApprovalLinks{
+			Self:    Link{Href: fmt.Sprintf("/api/v1/approvals/%s", req.ID)},
+			Network: Link{Href: fmt.Sprintf("/api/v1/networks/%s", req.Network)},
+		},
+	}
+	if !req.DecidedAt.IsZero() {
+		resp.DecidedAt = &req.DecidedAt
+	}
+	if req.Status == approval.StatusPending {
+		resp.Links.Approve = &Link{Href: resp.Links.Self.Href, Method: "POST"}
+		resp.Links.Reject = &Link{Href: resp.Links.Self.Href, Method: "DELETE"}
+	}
+	return resp
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/auth"
+)
+
+// newApprovalRouter mounts force-activation and the approval routes the way
+// the server does, audited, with approvals expiring after ttl
+func newApprovalRouter(t *testing.T, h *APIHandler, ttl time.Duration) http.Handler {
+	t.Helper()
+
+	auditLog, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	t.Cleanup(func() { auditLog.Close() })
+
+	h.audit = auditLog
+	h.approvals = approval.New(ttl)
+
+	viewer := h.Authorize(auth.RoleViewer)
+	admin := h.Authorize(auth.RoleAdmin)
+
+	r := chi.NewRouter()
+	r.Use(h.Authenticate)
+	r.With(h.Audit("force-active"), admin).Post("/sequencers/{id}/force-active", h.ForceActive)
+	r.With(viewer).Get("/approvals/{approval}", h.GetApproval)
+	r.With(h.Audit("approve"), admin).Post("/approvals/{approval}", h.Approve)
+	r.With(h.Audit("reject"), admin).Delete("/approvals/{approval}", h.Reject)
+	return r
+}
+
+// requestForceActive asks for sequencer-1 to be forced active and returns
+// the pending request
+func requestForceActive(t *testing.T, router http.Handler) ApprovalResponse {
+	t.Helper()
+
+	rec := serve(router, http.MethodPost, "/sequencers/sequencer-1/force-active", "admin-token", ForceActiveRequest{})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Force active = %d, want 202: %s", rec.Code, rec.Body.String())
+	}
+
+	resp := decode[PendingApprovalResponse](t, rec)
+	if resp.Approval.Status != string(approval.StatusPending) || resp.Approval.RequestedBy != "admin" ||
+		resp.Approval.RequestedByMethod != string(auth.MethodToken) {
+		t.Fatalf("Approval = %+v, want pending request by token admin", resp.Approval)
+	}
+	return resp.Approval
+}
+
+// auditEntries returns the recorded entries of an action, newest first
+func auditEntries(t *testing.T, h *APIHandler, action string) []audit.Entry {
+	t.Helper()
+
+	h.WaitAudit()
+	entries, _, err := h.audit.Query(audit.Filter{Action: action}, nil)
+	if err != nil {
+		t.Fatalf("Failed to query audit log: %v", err)
+	}
+	return entries
+}
+
+func TestApprove(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	router := newApprovalRouter(t, h, time.Hour)
+
+	pending := requestForceActive(t, router)
+
+	entries := auditEntries(t, h, "force-active")
+	if len(entries) != 1 || entries[0].Approval == nil || entries[0].Approval.Status != string(approval.StatusPending) {
+		t.Fatalf("Force active audit entries = %+v, want one for the pending request", entries)
+	}
+
+	// The requester cannot approve their own request
+	target := "/approvals/" + pending.ID
+	if rec := serve(router, http.MethodPost, target, "admin-token", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("Self-approval = %d, want 403: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(router, http.MethodGet, target, "admin-token", nil); decode[ApprovalResponse](t, rec).Status != string(approval.StatusPending) {
+		t.Errorf("Request after self-approval = %s, want it still pending", rec.Body.String())
+	}
+
+	rec := serve(router, http.MethodPost, target, "admin-2-token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Approval = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	resp := decode[ApprovedActionResponse](t, rec)
+	if resp.Approval.Status != string(approval.StatusExecuted) || resp.Approval.ApprovedBy != "admin-2" ||
+		resp.Approval.ApprovedByMethod != string(auth.MethodToken) {
+		t.Errorf("Approval = %+v, want executed and approved by admin-2", resp.Approval)
+	}
+
+	// The refused and the successful approval both name the requester
+	entries = auditEntries(t, h, "approve")
+	if len(entries) != 2 {
+		t.Fatalf("%d approve audit entries, want 2", len(entries))
+	}
+	denied, approved := entries[1], entries[0]
+	if denied.Result != audit.ResultDenied || denied.Actor != "admin" || denied.Approval == nil ||
+		denied.Approval.RequestedBy != "admin" || denied.Approval.Status != string(approval.StatusPending) {
+		t.Errorf("Self-approval audit entry = %+v, want denied pending request", denied)
+	}
+	want := audit.Approval{
+		ID:                pending.ID,
+		Action:            approval.ActionForceActive,
+		Status:            string(approval.StatusExecuted),
+		RequestedBy:       "admin",
+		RequestedByMethod: string(auth.MethodToken),
+		ApprovedBy:        "admin-2",
+		ApprovedByMethod:  string(auth.MethodToken),
+	}
+	if approved.Result != audit.ResultSuccess || approved.Approval == nil || *approved.Approval != want {
+		t.Errorf("Approval audit entry = %+v (approval %+v), want %+v", approved, approved.Approval, want)
+	}
+	if approved.Network != "devnet" || approved.Sequencer != "sequencer-1" || approved.Request == nil {
+		t.Errorf("Approval audit entry = %+v, want the request's network, sequencer and parameters", approved)
+	}
+
+	// A request runs once
+	if rec := serve(router, http.MethodPost, target, "admin-2-token", nil); rec.Code != http.StatusConflict {
+		t.Errorf("Second approval = %d, want 409", rec.Code)
+	}
+}
+
+func TestApprove_RechecksAction(t *testing.T) {
+	h, devnet := newTestHandler(t, testAuthConfig())
+	router := newApprovalRouter(t, h, time.Hour)
+
+	pending := requestForceActive(t, router)
+
+	// sequencer-1 became active while the request waited
+	devnet.Lock()
+	devnet.Sequencing = map[string]bool{"sequencer-1": true}
+	devnet.Unlock()
+	net, _ := h.app.GetNetwork(context.Background(), "devnet")
+	_ = net.Update(context.Background())
+
+	rec := serve(router, http.MethodPost, "/approvals/"+pending.ID, "admin-2-token", nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Approval = %d, want 409: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serve(router, http.MethodGet, "/approvals/"+pending.ID, "admin-token", nil)
+	if resp := decode[ApprovalResponse](t, rec); resp.Status != string(approval.StatusFailed) || resp.Error == "" {
+		t.Errorf("Request = %+v, want failed with the refusal", resp)
+	}
+
+	entries := auditEntries(t, h, "approve")
+	if len(entries) != 1 || entries[0].Result != audit.ResultFailure || entries[0].Approval == nil ||
+		entries[0].Approval.Status != string(approval.StatusFailed) {
+		t.Errorf("Approve audit entries = %+v, want the failed run", entries)
+	}
+}
+
+func TestReject(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	router := newApprovalRouter(t, h, time.Hour)
+
+	pending := requestForceActive(t, router)
+	target := "/approvals/" + pending.ID
+
+	rec := serve(router, http.MethodDelete, target, "admin-2-token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Rejection = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if resp := decode[ApprovalResponse](t, rec); resp.Status != string(approval.StatusRejected) || resp.RejectedBy != "admin-2" || resp.Links.Approve != nil {
+		t.Errorf("Request = %+v, want rejected by admin-2 without an approve link", resp)
+	}
+
+	if rec := serve(router, http.MethodPost, target, "admin-2-token", nil); rec.Code != http.StatusConflict {
+		t.Errorf("Approving a rejected request = %d, want 409", rec.Code)
+	}
+
+	entries := auditEntries(t, h, "reject")
+	if len(entries) != 1 || entries[0].Approval == nil || entries[0].Approval.RejectedBy != "admin-2" ||
+		entries[0].Approval.RequestedBy != "admin" || entries[0].Sequencer != "sequencer-1" {
+		t.Errorf("Reject audit entries = %+v, want the rejection of admin's request", entries)
+	}
+}
+
+func TestApprove_Expired(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	router := newApprovalRouter(t, h, 50*time.Millisecond)
+
+	pending := requestForceActive(t, router)
+	target := "/approvals/" + pending.ID
+
+	waitFor(t, func() bool {
+		rec := serve(router, http.MethodGet, target, "admin-token", nil)
+		return decode[ApprovalResponse](t, rec).Status == string(approval.StatusExpired)
+	}, "Request never expired")
+
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		if rec := serve(router, method, target, "admin-2-token", nil); rec.Code != http.StatusConflict {
+			t.Errorf("%s of an expired request = %d, want 409", method, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// maxAuditBody bounds the part of a request body kept in the audit log
+const maxAuditBody = 64 << 10
+
+// auditRefreshTimeout bounds the refresh of a network for the status after
+// an audited action
+const auditRefreshTimeout = 10 * time.Second
+
+// AuditLogResponse represents a page of audit entries, newest first
+type AuditLogResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Total   int                  `json:"total"`
+}
+
+// AuditEntryResponse represents a recorded control action in API responses
+type AuditEntryResponse struct {
+	ID         uint64                         `json:"id"`
+	Time       time.Time                      `json:"time"`
+	RequestID  string                         `json:"request_id,omitempty"`
+	Actor      string                         `json:"actor"`
+	AuthMethod string                         `json:"auth_method" example:"token"`
+	Action     string                         `json:"action" example:"pause"`
+	Network    string                         `json:"network,omitempty"`
+	Sequencer  string                         `json:"sequencer,omitempty"`
+	Request    json.RawMessage                `json:"request,omitempty" swaggertype:"object"`
+	Before     map[string]AuditStatusResponse `json:"before,omitempty"`
+	After      map[string]AuditStatusResponse `json:"after,omitempty"`
+	Result     string                         `json:"result" example:"success"`
+	Status     int                            `json:"status" example:"200"`
+	Error      string                         `json:"error,omitempty"`
+	DryRun     bool                           `json:"dry_run,omitempty"`
+	Approval   *AuditApprovalResponse         `json:"approval,omitempty"`
+}
+
+// AuditApprovalResponse represents the two-person approval of an audited
+// action
+type AuditApprovalResponse struct {
+	ID                string `json:"id"`
+	Action            string `json:"action" example:"force-active"`
+	Status            string `json:"status" example:"executed"`
+	RequestedBy       string `json:"requested_by"`
+	RequestedByMethod string `json:"requested_by_method" example:"oidc"`
+	ApprovedBy        string `json:"approved_by,omitempty"`
+	ApprovedByMethod  string `json:"approved_by_method,omitempty" example:"token"`
+	RejectedBy        string `json:"rejected_by,omitempty"`
+	RejectedByMethod  string `json:"rejected_by_method,omitempty"`
+}
+
+// AuditStatusResponse represents a sequencer status recorded in the audit log
+type AuditStatusResponse struct {
+	ConductorActive  bool   `json:"conductor_active"`
+	ConductorLeader  bool   `json:"conductor_leader"`
+	ConductorPaused  bool   `json:"conductor_paused"`
+	ConductorStopped bool   `json:"conductor_stopped"`
+	SequencerHealthy bool   `json:"sequencer_healthy"`
+	SequencerActive  bool   `json:"sequencer_active"`
+	UnsafeL2         uint64 `json:"unsafe_l2"`
+}
+
+// Audit is middleware that records a mutating request in the audit log,
+// together with the status of the addressed network's sequencers before and
+// after it. It must run before Authorize so that denied requests are recorded
+// as well. The status after the request is probed once the response has been
+// sent, so that the caller does not wait for it.
+func (h *APIHandler) Audit(action string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if h.audit == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := principalFromRequest(r)
+
+			entry := &audit.Entry{
+				RequestID:  middleware.GetReqID(r.Context()),
+				Actor:      principal.Name,
+				AuthMethod: string(principal.Method),
+				Action:     action,
+				Sequencer:  chi.URLParam(r, "id"),
+			}
+			r = r.WithContext(context.WithValue(r.Context(), auditEntryKey{}, entry))
+
+			// Keep a copy of the body while leaving it readable for the handler
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditBody))
+			if err == nil && json.Valid(body) {
+				entry.Request = json.RawMessage(body)
+			}
+			r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+			networkName, _ := h.requestNetwork(r)
+			entry.Network = networkName
+
+			net := h.auditNetwork(r.Context(), networkName)
+			if net != nil {
+				entry.Before = snapshotNetwork(net)
+			}
+
+			var response bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&response)
+
+			next.ServeHTTP(ww, r)
+
+			entry.Time = time.Now().UTC()
+			entry.Status = ww.Status()
+			switch {
+			case entry.Status == http.StatusForbidden:
+				entry.Result = audit.ResultDenied
+			case entry.Status >= http.StatusBadRequest:
+				entry.Result = audit.ResultFailure
+			default:
+				entry.Result = audit.ResultSuccess
+			}
+			entry.Error = responseError(entry.Status, response.Bytes())
+			entry.DryRun = responseDryRun(response.Bytes())
+
+			// Denied requests and dry runs never changed a sequencer
+			if net == nil || entry.Result == audit.ResultDenied || entry.DryRun {
+				h.appendAudit(entry)
+				return
+			}
+
+			ctx := context.WithoutCancel(r.Context())
+			h.auditing.Add(1)
+			go func() {
+				defer h.auditing.Done()
+
+				ctx, cancel := context.WithTimeout(ctx, auditRefreshTimeout)
+				defer cancel()
+
+				if err := net.Update(ctx); err != nil {
+					h.logger.Warn("Failed to refresh network for audit log",
+						slog.String("network", networkName),
+						slog.String("error", err.Error()))
+				}
+				entry.After = snapshotNetwork(net)
+				h.appendAudit(entry)
+			}()
+		})
+	}
+}
+
+// appendAudit records a completed audit entry
+func (h *APIHandler) appendAudit(entry *audit.Entry) {
+	if err := h.audit.Append(entry); err != nil {
+		h.logger.Error("Failed to record audit entry",
+			slog.String("action", entry.Action),
+			slog.String("actor", entry.Actor),
+			slog.String("error", err.Error()))
+	}
+}
+
+// auditEntryKey is the context key of the entry the Audit middleware is
+// recording for a request
+type auditEntryKey struct{}
+
+// auditApproval adds an approval request to the audit entry of r, if it is
+// being recorded. Approving or rejecting a request has no sequencer in its
+// path or action in its body, they are taken from the request.
+func auditApproval(r *http.Request, req approval.Request) {
+	entry, ok := r.Context().Value(auditEntryKey{}).(*audit.Entry)
+	if !ok {
+		return
+	}
+
+	entry.Approval = &audit.Approval{
+		ID:                req.ID,
+		Action:            req.Action,
+		Status:            string(req.Status),
+		RequestedBy:       req.RequestedBy.Name,
+		RequestedByMethod: req.RequestedBy.Method,
+		ApprovedBy:        req.ApprovedBy.Name,
+		ApprovedByMethod:  req.ApprovedBy.Method,
+		RejectedBy:        req.RejectedBy.Name,
+		RejectedByMethod:  req.RejectedBy.Method,
+	}
+	if entry.Sequencer == "" {
+		entry.Sequencer = req.Sequencer
+	}
+	if entry.Request == nil {
+		entry.Request = req.Params
+	}
+}
+
+// AuditLog returns recorded control actions
+// @Summary Query the audit log
+// @Description Get recorded control actions, newest first. Entries for networks the caller cannot view are omitted.
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param actor query string false "Principal that performed the action"
+// @Param action query string false "Action name, e.g. pause or force-active"
+// @Param network query string false "Network name"
+// @Param sequencer query string false "Sequencer ID"
+// @Param result query string false "Result (success, failure, denied)"
+// @Param since query string false "Only entries at or after this time (RFC 3339)"
+// @Param until query string false "Only entries before this time (RFC 3339)"
+// @Param limit query int false "Maximum number of entries (default 100, max 1000)"
+// @Success 200 {object} AuditLogResponse "Audit entries"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 404 {object} ErrorResponse "Audit log disabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *APIHandler) AuditLog(w http.ResponseWriter, r *http.Request) {
+	if h.audit == nil {
+		h.sendError(w, http.StatusNotFound, "Audit log disabled",
+			"No audit log is configured on this server")
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid filter", err.Error())
+		return
+	}
+
+	principal := principalFromRequest(r)
+	entries, total, err := h.audit.Query(filter, func(e *audit.Entry) bool {
+		return h.canView(principal, e.Network)
+	})
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "Failed to query audit log", err.Error())
+		return
+	}
+
+	resp := AuditLogResponse{
+		Entries: make([]AuditEntryResponse, 0, len(entries)),
+		Total:   total,
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, auditEntryToResponse(e))
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// parseAuditFilter reads audit log filters from the query string
+func parseAuditFilter(r *http.Request) (audit.Filter, error) {
+	query := r.URL.Query()
+
+	filter := audit.Filter{
+		Actor:     query.Get("actor"),
+		Action:    query.Get("action"),
+		Network:   query.Get("network"),
+		Sequencer: query.Get("sequencer"),
+		Result:    query.Get("result"),
+	}
+
+	switch filter.Result {
+	case "", audit.ResultSuccess, audit.ResultFailure, audit.ResultDenied:
+	default:
+		return filter, fmt.Errorf("result must be one of %s, %s or %s",
+			audit.ResultSuccess, audit.ResultFailure, audit.ResultDenied)
+	}
+
+	for name, field := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 time: %w", name, err)
+		}
+		*field = t
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("limit must be a positive integer")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// auditNetwork returns the network a request addresses, or nil if there is
+// none or it does not exist
+func (h *APIHandler) auditNetwork(ctx context.Context, networkName string) *network.Network {
+	if networkName == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	net, err := h.app.GetNetwork(ctx, networkName)
+	if err != nil {
+		return nil
+	}
+	return net
+}
+
+func auditEntryToResponse(e audit.Entry) AuditEntryResponse {
+	resp := AuditEntryResponse{
+		ID:         e.ID,
+		Time:       e.Time,
+		RequestID:  e.RequestID,
+		Actor:      e.Actor,
+		AuthMethod: e.AuthMethod,
+		Action:     e.Action,
+		Network:    e.Network,
+		Sequencer:  e.Sequencer,
+		Request:    e.Request,
+		Before:     auditStatusesToResponse(e.Before),
+		After:      auditStatusesToResponse(e.After),
+		Result:     e.Result,
+		Status:     e.Status,
+		Error:      e.Error,
+		DryRun:     e.DryRun,
+	}
+	if e.Approval != nil {
+		record := AuditApprovalResponse(*e.Approval)
+		resp.Approval = &record
+	}
+	return resp
+}
+
+func auditStatusesToResponse(snapshots map[string]audit.Snapshot) map[string]AuditStatusResponse {
+	if snapshots == nil {
+		return nil
+	}
+
+	resp := make(map[string]AuditStatusResponse, len(snapshots))
+	for id, s := range snapshots {
+		resp[id] = AuditStatusResponse(s)
+	}
+	return resp
+}
+
+// snapshotNetwork captures the status of every sequencer in a network
+func snapshotNetwork(net *network.Network) map[string]audit.Snapshot {
+	snapshots := make(map[string]audit.Snapshot, len(net.Sequencers()))
+	for _, seq := range net.Sequencers() {
+		snapshots[seq.ID()] = audit.SnapshotOf(seq.Status())
+	}
+	return snapshots
+}
+
+// responseError extracts the error reported in a failed response body
+func responseError(status int, body []byte) string {
+	if status < http.StatusBadRequest {
+		return ""
+	}
+
+	var resp struct {
+		Detail string `json:"detail"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return http.StatusText(status)
+	}
+
+	switch {
+	case resp.Detail != "":
+		return resp.Detail
+	case resp.Error != "":
+		return resp.Error
+	default:
+		return http.StatusText(status)
+	}
+}
+
+// responseDryRun reports whether a response describes a dry run instead of
+// the outcome of an action
+func responseDryRun(body []byte) bool {
+	var resp struct {
+		DryRun bool `json:"dry_run"`
+	}
+	return json.Unmarshal(body, &resp) == nil && resp.DryRun
+}
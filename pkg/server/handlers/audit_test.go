@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/auth"
+)
+
+func TestAudit_RecordsStatusAfterResponse(t *testing.T) {
+	h, devnet := newTestHandler(t, testAuthConfig())
+
+	auditLog, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	t.Cleanup(func() { auditLog.Close() })
+	h.audit = auditLog
+
+	// The action itself sends no RPC, only the refresh after it does
+	r := chi.NewRouter()
+	r.Use(h.Authenticate)
+	r.With(h.Audit("pause"), h.Authorize(auth.RoleOperator)).Post("/sequencers/{id}/pause", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Hold every RPC of devnet so that the refresh cannot complete
+	devnet.Lock()
+	locked := true
+	t.Cleanup(func() {
+		if locked {
+			devnet.Unlock()
+		}
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		done <- serve(r, http.MethodPost, "/sequencers/sequencer-0/pause", "operator-token", nil).Code
+	}()
+
+	select {
+	case code := <-done:
+		if code != http.StatusNoContent {
+			t.Errorf("Pause = %d, want 204", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Response waited for the network refresh")
+	}
+
+	devnet.Unlock()
+	locked = false
+	h.WaitAudit()
+
+	entries, _, err := h.audit.Query(audit.Filter{Action: "pause"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to query audit log: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Result != audit.ResultSuccess || len(entries[0].After) != 2 {
+		t.Errorf("Audit entries = %+v, want the pause with the status of both devnet sequencers after it", entries)
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+
+	"github.com/golem-base/seqctl/pkg/auth"
+)
+
+// QueryToken is middleware for the token browsers pass in the access_token
+// query parameter, since they cannot set headers on WebSocket upgrades or
+// event streams. It moves the token of such a request into the Authorization
+// header and drops the parameter from every URL. It must run ahead of request
+// logging so that tokens never end up in the logs.
+func QueryToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if !query.Has("access_token") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := query.Get("access_token")
+		query.Del("access_token")
+
+		r = r.Clone(r.Context())
+		r.URL.RawQuery = query.Encode()
+		r.RequestURI = r.URL.RequestURI()
+		if r.Header.Get("Authorization") == "" && (websocket.IsWebSocketUpgrade(r) || isEventStream(r)) {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Authenticate is middleware that resolves the caller from the Authorization
+// header and attaches the principal to the request context. Tokens passed in
+// the query string are moved into the header by QueryToken beforehand.
+func (h *APIHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credential := auth.BearerToken(r.Header.Get("Authorization"))
+
+		principal, err := h.auth.Authenticate(r.Context(), credential)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="seqctl"`)
+			h.sendError(w, http.StatusUnauthorized, "Unauthorized", err.Error())
+			return
+		}
+
+		if principal.Method != auth.MethodAnonymous {
+			h.logger.Debug("Request authenticated",
+				slog.String("principal", principal.Name),
+				slog.String("method", string(principal.Method)),
+				slog.String("path", r.URL.Path))
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// Authorize is middleware that requires the caller to hold at least the given
+// role. Routes addressing a network or a sequencer are checked against the
+// grants for that network, before the handler touches any sequencer; other
+// routes only require the role on some network and filter their results.
+func (h *APIHandler) Authorize(required auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := principalFromRequest(r)
+
+			var role auth.Role
+			if networkName, scoped := h.requestNetwork(r); scoped {
+				role = h.auth.RoleFor(principal, networkName)
+			} else {
+				role = h.auth.MaxRole(principal)
+			}
+
+			if role < required {
+				h.sendError(w, http.StatusForbidden, "Forbidden",
+					fmt.Sprintf("Role %s is required, %s has %s", required, principal.Name, role))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestNetwork returns the network a request addresses, if any, directly or
+// through a sequencer or an approval request. Unknown sequencers and approval
+// requests resolve to an empty network name, which only matches grants that
+// apply to all networks, so that existence is not revealed to other callers.
+func (h *APIHandler) requestNetwork(r *http.Request) (string, bool) {
+	if networkName := chi.URLParam(r, "network"); networkName != "" {
+		return networkName, true
+	}
+
+	if sequencerID := chi.URLParam(r, "id"); sequencerID != "" {
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+		defer cancel()
+
+		_, net, err := h.getSequencer(ctx, sequencerID)
+		if err != nil {
+			return "", true
+		}
+		return net.Name(), true
+	}
+
+	if approvalID := chi.URLParam(r, "approval"); approvalID != "" {
+		if h.approvals == nil {
+			return "", true
+		}
+		req, _ := h.approvals.Get(approvalID)
+		return req.Network, true
+	}
+
+	return "", false
+}
+
+// canView reports whether the principal may see a network
+func (h *APIHandler) canView(principal *auth.Principal, networkName string) bool {
+	return h.auth.RoleFor(principal, networkName) >= auth.RoleViewer
+}
+
+// principalFromRequest returns the authenticated caller, falling back to the
+// anonymous principal for routes outside the Authenticate middleware
+func principalFromRequest(r *http.Request) *auth.Principal {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		return principal
+	}
+	return auth.Anonymous
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	slogchi "github.com/samber/slog-chi"
+
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/config"
+)
+
+// newAuthRouter mounts a route of each group behind the role the server
+// requires for it. Handlers only report success, so that the response status
+// is decided by authentication and authorization alone.
+func newAuthRouter(h *APIHandler) http.Handler {
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }
+	viewer := h.Authorize(auth.RoleViewer)
+	operator := h.Authorize(auth.RoleOperator)
+	admin := h.Authorize(auth.RoleAdmin)
+
+	r := chi.NewRouter()
+	r.Use(h.Authenticate)
+
+	r.With(viewer).Get("/networks", ok)
+	r.With(viewer).Get("/networks/{network}", ok)
+	r.With(operator).Post("/networks/{network}/handover", ok)
+	r.With(admin).Post("/networks/{network}/membership/apply", ok)
+
+	r.With(viewer).Get("/sequencers/{id}/history", ok)
+	r.With(operator).Post("/sequencers/{id}/pause", ok)
+	r.With(admin).Post("/sequencers/{id}/halt", ok)
+
+	r.With(viewer).Get("/approvals/{approval}", ok)
+	r.With(admin).Post("/approvals/{approval}", ok)
+
+	r.With(viewer).Get("/audit", ok)
+	r.With(operator).Post("/alerts/silences", ok)
+
+	return r
+}
+
+func TestAuthorize(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	h.approvals = approval.New(time.Hour)
+
+	devnetRequest, err := h.approvals.Create(approval.Request{Action: approval.ActionForceActive, Network: "devnet", Sequencer: "sequencer-1", RequestedBy: approval.Identity{Name: "admin", Method: "token"}})
+	if err != nil {
+		t.Fatalf("Failed to create approval request: %v", err)
+	}
+	testnetRequest, err := h.approvals.Create(approval.Request{Action: approval.ActionForceActive, Network: "testnet", Sequencer: "testnet-0", RequestedBy: approval.Identity{Name: "admin", Method: "token"}})
+	if err != nil {
+		t.Fatalf("Failed to create approval request: %v", err)
+	}
+
+	router := newAuthRouter(h)
+
+	// Status per caller for each route: viewer, operator, admin. All of them
+	// are bound to devnet only.
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   [3]int
+	}{
+		{"list networks", http.MethodGet, "/networks", [3]int{204, 204, 204}},
+		{"view network", http.MethodGet, "/networks/devnet", [3]int{204, 204, 204}},
+		{"view other network", http.MethodGet, "/networks/testnet", [3]int{403, 403, 403}},
+		{"hand over", http.MethodPost, "/networks/devnet/handover", [3]int{403, 204, 204}},
+		{"hand over other network", http.MethodPost, "/networks/testnet/handover", [3]int{403, 403, 403}},
+		{"apply membership", http.MethodPost, "/networks/devnet/membership/apply", [3]int{403, 403, 204}},
+
+		{"sequencer history", http.MethodGet, "/sequencers/sequencer-0/history", [3]int{204, 204, 204}},
+		{"pause sequencer", http.MethodPost, "/sequencers/sequencer-0/pause", [3]int{403, 204, 204}},
+		{"halt sequencer", http.MethodPost, "/sequencers/sequencer-0/halt", [3]int{403, 403, 204}},
+		{"other network's sequencer", http.MethodPost, "/sequencers/testnet-0/pause", [3]int{403, 403, 403}},
+		{"unknown sequencer", http.MethodGet, "/sequencers/sequencer-9/history", [3]int{403, 403, 403}},
+
+		{"view approval", http.MethodGet, "/approvals/" + devnetRequest.ID, [3]int{204, 204, 204}},
+		{"approve", http.MethodPost, "/approvals/" + devnetRequest.ID, [3]int{403, 403, 204}},
+		{"other network's approval", http.MethodPost, "/approvals/" + testnetRequest.ID, [3]int{403, 403, 403}},
+		{"unknown approval", http.MethodGet, "/approvals/unknown", [3]int{403, 403, 403}},
+
+		{"audit log", http.MethodGet, "/audit", [3]int{204, 204, 204}},
+		{"silence alerts", http.MethodPost, "/alerts/silences", [3]int{403, 204, 204}},
+	}
+
+	tokens := [3]string{"viewer-token", "operator-token", "admin-token"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, token := range tokens {
+				if rec := serve(router, tt.method, tt.target, token, nil); rec.Code != tt.want[i] {
+					t.Errorf("%s %s as %s = %d, want %d", tt.method, tt.target, token, rec.Code, tt.want[i])
+				}
+			}
+
+			if rec := serve(router, tt.method, tt.target, "", nil); rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s without a token = %d, want 401", tt.method, tt.target, rec.Code)
+			}
+			if rec := serve(router, tt.method, tt.target, "wrong-token", nil); rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s with an invalid token = %d, want 401", tt.method, tt.target, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAuthorize_Disabled(t *testing.T) {
+	h, _ := newTestHandler(t, config.AuthConfig{})
+	router := newAuthRouter(h)
+
+	// Without authentication every caller is an admin
+	for _, target := range []string{"/networks/testnet/membership/apply", "/sequencers/testnet-0/halt"} {
+		if rec := serve(router, http.MethodPost, target, "", nil); rec.Code != http.StatusNoContent {
+			t.Errorf("POST %s = %d, want 204", target, rec.Code)
+		}
+	}
+}
+
+func TestRequestNetwork(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	h.approvals = approval.New(time.Hour)
+
+	req, err := h.approvals.Create(approval.Request{Action: approval.ActionForceActive, Network: "testnet", Sequencer: "testnet-0", RequestedBy: approval.Identity{Name: "admin", Method: "token"}})
+	if err != nil {
+		t.Fatalf("Failed to create approval request: %v", err)
+	}
+
+	var got struct {
+		network string
+		scoped  bool
+	}
+	record := func(_ http.ResponseWriter, r *http.Request) {
+		got.network, got.scoped = h.requestNetwork(r)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/networks/{network}", record)
+	r.Get("/sequencers/{id}", record)
+	r.Get("/approvals/{approval}", record)
+	r.Get("/audit", record)
+
+	tests := []struct {
+		target  string
+		network string
+		scoped  bool
+	}{
+		{"/networks/testnet", "testnet", true},
+		{"/sequencers/testnet-0", "testnet", true},
+		{"/sequencers/sequencer-9", "", true},
+		{"/approvals/" + req.ID, "testnet", true},
+		{"/approvals/unknown", "", true},
+		{"/audit", "", false},
+	}
+
+	for _, tt := range tests {
+		serve(r, http.MethodGet, tt.target, "", nil)
+		if got.network != tt.network || got.scoped != tt.scoped {
+			t.Errorf("requestNetwork(%s) = %q, %t, want %q, %t", tt.target, got.network, got.scoped, tt.network, tt.scoped)
+		}
+	}
+}
+
+func TestQueryToken_KeepsTokensOutOfLogs(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	var leaked bool
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = leaked || r.URL.Query().Has("access_token") || strings.Contains(r.RequestURI, "access_token")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	router := QueryToken(slogchi.New(logger)(h.Authenticate(h.Authorize(auth.RoleViewer)(ok))))
+
+	tests := []struct {
+		name   string
+		accept string
+		want   int
+	}{
+		{"event stream", "text/event-stream", http.StatusNoContent},
+		{"other request", "application/json", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+
+			req := httptest.NewRequest(http.MethodGet, "/events/stream?type=leader_changed&access_token=viewer-token", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("Request = %d, want %d", rec.Code, tt.want)
+			}
+			if logs.Len() == 0 {
+				t.Fatal("Request was not logged")
+			}
+			if strings.Contains(logs.String(), "viewer-token") {
+				t.Errorf("Logs contain the token: %s", logs.String())
+			}
+		})
+	}
+
+	if leaked {
+		t.Error("Handler saw the token in the URL")
+	}
+}
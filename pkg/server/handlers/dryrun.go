@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/golem-base/seqctl/pkg/action"
+)
+
+// DryRunResponse describes what an action would do. Action routes return it
+// instead of acting when called with dry_run=true.
+type DryRunResponse struct {
+	DryRun bool              `json:"dry_run"`
+	Calls  []RPCCallResponse `json:"calls"`
+	Effect string            `json:"effect"`
+}
+
+// RPCCallResponse represents an RPC an action would send
+type RPCCallResponse struct {
+	Sequencer string `json:"sequencer"`
+	Endpoint  string `json:"endpoint" example:"conductor"`
+	URL       string `json:"url"`
+	Method    string `json:"method" example:"conductor_pause"`
+	Params    []any  `json:"params" swaggertype:"array,object"`
+}
+
+// parseDryRun reads the dry_run query parameter, returning def if it is
+// absent
+func parseDryRun(r *http.Request, def bool) (bool, error) {
+	value := r.URL.Query().Get("dry_run")
+	if value == "" {
+		return def, nil
+	}
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("dry_run must be true or false")
+	}
+	return dryRun, nil
+}
+
+// sendPreview responds with the preview of an action, or with the error its
+// checks returned
+func (h *APIHandler) sendPreview(w http.ResponseWriter, operation string, preview *action.Preview, err error) {
+	if err != nil {
+		h.sendActionError(w, operation, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, NewDryRunResponse(preview))
+}
+
+// NewDryRunResponse converts an action preview to its API form
+func NewDryRunResponse(preview *action.Preview) DryRunResponse {
+	resp := DryRunResponse{
+		DryRun: true,
+		Calls:  make([]RPCCallResponse, 0, len(preview.Calls)),
+		Effect: preview.Effect,
+	}
+	for _, call := range preview.Calls {
+		resp.Calls = append(resp.Calls, RPCCallResponse(call))
+	}
+	return resp
+}
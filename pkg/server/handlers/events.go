@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/events"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// eventStreamKeepalive is how often a comment is sent on an idle event stream
+// so that proxies keep the connection open and disconnects are noticed
+const eventStreamKeepalive = 15 * time.Second
+
+// EventsResponse represents a page of network events, newest first
+type EventsResponse struct {
+	Events []EventResponse `json:"events"`
+	Total  int             `json:"total"`
+}
+
+// EventResponse represents a network event in API responses
+type EventResponse struct {
+	ID        uint64    `json:"id"`
+	Type      string    `json:"type" example:"leader_changed"`
+	Time      time.Time `json:"time"`
+	Network   string    `json:"network"`
+	Sequencer string    `json:"sequencer,omitempty"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// Events returns recent network events
+// @Summary Query network events
+// @Description Get recent events derived from sequencer status changes, newest first: leader changes, sequencers starting or stopping, conductor pauses, health changes, stalled unsafe heads and membership changes. Events of networks the caller cannot view are omitted.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param type query []string false "Event types, e.g. leader_changed (default: all)" collectionFormat(csv)
+// @Param network query string false "Network name"
+// @Param sequencer query string false "Sequencer ID"
+// @Param since query string false "Only events at or after this time (RFC 3339)"
+// @Param until query string false "Only events before this time (RFC 3339)"
+// @Param limit query int false "Maximum number of events (default 100, max 1000)"
+// @Success 200 {object} EventsResponse "Events"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /events [get]
+func (h *APIHandler) Events(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid filter", err.Error())
+		return
+	}
+
+	principal := principalFromRequest(r)
+	entries, total := h.events.Query(filter, func(e *events.Entry) bool {
+		return h.canView(principal, e.Network)
+	})
+
+	resp := EventsResponse{
+		Events: make([]EventResponse, 0, len(entries)),
+		Total:  total,
+	}
+	for _, e := range entries {
+		resp.Events = append(resp.Events, eventToResponse(e))
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// EventStream streams network events as server-sent events
+// @Summary Stream network events
+// @Description Stream events as they are detected using server-sent events. Each event is sent with its ID, its type as the event name and an EventResponse as data.
+// @Description Clients resuming with the Last-Event-ID header, or the after parameter, first receive the retained events they missed.
+// @Description Browsers may pass the token in the access_token query parameter.
+// @Tags Events
+// @Produce text/event-stream
+// @Param type query []string false "Event types, e.g. leader_changed (default: all)" collectionFormat(csv)
+// @Param network query string false "Network name"
+// @Param sequencer query string false "Sequencer ID"
+// @Param after query int false "Resume after this event ID, overridden by the Last-Event-ID header"
+// @Success 200 {object} EventResponse "Event stream"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /events/stream [get]
+func (h *APIHandler) EventStream(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid filter", err.Error())
+		return
+	}
+	// The time range and limit only apply to queries
+	filter.Since, filter.Until, filter.Limit = time.Time{}, time.Time{}, 0
+
+	after, err := parseLastEventID(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid filter", err.Error())
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("Failed to clear write deadline for event stream", slog.String("error", err.Error()))
+	}
+
+	replay, entries, cancel := h.events.Subscribe(after)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	principal := principalFromRequest(r)
+	send := func(e events.Entry) error {
+		if !filter.Matches(&e) || !h.canView(principal, e.Network) {
+			return nil
+		}
+		data, err := json.Marshal(eventToResponse(e))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+		return err
+	}
+
+	for _, e := range replay {
+		if err := send(e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepalive := time.NewTicker(eventStreamKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-entries:
+			if !ok {
+				// Dropped for falling behind, the client resumes from its last ID
+				h.logger.Warn("Event stream client too slow, disconnecting",
+					slog.String("remote", r.RemoteAddr))
+				return
+			}
+			if err := send(e); err != nil {
+				return
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// parseEventFilter reads event filters from the query string
+func parseEventFilter(r *http.Request) (events.Filter, error) {
+	query := r.URL.Query()
+
+	filter := events.Filter{
+		Network:   query.Get("network"),
+		Sequencer: query.Get("sequencer"),
+	}
+
+	for _, value := range query["type"] {
+		for _, name := range strings.Split(value, ",") {
+			typ := network.EventType(strings.TrimSpace(name))
+			if typ == "" {
+				continue
+			}
+			if !slices.Contains(network.EventTypes, typ) {
+				return filter, fmt.Errorf("unknown event type %q", typ)
+			}
+			filter.Types = append(filter.Types, typ)
+		}
+	}
+
+	for name, field := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 time: %w", name, err)
+		}
+		*field = t
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("limit must be a positive integer")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// parseLastEventID returns the ID a stream resumes after, zero for a new stream
+func parseLastEventID(r *http.Request) (uint64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("after")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("last event ID must be a non-negative integer")
+	}
+	return id, nil
+}
+
+// isEventStream reports whether a request asks for server-sent events
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func eventToResponse(e events.Entry) EventResponse {
+	return EventResponse{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		Time:      e.Time,
+		Network:   e.Network,
+		Sequencer: e.Sequencer,
+		From:      e.From,
+		To:        e.To,
+		Message:   e.Message,
+	}
+}
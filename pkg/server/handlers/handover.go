@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/handover"
+)
+
+// maxHandoverTimeout bounds the requested timeout so that the handover and
+// its rollback finish within the router's request timeout
+const maxHandoverTimeout = 30 * time.Second
+
+// HandoverRequest represents the request body for a guided handover
+type HandoverRequest struct {
+	TargetID       string `json:"target_id,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+}
+
+// HandoverResponse represents the outcome of a guided handover
+type HandoverResponse struct {
+	Network        string         `json:"network"`
+	From           string         `json:"from"`
+	To             string         `json:"to"`
+	Success        bool           `json:"success"`
+	RolledBack     bool           `json:"rolled_back"`
+	Error          string         `json:"error,omitempty"`
+	UnsafeL2Before uint64         `json:"unsafe_l2_before"`
+	UnsafeL2After  uint64         `json:"unsafe_l2_after,omitempty"`
+	Steps          []HandoverStep `json:"steps"`
+	Links          NetworkLinks   `json:"_links"`
+}
+
+// HandoverStep represents one step of a guided handover
+type HandoverStep struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Detail     string    `json:"detail,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// Handover performs a guided leader handover
+// @Summary Guided leader handover
+// @Description Hand leadership over to a healthy, caught-up voter. The target is picked automatically unless
+// @Description target_id is given. The call blocks until the target is conductor leader and active sequencer
+// @Description and its unsafe head advances; on timeout leadership is handed back to the original leader.
+// @Tags Actions
+// @Accept json
+// @Produce json
+// @Param network path string true "Network name"
+// @Param dry_run query bool false "Select the target and describe the transfer instead of making it. Read-only RPCs still probe every sequencer of the network to select the target"
+// @Param request body HandoverRequest false "Optional target and timeout"
+// @Success 200 {object} HandoverResponse "Handover completed"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 409 {object} ErrorResponse "Network has no leader"
+// @Failure 422 {object} ErrorResponse "No eligible target"
+// @Failure 500 {object} HandoverResponse "Transfer failed"
+// @Failure 504 {object} HandoverResponse "Handover timed out"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /networks/{network}/handover [post]
+func (h *APIHandler) Handover(w http.ResponseWriter, r *http.Request) {
+	networkName := chi.URLParam(r, "network")
+
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
+	var req HandoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	timeout := handover.DefaultTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = min(time.Duration(req.TimeoutSeconds)*time.Second, maxHandoverTimeout)
+	}
+
+	// The handover outlives the server's default write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(2 * maxHandoverTimeout)); err != nil {
+		h.logger.Warn("Failed to extend write deadline", "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	net, err := h.app.GetNetwork(ctx, networkName)
+	cancel()
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Network not found",
+			fmt.Sprintf("Network '%s' does not exist", networkName))
+		return
+	}
+
+	opts := handover.Options{
+		TargetID: req.TargetID,
+		Timeout:  timeout,
+	}
+
+	var (
+		result  *handover.Result
+		preview *action.Preview
+	)
+	if dryRun {
+		preview, err = handover.Preview(r.Context(), net, opts)
+	} else {
+		result, err = handover.Run(r.Context(), net, opts)
+	}
+
+	switch {
+	case errors.Is(err, handover.ErrNoLeader):
+		h.sendError(w, http.StatusConflict, "Invalid state", err.Error())
+		return
+	case errors.Is(err, handover.ErrInvalidTarget), errors.Is(err, handover.ErrNoCandidate):
+		h.sendError(w, http.StatusUnprocessableEntity, "Validation failed", err.Error())
+		return
+	}
+
+	if dryRun {
+		h.sendJSON(w, http.StatusOK, NewDryRunResponse(preview))
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case errors.Is(err, handover.ErrHandoverTimeout):
+		status = http.StatusGatewayTimeout
+	case err != nil:
+		status = http.StatusInternalServerError
+	}
+
+	h.sendJSON(w, status, handoverToResponse(result, err))
+}
+
+func handoverToResponse(result *handover.Result, err error) HandoverResponse {
+	steps := make([]HandoverStep, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		steps = append(steps, HandoverStep{
+			Name:       step.Name,
+			Status:     step.Status,
+			Detail:     step.Detail,
+			StartedAt:  step.Started,
+			DurationMs: step.Duration.Milliseconds(),
+		})
+	}
+
+	resp := HandoverResponse{
+		Network:        result.Network,
+		From:           result.From,
+		To:             result.To,
+		Success:        result.Success,
+		RolledBack:     result.RolledBack,
+		UnsafeL2Before: result.UnsafeL2Before,
+		UnsafeL2After:  result.UnsafeL2After,
+		Steps:          steps,
+		Links:          networkLinks(result.Network),
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/history"
+)
+
+// defaultHistoryRange is the time range returned when since is not given
+const defaultHistoryRange = time.Hour
+
+// HistoryResponse represents the status history of a network or sequencer
+// over a time range
+type HistoryResponse struct {
+	Network     string                    `json:"network"`
+	Sequencer   string                    `json:"sequencer,omitempty"`
+	Since       time.Time                 `json:"since"`
+	Until       time.Time                 `json:"until"`
+	Initial     []HistorySnapshotResponse `json:"initial"`
+	Transitions []TransitionResponse      `json:"transitions"`
+	Total       int                       `json:"total"`
+}
+
+// HistorySnapshotResponse represents the last recorded state of a sequencer
+// before the start of the range
+type HistorySnapshotResponse struct {
+	Time      time.Time            `json:"time"`
+	Sequencer string               `json:"sequencer"`
+	State     HistoryStateResponse `json:"state"`
+}
+
+// HistoryStateResponse represents a recorded sequencer state
+type HistoryStateResponse struct {
+	ConductorActive  bool   `json:"conductor_active"`
+	ConductorLeader  bool   `json:"conductor_leader"`
+	ConductorPaused  bool   `json:"conductor_paused"`
+	ConductorStopped bool   `json:"conductor_stopped"`
+	SequencerHealthy bool   `json:"sequencer_healthy"`
+	SequencerActive  bool   `json:"sequencer_active"`
+	Reachability     string `json:"reachability" example:"reachable"`
+	UnsafeL2         uint64 `json:"unsafe_l2"`
+	SafeL2           uint64 `json:"safe_l2"`
+}
+
+// TransitionResponse represents a change of one sequencer field
+type TransitionResponse struct {
+	Time      time.Time `json:"time"`
+	Sequencer string    `json:"sequencer"`
+	Field     string    `json:"field" example:"conductor_leader"`
+	From      string    `json:"from" example:"false"`
+	To        string    `json:"to" example:"true"`
+}
+
+// NetworkHistory returns the status history of a network
+// @Summary Get network status history
+// @Description Get the leadership, activity, health and reachability transitions of a network's sequencers over a time range, oldest first, along with each sequencer's last recorded state before the range
+// @Tags Networks
+// @Accept json
+// @Produce json
+// @Param network path string true "Network name"
+// @Param since query string false "Start of the range (RFC 3339, default one hour before until)"
+// @Param until query string false "End of the range, exclusive (RFC 3339, default now)"
+// @Param limit query int false "Maximum number of transitions (default 100, max 1000)"
+// @Success 200 {object} HistoryResponse "Status history"
+// @Failure 400 {object} ErrorResponse "Invalid time range"
+// @Failure 404 {object} ErrorResponse "Network not found or history disabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /networks/{network}/history [get]
+func (h *APIHandler) NetworkHistory(w http.ResponseWriter, r *http.Request) {
+	networkName := chi.URLParam(r, "network")
+
+	if _, err := h.app.GetNetwork(r.Context(), networkName); err != nil {
+		h.sendError(w, http.StatusNotFound, "Network not found",
+			fmt.Sprintf("Network '%s' does not exist", networkName))
+		return
+	}
+
+	h.sendHistory(w, r, history.Query{Network: networkName})
+}
+
+// SequencerHistory returns the status history of a sequencer
+// @Summary Get sequencer status history
+// @Description Get the leadership, activity, health and reachability transitions of a sequencer over a time range, oldest first, along with its last recorded state before the range
+// @Tags Sequencers
+// @Accept json
+// @Produce json
+// @Param id path string true "Sequencer ID"
+// @Param since query string false "Start of the range (RFC 3339, default one hour before until)"
+// @Param until query string false "End of the range, exclusive (RFC 3339, default now)"
+// @Param limit query int false "Maximum number of transitions (default 100, max 1000)"
+// @Success 200 {object} HistoryResponse "Status history"
+// @Failure 400 {object} ErrorResponse "Invalid time range"
+// @Failure 404 {object} ErrorResponse "Sequencer not found or history disabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /sequencers/{id}/history [get]
+func (h *APIHandler) SequencerHistory(w http.ResponseWriter, r *http.Request) {
+	sequencerID := chi.URLParam(r, "id")
+
+	_, net, err := h.getSequencer(r.Context(), sequencerID)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
+		return
+	}
+
+	h.sendHistory(w, r, history.Query{Network: net.Name(), Sequencer: sequencerID})
+}
+
+// sendHistory completes a history query from the request and sends the result
+func (h *APIHandler) sendHistory(w http.ResponseWriter, r *http.Request, query history.Query) {
+	if h.history == nil {
+		h.sendError(w, http.StatusNotFound, "History disabled",
+			"No history database is configured on this server")
+		return
+	}
+
+	if err := parseHistoryRange(r, &query); err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid time range", err.Error())
+		return
+	}
+
+	result, err := h.history.Query(query)
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "Failed to query history", err.Error())
+		return
+	}
+
+	resp := HistoryResponse{
+		Network:     query.Network,
+		Sequencer:   query.Sequencer,
+		Since:       query.Since,
+		Until:       query.Until,
+		Initial:     make([]HistorySnapshotResponse, 0, len(result.Initial)),
+		Transitions: make([]TransitionResponse, 0, len(result.Transitions)),
+		Total:       result.Total,
+	}
+	for _, snap := range result.Initial {
+		resp.Initial = append(resp.Initial, HistorySnapshotResponse{
+			Time:      snap.Time,
+			Sequencer: snap.Sequencer,
+			State:     HistoryStateResponse(snap.State),
+		})
+	}
+	for _, t := range result.Transitions {
+		resp.Transitions = append(resp.Transitions, TransitionResponse{
+			Time:      t.Time,
+			Sequencer: t.Sequencer,
+			Field:     t.Field,
+			From:      t.From,
+			To:        t.To,
+		})
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// parseHistoryRange reads the time range and limit from the query string
+func parseHistoryRange(r *http.Request, query *history.Query) error {
+	values := r.URL.Query()
+
+	query.Until = time.Now().UTC()
+	if value := values.Get("until"); value != "" {
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("until must be an RFC 3339 time: %w", err)
+		}
+		query.Until = until
+	}
+
+	query.Since = query.Until.Add(-defaultHistoryRange)
+	if value := values.Get("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("since must be an RFC 3339 time: %w", err)
+		}
+		query.Since = since
+	}
+
+	if !query.Since.Before(query.Until) {
+		return fmt.Errorf("since must be before until")
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return fmt.Errorf("limit must be a positive integer")
+		}
+		query.Limit = limit
+	}
+
+	return nil
+}
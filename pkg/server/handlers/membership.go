@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/membership"
+	"github.com/golem-base/seqctl/pkg/provider"
+)
+
+// MembershipResponse represents the Raft cluster membership of a network,
+// cross-referenced against its discovered sequencers
+type MembershipResponse struct {
+	Network    string           `json:"network"`
+	Version    uint64           `json:"version" example:"12"`
+	Source     string           `json:"source"`
+	FromLeader bool             `json:"from_leader"`
+	Members    []MemberResponse `json:"members"`
+	Missing    []string         `json:"missing"`
+}
+
+// MemberResponse represents a server in the Raft configuration. An unknown
+// member has no discovered sequencer with its ID.
+type MemberResponse struct {
+	ID       string `json:"id"`
+	Addr     string `json:"addr"`
+	Suffrage string `json:"suffrage" example:"voter"`
+	Leader   bool   `json:"leader"`
+	Unknown  bool   `json:"unknown"`
+}
+
+// Member suffrages in API responses
+const (
+	suffrageVoter    = "voter"
+	suffrageNonvoter = "nonvoter"
+)
+
+// SuffrageResponse is the outcome of promoting or demoting a member. Voting
+// tells how the provider's record of the sequencer's suffrage was brought in
+// line: unchanged, recorded, unsupported or failed.
+type SuffrageResponse struct {
+	Membership  MembershipResponse `json:"membership"`
+	Voting      string             `json:"voting" example:"recorded"`
+	VotingError string             `json:"voting_error,omitempty"`
+}
+
+// NetworkMembership returns the Raft cluster membership of a network
+// @Summary Get cluster membership
+// @Description Get the Raft configuration of a network from its conductor leader, or from any reachable sequencer if the leader does not answer.
+// @Description Members without a discovered sequencer are flagged unknown, discovered sequencers that are not members are listed as missing.
+// @Tags Networks
+// @Accept json
+// @Produce json
+// @Param network path string true "Network name"
+// @Success 200 {object} MembershipResponse "Cluster membership"
+// @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 502 {object} ErrorResponse "No conductor answered"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /networks/{network}/membership [get]
+func (h *APIHandler) NetworkMembership(w http.ResponseWriter, r *http.Request) {
+	networkName := chi.URLParam(r, "network")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	net, err := h.app.GetNetwork(ctx, networkName)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Network not found",
+			fmt.Sprintf("Network '%s' does not exist", networkName))
+		return
+	}
+
+	m, err := membership.Get(ctx, net)
+	if err != nil {
+		h.sendError(w, http.StatusBadGateway, "Membership unavailable", err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, NewMembershipResponse(m))
+}
+
+// PromoteMember makes a non-voting member a voter
+// @Summary Promote member to voter
+// @Description Make the sequencer a voting member of the Raft cluster through the conductor leader, without removing it from the cluster.
+// @Description The change is made against the current membership version and verified by reading the membership back.
+// @Description The provider's record of the sequencer's suffrage, such as its Kubernetes role label, is updated to match.
+// @Tags Actions
+// @Accept json
+// @Produce json
+// @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks"
+// @Success 200 {object} SuffrageResponse "Member promoted"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Sequencer not found"
+// @Failure 409 {object} ErrorResponse "No leader, not a member or already a voter"
+// @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /sequencers/{id}/membership/promote [post]
+func (h *APIHandler) PromoteMember(w http.ResponseWriter, r *http.Request) {
+	h.changeSuffrage(w, r, true)
+}
+
+// DemoteMember makes a voting member a non-voter
+// @Summary Demote member to non-voter
+// @Description Make the sequencer a non-voting member of the Raft cluster through the conductor leader.
+// @Description As the conductor API cannot demote, the member is removed and added back as a non-voter, each against the membership version the previous step left.
+// @Description Adding the member back is retried, and if it keeps failing the member is restored as a voter and the demotion fails.
+// @Description Demoting the leader, or a voter without which the remaining reachable voters lose quorum, is refused.
+// @Description The provider's record of the sequencer's suffrage, such as its Kubernetes role label, is updated to match.
+// @Tags Actions
+// @Accept json
+// @Produce json
+// @Param id path string true "Sequencer ID"
+// @Param dry_run query bool false "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks"
+// @Success 200 {object} SuffrageResponse "Member demoted"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Sequencer not found"
+// @Failure 409 {object} ErrorResponse "No leader, not a member, already a non-voter or quorum at risk"
+// @Failure 500 {object} ErrorResponse "Operation failed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /sequencers/{id}/membership/demote [post]
+func (h *APIHandler) DemoteMember(w http.ResponseWriter, r *http.Request) {
+	h.changeSuffrage(w, r, false)
+}
+
+// changeSuffrage promotes or demotes the sequencer and records its new
+// suffrage with the provider. A failure to record it does not fail the
+// request, the Raft change has been made.
+func (h *APIHandler) changeSuffrage(w http.ResponseWriter, r *http.Request, voter bool) {
+	dryRun, err := parseDryRun(r, false)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	seq, net, err := h.getSequencer(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Sequencer not found", err.Error())
+		return
+	}
+
+	change, preview, operation := action.DemoteMember, action.PreviewDemoteMember, "Failed to demote member"
+	if voter {
+		change, preview, operation = action.PromoteMember, action.PreviewPromoteMember, "Failed to promote member"
+	}
+
+	if dryRun {
+		p, err := preview(ctx, net, seq)
+		h.sendPreview(w, operation, p, err)
+		return
+	}
+
+	m, err := change(ctx, net, seq)
+	if err != nil {
+		h.sendActionError(w, operation, err)
+		return
+	}
+
+	resp := SuffrageResponse{Membership: NewMembershipResponse(m)}
+	resp.Voting, err = h.app.RecordVoting(ctx, seq, voter)
+	if err != nil {
+		h.logger.Warn("Failed to record sequencer suffrage",
+			slog.String("sequencer", seq.ID()),
+			slog.String("error", err.Error()))
+		resp.Voting, resp.VotingError = provider.VotingFailed, err.Error()
+	}
+
+	h.sendJSON(w, http.StatusOK, resp)
+}
+
+// NewMembershipResponse converts a cluster membership to its API form
+func NewMembershipResponse(m *membership.Membership) MembershipResponse {
+	resp := MembershipResponse{
+		Network:    m.Network,
+		Version:    m.Version,
+		Source:     m.Source,
+		FromLeader: m.FromLeader,
+		Members:    make([]MemberResponse, 0, len(m.Members)),
+		Missing:    make([]string, 0, len(m.Missing)),
+	}
+	for _, member := range m.Members {
+		suffrage := suffrageNonvoter
+		if member.Voter {
+			suffrage = suffrageVoter
+		}
+		resp.Members = append(resp.Members, MemberResponse{
+			ID:       member.ID,
+			Addr:     member.Addr,
+			Suffrage: suffrage,
+			Leader:   member.Leader,
+			Unknown:  member.Sequencer == nil,
+		})
+	}
+	for _, seq := range m.Missing {
+		resp.Missing = append(resp.Missing, seq.ID())
+	}
+	return resp
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/golem-base/seqctl/pkg/reconcile"
+)
+
+// applyTimeout bounds applying a membership plan, which makes one or more
+// Raft configuration changes in turn
+const applyTimeout = 60 * time.Second
+
+// MembershipPlanResponse lists the Raft membership changes that bring a
+// network in line with the suffrage of its discovered sequencers
+type MembershipPlanResponse struct {
+	Network    string             `json:"network"`
+	Version    uint64             `json:"version" example:"12"`
+	Source     string             `json:"source"`
+	FromLeader bool               `json:"from_leader"`
+	InSync     bool               `json:"in_sync"`
+	Steps      []PlanStepResponse `json:"steps"`
+}
+
+// PlanStepResponse represents one planned membership change. Status and
+// detail are set once the plan is applied.
+type PlanStepResponse struct {
+	Action string `json:"action" example:"add_voter"`
+	ID     string `json:"id"`
+	Addr   string `json:"addr"`
+	Reason string `json:"reason"`
+	Status string `json:"status,omitempty" example:"succeeded"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ApplyPlanRequest represents the plan a caller reviewed, as returned by
+// GET /membership/plan. It is required to apply a plan and optional for dry
+// runs.
+type ApplyPlanRequest struct {
+	// Membership version the caller reviewed the plan at, refused if the
+	// membership has changed since
+	Version uint64 `json:"version,omitempty" example:"12"`
+	// Changes the caller reviewed, refused unless the plan made now has
+	// exactly these
+	Steps []PlanStepRequest `json:"steps,omitempty"`
+}
+
+// PlanStepRequest identifies a reviewed membership change
+type PlanStepRequest struct {
+	Action string `json:"action" example:"add_voter"`
+	ID     string `json:"id"`
+	Addr   string `json:"addr"`
+}
+
+// NewApplyPlanRequest returns the request that applies a reviewed plan
+func NewApplyPlanRequest(plan MembershipPlanResponse) ApplyPlanRequest {
+	req := ApplyPlanRequest{
+		Version: plan.Version,
+		Steps:   make([]PlanStepRequest, 0, len(plan.Steps)),
+	}
+	for _, step := range plan.Steps {
+		req.Steps = append(req.Steps, PlanStepRequest{Action: step.Action, ID: step.ID, Addr: step.Addr})
+	}
+	return req
+}
+
+// ReviewedSteps returns the reviewed changes for reconcile.Plan.Check
+func (req ApplyPlanRequest) ReviewedSteps() []reconcile.Step {
+	steps := make([]reconcile.Step, 0, len(req.Steps))
+	for _, step := range req.Steps {
+		steps = append(steps, reconcile.Step{Action: step.Action, ID: step.ID, Addr: step.Addr})
+	}
+	return steps
+}
+
+// ApplyPlanResponse is the outcome of applying a membership plan
+type ApplyPlanResponse struct {
+	DryRun     bool                   `json:"dry_run"`
+	Plan       MembershipPlanResponse `json:"plan"`
+	Membership *MembershipResponse    `json:"membership,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// MembershipPlan returns the membership changes a network needs
+// @Summary Plan membership reconciliation
+// @Description Compare the Raft configuration of a network against the suffrage of its discovered sequencers, as given by the
+// @Description Kubernetes role label or the static voting setting, and list the changes that bring it in line:
+// @Description discovered sequencers that are not members are added, members with the wrong suffrage are promoted or demoted,
+// @Description and members matching no discovered sequencer are removed. Nothing is changed.
+// @Tags Networks
+// @Accept json
+// @Produce json
+// @Param network path string true "Network name"
+// @Success 200 {object} MembershipPlanResponse "Planned changes"
+// @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 502 {object} ErrorResponse "No conductor answered"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /networks/{network}/membership/plan [get]
+func (h *APIHandler) MembershipPlan(w http.ResponseWriter, r *http.Request) {
+	networkName := chi.URLParam(r, "network")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	net, err := h.app.GetNetwork(ctx, networkName)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Network not found",
+			fmt.Sprintf("Network '%s' does not exist", networkName))
+		return
+	}
+
+	plan, err := reconcile.NewPlan(ctx, net)
+	if err != nil {
+		h.sendError(w, http.StatusBadGateway, "Membership unavailable", err.Error())
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, NewPlanResponse(plan))
+}
+
+// ApplyMembershipPlan plans and applies the membership changes a network needs
+// @Summary Apply membership reconciliation
+// @Description Plan the membership changes of a network like GET /membership/plan and, only with dry_run=false, apply them in order
+// @Description through the conductor leader: additions and promotions first, then demotions and removals. Each change is checked and
+// @Description made against the current membership version like the matching manual action, and applying stops at the first failure.
+// @Description Applying requires the version and steps of the reviewed plan in the body, and is refused if the membership has changed
+// @Description since or the plan made now has other steps, e.g. because a sequencer is no longer discovered.
+// @Tags Actions
+// @Accept json
+// @Produce json
+// @Param network path string true "Network name"
+// @Param dry_run query bool false "Only plan, do not apply (default: true)"
+// @Param request body ApplyPlanRequest false "Reviewed plan, required unless dry_run is true"
+// @Success 200 {object} ApplyPlanResponse "Plan, and the resulting membership if applied"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 404 {object} ErrorResponse "Network not found"
+// @Failure 409 {object} ErrorResponse "No leader, or the membership or plan changed since it was reviewed"
+// @Failure 500 {object} ApplyPlanResponse "A change failed"
+// @Failure 502 {object} ErrorResponse "No conductor answered"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Security BearerAuth
+// @Router /networks/{network}/membership/apply [post]
+func (h *APIHandler) ApplyMembershipPlan(w http.ResponseWriter, r *http.Request) {
+	networkName := chi.URLParam(r, "network")
+
+	dryRun, err := parseDryRun(r, true)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query", err.Error())
+		return
+	}
+
+	var req ApplyPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+	if !dryRun && req.Version == 0 {
+		h.sendError(w, http.StatusBadRequest, "Invalid request body",
+			"the version and steps of the reviewed plan are required to apply it")
+		return
+	}
+
+	// Applying several changes outlives the server's default write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(applyTimeout + 10*time.Second)); err != nil {
+		h.logger.Warn("Failed to extend write deadline", "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), applyTimeout)
+	defer cancel()
+
+	net, err := h.app.GetNetwork(ctx, networkName)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, "Network not found",
+			fmt.Sprintf("Network '%s' does not exist", networkName))
+		return
+	}
+
+	plan, err := reconcile.NewPlan(ctx, net)
+	if err != nil {
+		h.sendError(w, http.StatusBadGateway, "Membership unavailable", err.Error())
+		return
+	}
+	switch {
+	case !dryRun:
+		err = plan.Check(req.Version, req.ReviewedSteps())
+	case req.Version != 0 && req.Version != plan.Version:
+		err = fmt.Errorf("%w: reviewed at version %d, now at %d", reconcile.ErrOutdated, req.Version, plan.Version)
+	}
+	if err != nil {
+		h.sendError(w, http.StatusConflict, "Invalid state", err.Error())
+		return
+	}
+	if dryRun || plan.InSync() {
+		h.sendJSON(w, http.StatusOK, ApplyPlanResponse{DryRun: dryRun, Plan: NewPlanResponse(plan)})
+		return
+	}
+
+	m, err := reconcile.Apply(ctx, net, plan)
+	switch {
+	case errors.Is(err, reconcile.ErrNoLeader), errors.Is(err, reconcile.ErrOutdated):
+		h.sendError(w, http.StatusConflict, "Invalid state", err.Error())
+		return
+	case err != nil:
+		h.sendJSON(w, http.StatusInternalServerError, ApplyPlanResponse{Plan: NewPlanResponse(plan), Error: err.Error()})
+		return
+	}
+
+	resp := NewMembershipResponse(m)
+	h.sendJSON(w, http.StatusOK, ApplyPlanResponse{Plan: NewPlanResponse(plan), Membership: &resp})
+}
+
+// NewPlanResponse converts a membership plan to its API form
+func NewPlanResponse(plan *reconcile.Plan) MembershipPlanResponse {
+	resp := MembershipPlanResponse{
+		Network:    plan.Network,
+		Version:    plan.Version,
+		Source:     plan.Source,
+		FromLeader: plan.FromLeader,
+		InSync:     plan.InSync(),
+		Steps:      make([]PlanStepResponse, 0, len(plan.Steps)),
+	}
+	for _, step := range plan.Steps {
+		resp.Steps = append(resp.Steps, PlanStepResponse(step))
+	}
+	return resp
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestApplyMembershipPlan_RequiresReviewedPlan(t *testing.T) {
+	h, devnet := newTestHandler(t, testAuthConfig())
+
+	r := chi.NewRouter()
+	r.Get("/networks/{network}/membership/plan", h.MembershipPlan)
+	r.Post("/networks/{network}/membership/apply", h.ApplyMembershipPlan)
+
+	// devnet has no members yet, the plan adds both sequencers
+	devnet.Lock()
+	devnet.Version = 12
+	devnet.Unlock()
+
+	rec := serve(r, http.MethodGet, "/networks/devnet/membership/plan", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Plan = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	reviewed := decode[MembershipPlanResponse](t, rec)
+	if len(reviewed.Steps) != 2 {
+		t.Fatalf("Plan steps = %+v, want both sequencers added", reviewed.Steps)
+	}
+
+	// Dry runs need no reviewed plan
+	if rec := serve(r, http.MethodPost, "/networks/devnet/membership/apply", "", nil); rec.Code != http.StatusOK {
+		t.Errorf("Dry run = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	apply := "/networks/devnet/membership/apply?dry_run=false"
+	if rec := serve(r, http.MethodPost, apply, "", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("Apply without a reviewed plan = %d, want 400", rec.Code)
+	}
+
+	// Only part of the plan was reviewed
+	partial := NewApplyPlanRequest(reviewed)
+	partial.Steps = partial.Steps[:1]
+	if rec := serve(r, http.MethodPost, apply, "", partial); rec.Code != http.StatusConflict {
+		t.Errorf("Apply with other steps = %d, want 409: %s", rec.Code, rec.Body.String())
+	}
+
+	outdated := NewApplyPlanRequest(reviewed)
+	outdated.Version++
+	if rec := serve(r, http.MethodPost, apply, "", outdated); rec.Code != http.StatusConflict {
+		t.Errorf("Apply at another version = %d, want 409: %s", rec.Code, rec.Body.String())
+	}
+
+	devnet.Lock()
+	defer devnet.Unlock()
+	if len(devnet.Servers) != 0 {
+		t.Errorf("Cluster members = %+v, want none after refused applies", devnet.Servers)
+	}
+}
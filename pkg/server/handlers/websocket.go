@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket connection tuning
+const (
+	// Time allowed to write a message to the peer
+	wsWriteWait = 10 * time.Second
+
+	// Maximum message size allowed from peer
+	wsMaxMessageSize = 4096
+
+	// Number of outbound messages buffered per client before it is considered slow
+	wsSendBufferSize = 64
+)
+
+// WebSocket keepalive, variables so that tests can shorten it
+var (
+	// Time allowed to read the next pong message from the peer
+	wsPongWait = 60 * time.Second
+
+	// Send pings to peer with this period, must be less than wsPongWait
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+// WebSocket message types sent to clients
+const (
+	WSMessageSnapshot = "snapshot"
+	WSMessageUpdate   = "update"
+	WSMessageError    = "error"
+)
+
+// WebSocket actions accepted from clients
+const (
+	WSActionSubscribe   = "subscribe"
+	WSActionUnsubscribe = "unsubscribe"
+)
+
+// WSMessage is a message pushed to WebSocket clients
+type WSMessage struct {
+	Type      string    `json:"type"`
+	Network   string    `json:"network,omitempty"`
+	Data      any       `json:"data,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WSRequest is a message received from WebSocket clients
+type WSRequest struct {
+	Action   string   `json:"action"`
+	Networks []string `json:"networks"`
+}
+
+// NetworkUpdate carries the sequencers whose status changed in a network
+type NetworkUpdate struct {
+	Healthy    bool                `json:"healthy"`
+	Violations []ViolationResponse `json:"violations"`
+	Sequencers []SequencerResponse `json:"sequencers"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// Hub fans out network status changes to subscribed WebSocket clients
+type Hub struct {
+	api    *APIHandler
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	clients map[*wsClient]struct{}
+}
+
+// wsClient is a single WebSocket connection and its subscriptions
+type wsClient struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	principal *auth.Principal
+	send      chan []byte
+
+	mu            sync.RWMutex
+	allNetworks   bool
+	subscriptions map[string]struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// newHub creates a hub bound to the given API handler
+func newHub(api *APIHandler) *Hub {
+	return &Hub{
+		api:     api,
+		logger:  api.logger.With(slog.String("subcomponent", "ws-hub")),
+		clients: make(map[*wsClient]struct{}),
+	}
+}
+
+// Run serves clients until ctx is cancelled and then disconnects them all.
+// Updates are driven by the repository's background poller through Publish.
+func (hub *Hub) Run(ctx context.Context) {
+	<-ctx.Done()
+	hub.closeAll()
+}
+
+// Publish sends the changed sequencers of a network to subscribed clients
+func (hub *Hub) Publish(net *network.Network, changes []network.StatusChange) {
+	sequencers := make([]SequencerResponse, 0, len(changes))
+	for _, change := range changes {
+		sequencers = append(sequencers, hub.api.sequencerToResponse(change.Sequencer, net))
+	}
+
+	violations := violationsToResponse(net.Invariants())
+	msg, err := encodeWSMessage(WSMessageUpdate, net.Name(), NetworkUpdate{
+		Healthy:    networkHealthy(net, violations),
+		Violations: violations,
+		Sequencers: sequencers,
+		UpdatedAt:  net.UpdatedAt(),
+	})
+	if err != nil {
+		hub.logger.Error("Failed to encode update", slog.String("error", err.Error()))
+		return
+	}
+
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	for client := range hub.clients {
+		if client.subscribed(net.Name()) {
+			client.enqueue(msg)
+		}
+	}
+}
+
+// register adds a client to the hub unless it has already disconnected
+func (hub *Hub) register(client *wsClient) {
+	hub.mu.Lock()
+	select {
+	case <-client.done:
+		hub.mu.Unlock()
+		return
+	default:
+	}
+	hub.clients[client] = struct{}{}
+	count := len(hub.clients)
+	hub.mu.Unlock()
+
+	hub.logger.Debug("WebSocket client connected", slog.Int("clients", count))
+}
+
+// unregister removes a client from the hub
+func (hub *Hub) unregister(client *wsClient) {
+	hub.mu.Lock()
+	delete(hub.clients, client)
+	count := len(hub.clients)
+	hub.mu.Unlock()
+
+	hub.logger.Debug("WebSocket client disconnected", slog.Int("clients", count))
+}
+
+// closeAll disconnects every client
+func (hub *Hub) closeAll() {
+	hub.mu.RLock()
+	clients := make([]*wsClient, 0, len(hub.clients))
+	for client := range hub.clients {
+		clients = append(clients, client)
+	}
+	hub.mu.RUnlock()
+
+	for _, client := range clients {
+		client.close()
+	}
+}
+
+// sendSnapshots sends full network snapshots to a client
+func (hub *Hub) sendSnapshots(ctx context.Context, client *wsClient, networkNames []string) {
+	if len(networkNames) == 0 {
+		networks, err := hub.api.app.ListNetworks(ctx)
+		if err != nil {
+			client.sendError("Failed to list networks: " + err.Error())
+			return
+		}
+		for _, net := range networks {
+			if hub.api.canView(client.principal, net.Name()) {
+				client.sendSnapshot(net)
+			}
+		}
+		return
+	}
+
+	for _, name := range networkNames {
+		if !hub.api.canView(client.principal, name) {
+			client.sendError("Network '" + name + "' does not exist")
+			continue
+		}
+
+		net, err := hub.api.app.GetNetwork(ctx, name)
+		if err != nil {
+			client.sendError("Network '" + name + "' does not exist")
+			continue
+		}
+		client.sendSnapshot(net)
+	}
+}
+
+// newWSClient creates a client subscribed to the given networks, or to all
+// networks when none are given
+func newWSClient(hub *Hub, conn *websocket.Conn, principal *auth.Principal, networkNames []string) *wsClient {
+	client := &wsClient{
+		hub:           hub,
+		conn:          conn,
+		principal:     principal,
+		send:          make(chan []byte, wsSendBufferSize),
+		subscriptions: make(map[string]struct{}),
+		done:          make(chan struct{}),
+	}
+	client.subscribe(networkNames)
+	return client
+}
+
+// subscribed reports whether the client wants, and may see, updates for a network
+func (c *wsClient) subscribed(networkName string) bool {
+	if !c.hub.api.canView(c.principal, networkName) {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.allNetworks {
+		return true
+	}
+	_, ok := c.subscriptions[networkName]
+	return ok
+}
+
+// subscribe adds network subscriptions, an empty list subscribes to all networks
+func (c *wsClient) subscribe(networkNames []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(networkNames) == 0 {
+		c.allNetworks = true
+		return
+	}
+	for _, name := range networkNames {
+		c.subscriptions[name] = struct{}{}
+	}
+}
+
+// unsubscribe removes network subscriptions, an empty list removes all of them
+func (c *wsClient) unsubscribe(networkNames []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(networkNames) == 0 {
+		c.allNetworks = false
+		c.subscriptions = make(map[string]struct{})
+		return
+	}
+	for _, name := range networkNames {
+		delete(c.subscriptions, name)
+	}
+}
+
+// enqueue queues a message without blocking, dropping the client if it cannot keep up
+func (c *wsClient) enqueue(msg []byte) {
+	select {
+	case <-c.done:
+	case c.send <- msg:
+	default:
+		c.hub.logger.Warn("WebSocket client too slow, disconnecting",
+			slog.String("remote", c.conn.RemoteAddr().String()))
+		c.close()
+	}
+}
+
+// sendSnapshot queues a full network snapshot
+func (c *wsClient) sendSnapshot(net *network.Network) {
+	msg, err := encodeWSMessage(WSMessageSnapshot, net.Name(), c.hub.api.networkToResponse(net))
+	if err != nil {
+		c.hub.logger.Error("Failed to encode snapshot", slog.String("error", err.Error()))
+		return
+	}
+	c.enqueue(msg)
+}
+
+// sendError queues an error message
+func (c *wsClient) sendError(detail string) {
+	msg, err := encodeWSMessage(WSMessageError, "", map[string]string{"detail": detail})
+	if err != nil {
+		return
+	}
+	c.enqueue(msg)
+}
+
+// close signals the write pump to terminate the connection
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// readPump processes client requests and pong messages until the connection fails
+func (c *wsClient) readPump() {
+	defer func() {
+		c.close()
+		c.hub.unregister(c)
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var req WSRequest
+		if err := c.conn.ReadJSON(&req); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.hub.logger.Debug("WebSocket read failed", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		switch req.Action {
+		case WSActionSubscribe:
+			c.subscribe(req.Networks)
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			c.hub.sendSnapshots(ctx, c, req.Networks)
+			cancel()
+		case WSActionUnsubscribe:
+			c.unsubscribe(req.Networks)
+		default:
+			c.sendError("Unknown action '" + req.Action + "'")
+		}
+	}
+}
+
+// writePump writes queued messages and periodic pings to the connection
+func (c *wsClient) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.close()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			c.conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// encodeWSMessage serializes a message for the wire
+func encodeWSMessage(msgType, networkName string, data any) ([]byte, error) {
+	return json.Marshal(WSMessage{
+		Type:      msgType,
+		Network:   networkName,
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+}
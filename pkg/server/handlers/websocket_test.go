@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// newWSServer serves the WebSocket route behind authentication, like the API
+func newWSServer(t *testing.T, h *APIHandler) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(h.Authenticate(h.Authorize(auth.RoleViewer)(http.HandlerFunc(h.WebSocket))))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dialWS connects to the WebSocket server with the given token and query
+func dialWS(t *testing.T, srv *httptest.Server, token, query string) *websocket.Conn {
+	t.Helper()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/"+query, header)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readWS reads the next message from the connection
+func readWS(t *testing.T, conn *websocket.Conn) WSMessage {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg WSMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	return msg
+}
+
+// clientCount returns the number of clients registered with the hub
+func clientCount(hub *Hub) int {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	return len(hub.clients)
+}
+
+// waitFor fails the test unless cond holds within a few seconds
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// publish reports every sequencer of a network as changed
+func publish(t *testing.T, h *APIHandler, networkName string) {
+	t.Helper()
+
+	net, err := h.app.GetNetwork(context.Background(), networkName)
+	if err != nil {
+		t.Fatalf("Failed to get network: %v", err)
+	}
+
+	changes := make([]network.StatusChange, 0, len(net.Sequencers()))
+	for _, seq := range net.Sequencers() {
+		changes = append(changes, network.StatusChange{Sequencer: seq, Current: seq.Status()})
+	}
+	h.hub.Publish(net, changes)
+}
+
+func TestHub_FiltersNetworksByRole(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	srv := newWSServer(t, h)
+
+	// The viewer may only see devnet, so subscribing to all networks
+	// yields its snapshot alone
+	conn := dialWS(t, srv, "viewer-token", "")
+	if msg := readWS(t, conn); msg.Type != WSMessageSnapshot || msg.Network != "devnet" {
+		t.Fatalf("First message = %s for %q, want devnet snapshot", msg.Type, msg.Network)
+	}
+	waitFor(t, func() bool { return clientCount(h.hub) == 1 }, "Client never registered")
+
+	// Updates are delivered in order, the testnet one must be skipped
+	publish(t, h, "testnet")
+	publish(t, h, "devnet")
+	msg := readWS(t, conn)
+	if msg.Type != WSMessageUpdate || msg.Network != "devnet" {
+		t.Fatalf("Next message = %s for %q, want devnet update", msg.Type, msg.Network)
+	}
+
+	var update NetworkUpdate
+	data, _ := json.Marshal(msg.Data)
+	if err := json.Unmarshal(data, &update); err != nil || len(update.Sequencers) != 2 {
+		t.Errorf("Update = %s (%v), want both devnet sequencers", data, err)
+	}
+
+	// Naming a hidden network explicitly does not reveal it either
+	if err := conn.WriteJSON(WSRequest{Action: WSActionSubscribe, Networks: []string{"testnet"}}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	if msg := readWS(t, conn); msg.Type != WSMessageError {
+		t.Errorf("Subscribing to testnet = %s for %q, want an error", msg.Type, msg.Network)
+	}
+}
+
+func TestHub_Subscriptions(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+	srv := newWSServer(t, h)
+
+	conn := dialWS(t, srv, "admin-token", "?network=devnet")
+	if msg := readWS(t, conn); msg.Type != WSMessageSnapshot || msg.Network != "devnet" {
+		t.Fatalf("First message = %s for %q, want devnet snapshot", msg.Type, msg.Network)
+	}
+	waitFor(t, func() bool { return clientCount(h.hub) == 1 }, "Client never registered")
+
+	if err := conn.WriteJSON(WSRequest{Action: WSActionUnsubscribe, Networks: []string{"devnet"}}); err != nil {
+		t.Fatalf("Failed to unsubscribe: %v", err)
+	}
+
+	// The unsubscribe is processed before the next request, whose error
+	// marks the point from which updates must stop
+	if err := conn.WriteJSON(WSRequest{Action: "bogus"}); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if msg := readWS(t, conn); msg.Type != WSMessageError {
+		t.Fatalf("Unknown action = %s, want an error", msg.Type)
+	}
+
+	publish(t, h, "devnet")
+	if err := conn.WriteJSON(WSRequest{Action: "bogus"}); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if msg := readWS(t, conn); msg.Type != WSMessageError {
+		t.Errorf("Message after unsubscribing = %s for %q, want no devnet update", msg.Type, msg.Network)
+	}
+}
+
+func TestHub_DropsSlowClient(t *testing.T) {
+	h, _ := newTestHandler(t, testAuthConfig())
+
+	// Hand the server side of a connection to the test instead of serving it
+	conns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := h.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	peer := dialWS(t, srv, "", "")
+	viewer := &auth.Principal{Name: "viewer", Method: auth.MethodToken}
+	client := newWSClient(h.hub, <-conns, viewer, nil)
+	h.hub.register(client)
+
+	// Nothing drains the send buffer, so the update past it drops the client
+	for range wsSendBufferSize {
+		publish(t, h, "devnet")
+	}
+	select {
+	case <-client.done:
+		t.Fatal("Client dropped before its buffer was full")
+	default:
+	}
+
+	publish(t, h, "devnet")
+	select {
+	case <-client.done:
+	default:
+		t.Fatal("Client kept after its buffer overflowed")
+	}
+
+	// The pumps close the connection and unregister the client
+	go client.writePump()
+	go client.readPump()
+	waitFor(t, func() bool { return clientCount(h.hub) == 0 }, "Slow client still registered")
+
+	peer.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := peer.ReadMessage(); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				t.Errorf("Connection ended with %v, want a normal close", err)
+			}
+			break
+		}
+	}
+}
+
+func TestHub_DropsClientWithoutPong(t *testing.T) {
+	pongWait, pingPeriod := wsPongWait, wsPingPeriod
+	wsPongWait, wsPingPeriod = 200*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { wsPongWait, wsPingPeriod = pongWait, pingPeriod })
+
+	h, _ := newTestHandler(t, testAuthConfig())
+	srv := newWSServer(t, h)
+
+	// Reading answers pings, a client that stops reading does not
+	responsive := dialWS(t, srv, "viewer-token", "")
+	readWS(t, responsive)
+	responsive.SetReadDeadline(time.Time{})
+	go func() {
+		for {
+			if _, _, err := responsive.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	silent := dialWS(t, srv, "viewer-token", "")
+	readWS(t, silent)
+
+	waitFor(t, func() bool { return clientCount(h.hub) == 2 }, "Clients never registered")
+	waitFor(t, func() bool { return clientCount(h.hub) == 1 }, "Client without pongs still registered")
+
+	// The responsive client outlives several pong deadlines
+	time.Sleep(3 * wsPongWait)
+	if n := clientCount(h.hub); n != 1 {
+		t.Errorf("%d clients registered, want the responsive one", n)
+	}
+}
@@ -12,7 +12,13 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/golem-base/seqctl/pkg/alert"
 	"github.com/golem-base/seqctl/pkg/app"
+	"github.com/golem-base/seqctl/pkg/approval"
+	"github.com/golem-base/seqctl/pkg/audit"
+	"github.com/golem-base/seqctl/pkg/auth"
+	"github.com/golem-base/seqctl/pkg/history"
+	"github.com/golem-base/seqctl/pkg/metrics"
 	"github.com/golem-base/seqctl/pkg/server/handlers"
 	slogchi "github.com/samber/slog-chi"
 )
@@ -46,16 +52,31 @@ func DefaultConfig() Config {
 type Server struct {
 	config     Config
 	app        *app.App
+	auth       *auth.Authenticator
+	audit      *audit.Store
+	history    *history.Store
+	alerts     *alert.Manager
+	approvals  *approval.Store
 	httpServer *http.Server
+	api        *handlers.APIHandler
+	metrics    *metrics.Metrics
 	logger     *slog.Logger
 }
 
-// NewServer creates a new server instance
-func NewServer(cfg Config, application *app.App) *Server {
+// NewServer creates a new server instance. The audit log and history store
+// may be nil to disable auditing and the history endpoints, the approval
+// store to run dangerous actions without a second operator.
+func NewServer(cfg Config, application *app.App, authenticator *auth.Authenticator, auditLog *audit.Store, historyStore *history.Store, alerts *alert.Manager, approvals *approval.Store) *Server {
 	return &Server{
-		config: cfg,
-		app:    application,
-		logger: slog.Default().With(slog.String("component", "server")),
+		config:    cfg,
+		app:       application,
+		auth:      authenticator,
+		audit:     auditLog,
+		history:   historyStore,
+		alerts:    alerts,
+		approvals: approvals,
+		metrics:   metrics.New(application),
+		logger:    slog.Default().With(slog.String("component", "server")),
 	}
 }
 
@@ -67,11 +88,17 @@ func (s *Server) setupRoutes() http.Handler {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 
+	// Take query string tokens out of the URL before it is logged
+	r.Use(handlers.QueryToken)
+
 	// Use slog for request logging
 	r.Use(slogchi.New(s.logger))
 
+	// Record request metrics
+	r.Use(s.metrics.Middleware)
+
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(skipForStreams(middleware.Timeout(60 * time.Second)))
 
 	// CORS middleware for API access
 	r.Use(func(next http.Handler) http.Handler {
@@ -90,7 +117,8 @@ func (s *Server) setupRoutes() http.Handler {
 	})
 
 	// Initialize handlers
-	apiHandler := handlers.NewAPIHandler(s.app, s.logger)
+	apiHandler := handlers.NewAPIHandler(s.app, s.auth, s.audit, s.history, s.alerts, s.approvals, s.logger)
+	s.api = apiHandler
 	swaggerHandler := handlers.NewSwaggerHandler(handlers.SwaggerConfig{
 		JSONPath: "/swagger/doc.json",
 		DocPath:  "./pkg/server/swagger/swagger.json",
@@ -106,33 +134,76 @@ func (s *Server) setupRoutes() http.Handler {
 		// Swagger endpoint
 		r.Get("/swagger/doc.json", swaggerHandler.Doc)
 
-		// Network endpoints
-		r.Get("/networks", apiHandler.ListNetworks)
-		r.Get("/networks/{network}", apiHandler.GetNetwork)
-		r.Get("/networks/{network}/sequencers", apiHandler.GetSequencers)
-
-		// Sequencer actions
-		r.Route("/sequencers/{id}", func(r chi.Router) {
-			r.Post("/pause", apiHandler.PauseSequencer)
-			r.Post("/resume", apiHandler.ResumeSequencer)
-			r.Post("/transfer-leader", apiHandler.TransferLeader)
-			r.Post("/resign-leader", apiHandler.ResignLeader)
-			r.Post("/override-leader", apiHandler.OverrideLeader)
-			r.Post("/halt", apiHandler.HaltSequencer)
-			r.Post("/force-active", apiHandler.ForceActive)
-			r.Delete("/membership", apiHandler.RemoveFromCluster)
-			r.Put("/membership", apiHandler.UpdateMembership)
+		// Everything else requires an authenticated caller
+		r.Group(func(r chi.Router) {
+			r.Use(apiHandler.Authenticate)
+
+			// Role required by each route, see auth.Role
+			viewer := apiHandler.Authorize(auth.RoleViewer)
+			operator := apiHandler.Authorize(auth.RoleOperator)
+			admin := apiHandler.Authorize(auth.RoleAdmin)
+
+			// Mutating routes are audited ahead of authorization so that
+			// denied attempts are recorded too
+			audited := apiHandler.Audit
+
+			// Network endpoints
+			r.With(viewer).Get("/health", apiHandler.NetworksHealth)
+			r.With(viewer).Get("/networks", apiHandler.ListNetworks)
+			r.With(viewer).Get("/networks/{network}", apiHandler.GetNetwork)
+			r.With(viewer).Get("/networks/{network}/sequencers", apiHandler.GetSequencers)
+			r.With(viewer).Get("/networks/{network}/history", apiHandler.NetworkHistory)
+			r.With(viewer).Get("/networks/{network}/membership", apiHandler.NetworkMembership)
+			r.With(viewer).Get("/networks/{network}/membership/plan", apiHandler.MembershipPlan)
+			r.With(audited("apply-membership"), admin).Post("/networks/{network}/membership/apply", apiHandler.ApplyMembershipPlan)
+			r.With(audited("handover"), operator).Post("/networks/{network}/handover", apiHandler.Handover)
+
+			// Sequencer actions
+			r.Route("/sequencers/{id}", func(r chi.Router) {
+				r.With(viewer).Get("/history", apiHandler.SequencerHistory)
+
+				r.With(audited("pause"), operator).Post("/pause", apiHandler.PauseSequencer)
+				r.With(audited("resume"), operator).Post("/resume", apiHandler.ResumeSequencer)
+				r.With(audited("transfer-leader"), operator).Post("/transfer-leader", apiHandler.TransferLeader)
+				r.With(audited("resign-leader"), operator).Post("/resign-leader", apiHandler.ResignLeader)
+				r.With(audited("override-leader"), admin).Post("/override-leader", apiHandler.OverrideLeader)
+				r.With(audited("halt"), admin).Post("/halt", apiHandler.HaltSequencer)
+				r.With(audited("force-active"), admin).Post("/force-active", apiHandler.ForceActive)
+				r.With(audited("remove-member"), admin).Delete("/membership", apiHandler.RemoveFromCluster)
+				r.With(audited("update-member"), admin).Put("/membership", apiHandler.UpdateMembership)
+				r.With(audited("promote-member"), admin).Post("/membership/promote", apiHandler.PromoteMember)
+				r.With(audited("demote-member"), admin).Post("/membership/demote", apiHandler.DemoteMember)
+			})
+
+			// Audit log
+			r.With(viewer).Get("/audit", apiHandler.AuditLog)
+
+			// Dangerous actions held for a second operator
+			r.With(viewer).Get("/approvals", apiHandler.Approvals)
+			r.With(viewer).Get("/approvals/{approval}", apiHandler.GetApproval)
+			r.With(audited("approve"), admin).Post("/approvals/{approval}", apiHandler.Approve)
+			r.With(audited("reject"), admin).Delete("/approvals/{approval}", apiHandler.Reject)
+
+			r.With(viewer).Get("/alerts", apiHandler.Alerts)
+			r.With(viewer).Get("/alerts/silences", apiHandler.Silences)
+			r.With(audited("silence"), operator).Post("/alerts/silences", apiHandler.CreateSilence)
+			r.With(audited("unsilence"), operator).Delete("/alerts/silences/{silence}", apiHandler.DeleteSilence)
+
+			r.With(viewer).Get("/events", apiHandler.Events)
+			r.With(viewer).Get(eventStreamPath, apiHandler.EventStream)
+
+			// WebSocket for real-time updates
+			r.With(viewer).Get("/ws", apiHandler.WebSocket)
 		})
-
-		// WebSocket for real-time updates
-		r.Get("/ws", apiHandler.WebSocket)
 	})
 
-	// Health check
-	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	// Health check, without per-network details since it is unauthenticated
+	r.Get("/health", apiHandler.Health)
+
+	// Prometheus metrics, which name every network and sequencer and so need
+	// the viewer role when authentication is enabled
+	r.With(apiHandler.Authenticate, apiHandler.Authorize(auth.RoleViewer)).
+		Method(http.MethodGet, "/metrics", s.metrics.Handler())
 
 	// Serve React app for all non-API routes
 	contentStatic, err := fs.Sub(content, "dist")
@@ -181,10 +252,32 @@ func (s *Server) setupRoutes() http.Handler {
 	return r
 }
 
+// eventStreamPath is the route of the server-sent event stream, relative to
+// /api/v1
+const eventStreamPath = "/events/stream"
+
+// skipForStreams applies a middleware to every request except the long-lived
+// event stream, which ends when the client disconnects
+func skipForStreams(mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1"+eventStreamPath {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
 // Start begins serving HTTP requests
 func (s *Server) Start(ctx context.Context) error {
 	router := s.setupRoutes()
 
+	// Run API background tasks (WebSocket hub) for the server lifetime
+	go s.api.Run(ctx)
+
 	s.httpServer = &http.Server{
 		Addr:           fmt.Sprintf("%s:%d", s.config.Address, s.config.Port),
 		Handler:        router,
@@ -209,6 +302,9 @@ func (s *Server) Start(ctx context.Context) error {
 	case <-ctx.Done():
 		s.logger.Info("Shutting down server...")
 
+		// Audit entries of the last requests are recorded in the background
+		defer s.api.WaitAudit()
+
 		// Graceful shutdown
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
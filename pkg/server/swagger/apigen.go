@@ -16,6 +16,11 @@ package swagger
 
 // @schemes http https
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description API token or OIDC JWT as "Bearer <token>". Required when auth is enabled.
+
 // @tag.name Networks
 // @tag.description Operations related to sequencer networks
 
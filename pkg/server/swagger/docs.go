@@ -22,9 +22,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/networks": {
+        "/alerts": {
             "get": {
-                "description": "Get a list of all sequencer networks in the environment",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the alerts whose condition currently holds, pending ones included. Alerts of networks the caller cannot view are omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,21 +37,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Networks"
+                    "Alerts"
                 ],
-                "summary": "List all networks",
+                "summary": "List alerts",
                 "responses": {
                     "200": {
-                        "description": "List of networks",
+                        "description": "Alerts",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.NetworkResponse"
-                            }
+                            "$ref": "#/definitions/handlers.AlertsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Alerting disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -54,9 +68,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/networks/{network}": {
+        "/alerts/silences": {
             "get": {
-                "description": "Get detailed information about a specific network",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the silences that have not expired, soonest to expire first",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,37 +83,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Networks"
-                ],
-                "summary": "Get network details",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Network name",
-                        "name": "network",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Alerts"
                 ],
+                "summary": "List silences",
                 "responses": {
                     "200": {
-                        "description": "Network details",
+                        "description": "Silences",
                         "schema": {
-                            "$ref": "#/definitions/handlers.NetworkResponse"
+                            "$ref": "#/definitions/handlers.SilencesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Network not found",
+                        "description": "Alerting disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/networks/{network}/sequencers": {
-            "get": {
-                "description": "Get all sequencers belonging to a specific network",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suppress notifications of alerts matching a rule, network and/or sequencer until the silence expires. Silencing a single network requires the operator role on it, otherwise on all networks.",
                 "consumes": [
                     "application/json"
                 ],
@@ -102,31 +127,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Networks",
-                    "Sequencers"
+                    "Alerts"
                 ],
-                "summary": "List network sequencers",
+                "summary": "Create a silence",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Network name",
-                        "name": "network",
-                        "in": "path",
-                        "required": true
+                        "description": "Silence",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SilenceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of sequencers",
+                    "201": {
+                        "description": "Silence created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.SequencerResponse"
-                            }
+                            "$ref": "#/definitions/handlers.SilenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Network not found",
+                        "description": "Alerting disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -134,9 +175,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/force-active": {
-            "post": {
-                "description": "Force a sequencer to become the active sequencer (WARNING: Use only in emergencies)",
+        "/alerts/silences/{silence}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a silence so that matching alerts are notified again",
                 "consumes": [
                     "application/json"
                 ],
@@ -144,47 +190,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Alerts"
                 ],
-                "summary": "Force sequencer active",
+                "summary": "Remove a silence",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
+                        "description": "Silence ID",
+                        "name": "silence",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Optional block hash to start from",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ForceActiveRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sequencer activated",
+                        "description": "Silence removed",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.SilenceResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sequencer already active",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Operation failed",
+                    "404": {
+                        "description": "Silence not found or alerting disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -192,9 +230,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/halt": {
-            "post": {
-                "description": "Stop a sequencer from processing transactions",
+        "/approvals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the dangerous actions waiting for a second operator, and those approved, rejected or expired within the last day, newest first.\nRequests on networks the caller cannot view are omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -202,39 +245,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Approvals"
                 ],
-                "summary": "Halt sequencer",
+                "summary": "List approval requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only requests with this status (pending, approved, executed, failed, rejected, expired)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sequencer halted",
+                        "description": "Approval requests",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.ApprovalsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sequencer already halted",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Operation failed",
+                    "404": {
+                        "description": "Approval disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -242,9 +284,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/membership": {
-            "put": {
-                "description": "Add a new server to the Raft cluster as either a voting or non-voting member",
+        "/approvals/{approval}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a dangerous action held for a second operator",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,62 +299,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Approvals"
                 ],
-                "summary": "Update cluster membership",
+                "summary": "Get approval request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID (must be leader)",
-                        "name": "id",
+                        "description": "Approval request ID",
+                        "name": "approval",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New member details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateMembershipRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Membership updated",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Approval request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ApprovalResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Validation failed",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Operation failed",
+                    "404": {
+                        "description": "Approval request not found or approval disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Remove a server from the Raft cluster membership",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a dangerous action held for a second operator and run it. The approver needs the admin role on the network\nand must not be the operator who requested the action. The action's checks are made again against the current\nstate, and the audit log records both operators.",
                 "consumes": [
                     "application/json"
                 ],
@@ -315,45 +352,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Approvals"
                 ],
-                "summary": "Remove server from cluster",
+                "summary": "Approve and run an action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID (must be leader)",
-                        "name": "id",
+                        "description": "Approval request ID",
+                        "name": "approval",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Server to remove",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.RemoveMemberRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Server removed successfully"
+                    "200": {
+                        "description": "Action approved and run",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApprovedActionResponse"
+                        }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, or the approver requested the action",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Sequencer not found",
+                        "description": "Approval request or sequencer not found, or approval disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Validation failed",
+                    "409": {
+                        "description": "Request no longer pending, or the action is refused in the current state",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -365,11 +402,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/sequencers/{id}/override-leader": {
-            "post": {
-                "description": "Force override the leader status of a sequencer (WARNING: Can cause split-brain)",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a dangerous action held for a second operator so that it can no longer be approved. The operator who requested it may\nwithdraw it this way too.",
                 "consumes": [
                     "application/json"
                 ],
@@ -377,48 +417,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Approvals"
                 ],
-                "summary": "Override leader status",
+                "summary": "Reject an action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
+                        "description": "Approval request ID",
+                        "name": "approval",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Override configuration",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.OverrideLeaderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Leader status overridden",
+                        "description": "Request rejected",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.ApprovalResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Sequencer not found",
+                        "description": "Approval request not found or approval disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Operation failed",
+                    "409": {
+                        "description": "Request no longer pending",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -426,9 +463,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/pause": {
-            "post": {
-                "description": "Pause the conductor service on a sequencer, stopping it from participating in consensus",
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recorded control actions, newest first. Entries for networks the caller cannot view are omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -436,39 +478,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Audit"
                 ],
-                "summary": "Pause conductor",
+                "summary": "Query the audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                        "description": "Principal that performed the action",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action name, e.g. pause or force-active",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "sequencer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result (success, failure, denied)",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this time (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Updated sequencer state",
+                        "description": "Audit entries",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.AuditLogResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "400": {
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conductor already paused",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Audit log disabled",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Operation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -476,9 +571,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/resign-leader": {
-            "post": {
-                "description": "Make the current leader sequencer resign, triggering a new leader election",
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recent events derived from sequencer status changes, newest first: leader changes, sequencers starting or stopping, conductor pauses, health changes, stalled unsafe heads and membership changes. Events of networks the caller cannot view are omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -486,39 +586,231 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Events"
                 ],
-                "summary": "Resign leadership",
+                "summary": "Query network events",
                 "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Event types, e.g. leader_changed (default: all)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Sequencer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "name": "sequencer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this time (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Leadership resignation accepted",
+                    "200": {
+                        "description": "Events",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.EventsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "400": {
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Sequencer is not the current leader",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream events as they are detected using server-sent events. Each event is sent with its ID, its type as the event name and an EventResponse as data.\nClients resuming with the Last-Event-ID header, or the after parameter, first receive the retained events they missed.\nBrowsers may pass the token in the access_token query parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream network events",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Event types, e.g. leader_changed (default: all)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "sequencer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID, overridden by the Last-Event-ID header",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the health and invariant violations of each network the caller may view.\nThe status covers these networks only; the unauthenticated /health covers all of them without details.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "Get network health",
+                "responses": {
+                    "200": {
+                        "description": "Network health",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NetworksHealthResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all sequencer networks in the environment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "List all networks",
+                "responses": {
+                    "200": {
+                        "description": "List of networks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.NetworkResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Operation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -526,9 +818,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/resume": {
-            "post": {
-                "description": "Resume the conductor service on a sequencer, allowing it to participate in consensus again",
+        "/networks/{network}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific network",
                 "consumes": [
                     "application/json"
                 ],
@@ -536,39 +833,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Actions"
+                    "Networks"
                 ],
-                "summary": "Resume conductor",
+                "summary": "Get network details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
+                        "description": "Network name",
+                        "name": "network",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated sequencer state",
+                        "description": "Network details",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SequencerResponse"
+                            "$ref": "#/definitions/handlers.NetworkResponse"
                         }
                     },
-                    "404": {
-                        "description": "Sequencer not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conductor already active",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Operation failed",
+                    "404": {
+                        "description": "Network not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -576,9 +873,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/sequencers/{id}/transfer-leader": {
+        "/networks/{network}/handover": {
             "post": {
-                "description": "Transfer Raft leadership from the current leader to a specified target sequencer",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hand leadership over to a healthy, caught-up voter. The target is picked automatically unless\ntarget_id is given. The call blocks until the target is conductor leader and active sequencer\nand its unsafe head advances; on timeout leadership is handed back to the original leader.",
                 "consumes": [
                     "application/json"
                 ],
@@ -588,31 +890,35 @@ const docTemplate = `{
                 "tags": [
                     "Actions"
                 ],
-                "summary": "Transfer leadership",
+                "summary": "Guided leader handover",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sequencer ID",
-                        "name": "id",
+                        "description": "Network name",
+                        "name": "network",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Transfer target details",
+                        "type": "boolean",
+                        "description": "Select the target and describe the transfer instead of making it. Read-only RPCs still probe every sequencer of the network to select the target",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Optional target and timeout",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.TransferLeaderRequest"
+                            "$ref": "#/definitions/handlers.HandoverRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Leadership transfer initiated",
+                    "200": {
+                        "description": "Handover completed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.HandoverResponse"
                         }
                     },
                     "400": {
@@ -621,81 +927,2264 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Sequencer not found",
+                        "description": "Network not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Cannot transfer from current leader",
+                        "description": "Network has no leader",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "422": {
-                        "description": "Validation failed",
+                        "description": "No eligible target",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Operation failed",
+                        "description": "Transfer failed",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.HandoverResponse"
                         }
-                    }
+                    },
+                    "504": {
+                        "description": "Handover timed out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HandoverResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks/{network}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leadership, activity, health and reachability transitions of a network's sequencers over a time range, oldest first, along with each sequencer's last recorded state before the range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "Get network status history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC 3339, default one hour before until)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive (RFC 3339, default now)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of transitions (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status history",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid time range",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Network not found or history disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks/{network}/membership": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the Raft configuration of a network from its conductor leader, or from any reachable sequencer if the leader does not answer.\nMembers without a discovered sequencer are flagged unknown, discovered sequencers that are not members are listed as missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "Get cluster membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cluster membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MembershipResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Network not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "No conductor answered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks/{network}/membership/apply": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plan the membership changes of a network like GET /membership/plan and, only with dry_run=false, apply them in order\nthrough the conductor leader: additions and promotions first, then demotions and removals. Each change is checked and\nmade against the current membership version like the matching manual action, and applying stops at the first failure.\nApplying requires the version and steps of the reviewed plan in the body, and is refused if the membership has changed\nsince or the plan made now has other steps, e.g. because a sequencer is no longer discovered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Apply membership reconciliation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only plan, do not apply (default: true)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Reviewed plan, required unless dry_run is true",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplyPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plan, and the resulting membership if applied",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplyPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Network not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No leader, or the membership or plan changed since it was reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "A change failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplyPlanResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "No conductor answered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks/{network}/membership/plan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the Raft configuration of a network against the suffrage of its discovered sequencers, as given by the\nKubernetes role label or the static voting setting, and list the changes that bring it in line:\ndiscovered sequencers that are not members are added, members with the wrong suffrage are promoted or demoted,\nand members matching no discovered sequencer are removed. Nothing is changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "Plan membership reconciliation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Planned changes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MembershipPlanResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Network not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "No conductor answered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks/{network}/sequencers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all sequencers belonging to a specific network",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Networks",
+                    "Sequencers"
+                ],
+                "summary": "List network sequencers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network name",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of sequencers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SequencerResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Network not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/force-active": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Force a sequencer to become the active sequencer (WARNING: Use only in emergencies)\nWhen two-person approval is enabled the activation is checked and held as a pending request instead, which a second\noperator runs through POST /approvals/{approval}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Force sequencer active",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Optional block hash to start from",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForceActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sequencer activated",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Held for approval",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PendingApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequencer already active",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/halt": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a sequencer from processing transactions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Halt sequencer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sequencer halted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequencer already halted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leadership, activity, health and reachability transitions of a sequencer over a time range, oldest first, along with its last recorded state before the range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sequencers"
+                ],
+                "summary": "Get sequencer status history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC 3339, default one hour before until)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive (RFC 3339, default now)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of transitions (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status history",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid time range",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found or history disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/membership": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new server to the Raft cluster as either a voting or non-voting member.\nThe change is made against the current membership version and fails if the membership changed concurrently.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Update cluster membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID (must be leader)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "New member details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMembershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New cluster membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MembershipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequencer is not the leader, or the server is already a member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a server from the Raft cluster membership. The change is made against the current membership version and fails if the membership changed concurrently.\nRemoving the conductor leader, or a voter without which the remaining reachable voters lose quorum, is refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Remove server from cluster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID (must be leader)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Server to remove",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RemoveMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New cluster membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MembershipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequencer is not the leader, or the removal would break quorum",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/membership/demote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make the sequencer a non-voting member of the Raft cluster through the conductor leader.\nAs the conductor API cannot demote, the member is removed and added back as a non-voter, each against the membership version the previous step left.\nAdding the member back is retried, and if it keeps failing the member is restored as a voter and the demotion fails.\nDemoting the leader, or a voter without which the remaining reachable voters lose quorum, is refused.\nThe provider's record of the sequencer's suffrage, such as its Kubernetes role label, is updated to match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Demote member to non-voter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member demoted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuffrageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No leader, not a member, already a non-voter or quorum at risk",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/membership/promote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make the sequencer a voting member of the Raft cluster through the conductor leader, without removing it from the cluster.\nThe change is made against the current membership version and verified by reading the membership back.\nThe provider's record of the sequencer's suffrage, such as its Kubernetes role label, is updated to match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Promote member to voter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them. Read-only RPCs still fetch the cluster membership from the leader for the checks",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member promoted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuffrageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No leader, not a member or already a voter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/override-leader": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Force override the leader status of a sequencer (WARNING: Can cause split-brain)\nWhen two-person approval is enabled the override is checked and held as a pending request instead, which a second operator\nruns through POST /approvals/{approval}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Override leader status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Override configuration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OverrideLeaderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leader status overridden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Held for approval",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PendingApprovalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pause the conductor service on a sequencer, stopping it from participating in consensus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Pause conductor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated sequencer state",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conductor already paused",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/resign-leader": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make the current leader sequencer resign, triggering a new leader election",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Resign leadership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Leadership resignation accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequencer is not the current leader",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resume the conductor service on a sequencer, allowing it to participate in consensus again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Resume conductor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated sequencer state",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SequencerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conductor already active",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sequencers/{id}/transfer-leader": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transfer Raft leadership from the current leader to a specified target sequencer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actions"
+                ],
+                "summary": "Transfer leadership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sequencer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the action and describe the RPCs it would send instead of sending them",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Transfer target details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferLeaderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Leadership transfer initiated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sequencer not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot transfer from current leader",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket that streams network snapshots and sequencer status changes.\nClients may send {\"action\":\"subscribe\",\"networks\":[...]} or {\"action\":\"unsubscribe\",\"networks\":[...]};\nan empty network list means all networks.",
+                "tags": [
+                    "Networks"
+                ],
+                "summary": "Subscribe to real-time updates",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Networks to subscribe to initially (default: all)",
+                        "name": "network",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WSMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AlertResponse": {
+            "type": "object",
+            "properties": {
+                "active_since": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "no_leader"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "fired_at": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "critical"
+                },
+                "silenced": {
+                    "type": "boolean"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "firing"
+                },
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AlertsResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AlertResponse"
+                    }
+                }
+            }
+        },
+        "handlers.ApplyPlanRequest": {
+            "type": "object",
+            "properties": {
+                "steps": {
+                    "description": "Changes the caller reviewed, refused unless the plan made now has\nexactly these",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.PlanStepRequest"
+                    }
+                },
+                "version": {
+                    "description": "Membership version the caller reviewed the plan at, refused if the\nmembership has changed since",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.ApplyPlanResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "membership": {
+                    "$ref": "#/definitions/handlers.MembershipResponse"
+                },
+                "plan": {
+                    "$ref": "#/definitions/handlers.MembershipPlanResponse"
+                }
+            }
+        },
+        "handlers.ApprovalLinks": {
+            "type": "object",
+            "properties": {
+                "approve": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "network": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "reject": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "self": {
+                    "$ref": "#/definitions/handlers.Link"
+                }
+            }
+        },
+        "handlers.ApprovalResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/handlers.ApprovalLinks"
+                },
+                "action": {
+                    "type": "string",
+                    "example": "force-active"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "approved_by_method": {
+                    "type": "string",
+                    "example": "token"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "effect": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "rejected_by": {
+                    "type": "string"
+                },
+                "rejected_by_method": {
+                    "type": "string"
+                },
+                "request": {
+                    "type": "object"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "requested_by_method": {
+                    "type": "string",
+                    "example": "oidc"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "handlers.ApprovalsResponse": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ApprovalResponse"
+                    }
+                }
+            }
+        },
+        "handlers.ApprovedActionResponse": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/handlers.ApprovalResponse"
+                },
+                "sequencer": {
+                    "$ref": "#/definitions/handlers.SequencerResponse"
+                }
+            }
+        },
+        "handlers.AuditApprovalResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "force-active"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "approved_by_method": {
+                    "type": "string",
+                    "example": "token"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rejected_by": {
+                    "type": "string"
+                },
+                "rejected_by_method": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "requested_by_method": {
+                    "type": "string",
+                    "example": "oidc"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "executed"
+                }
+            }
+        },
+        "handlers.AuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "pause"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.AuditStatusResponse"
+                    }
+                },
+                "approval": {
+                    "$ref": "#/definitions/handlers.AuditApprovalResponse"
+                },
+                "auth_method": {
+                    "type": "string",
+                    "example": "token"
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.AuditStatusResponse"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "request": {
+                    "type": "object"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AuditEntryResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AuditStatusResponse": {
+            "type": "object",
+            "properties": {
+                "conductor_active": {
+                    "type": "boolean"
+                },
+                "conductor_leader": {
+                    "type": "boolean"
+                },
+                "conductor_paused": {
+                    "type": "boolean"
+                },
+                "conductor_stopped": {
+                    "type": "boolean"
+                },
+                "sequencer_active": {
+                    "type": "boolean"
+                },
+                "sequencer_healthy": {
+                    "type": "boolean"
+                },
+                "unsafe_l2": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BlockIDResponse": {
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "type": "string",
+                    "example": "0x9a8b7c6d5e4f..."
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 512
+                }
+            }
+        },
+        "handlers.BlockRefResponse": {
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "type": "string",
+                    "example": "0x2c3b5d2e1f0a..."
+                },
+                "l1_origin": {
+                    "$ref": "#/definitions/handlers.BlockIDResponse"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1718000000
+                }
+            }
+        },
+        "handlers.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "succeeded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EventResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "leader_changed"
+                }
+            }
+        },
+        "handlers.EventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.EventResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ForceActiveRequest": {
+            "type": "object",
+            "properties": {
+                "block_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.HandoverRequest": {
+            "type": "object",
+            "properties": {
+                "target_id": {
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.HandoverResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/handlers.NetworkLinks"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "rolled_back": {
+                    "type": "boolean"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.HandoverStep"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "unsafe_l2_after": {
+                    "type": "integer"
+                },
+                "unsafe_l2_before": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.HandoverStep": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.HeadResponse": {
+            "type": "object",
+            "properties": {
+                "block_time_seconds": {
+                    "type": "number",
+                    "example": 2
+                },
+                "blocks_per_second": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "lag_blocks": {
+                    "type": "integer"
+                },
+                "lagging": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 120345
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "stall_threshold_seconds": {
+                    "type": "number",
+                    "example": 20
+                },
+                "stalled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.HistoryResponse": {
+            "type": "object",
+            "properties": {
+                "initial": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.HistorySnapshotResponse"
+                    }
+                },
+                "network": {
+                    "type": "string"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TransitionResponse"
+                    }
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.HistorySnapshotResponse": {
+            "type": "object",
+            "properties": {
+                "sequencer": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/handlers.HistoryStateResponse"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.HistoryStateResponse": {
+            "type": "object",
+            "properties": {
+                "conductor_active": {
+                    "type": "boolean"
+                },
+                "conductor_leader": {
+                    "type": "boolean"
+                },
+                "conductor_paused": {
+                    "type": "boolean"
+                },
+                "conductor_stopped": {
+                    "type": "boolean"
+                },
+                "reachability": {
+                    "type": "string",
+                    "example": "reachable"
+                },
+                "safe_l2": {
+                    "type": "integer"
+                },
+                "sequencer_active": {
+                    "type": "boolean"
+                },
+                "sequencer_healthy": {
+                    "type": "boolean"
+                },
+                "unsafe_l2": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.ErrorResponse": {
+        },
+        "handlers.LagResponse": {
             "type": "object",
             "properties": {
-                "detail": {
+                "l1_derivation_blocks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "l1_origin_age_seconds": {
+                    "type": "number",
+                    "example": 14.5
+                },
+                "safe_finalized_blocks": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "unsafe_safe_blocks": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.Link": {
+            "type": "object",
+            "properties": {
+                "href": {
                     "type": "string"
                 },
-                "errors": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "type": "string"
                 },
-                "instance": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
+                "leader": {
+                    "type": "boolean"
                 },
-                "title": {
+                "suffrage": {
+                    "type": "string",
+                    "example": "voter"
+                },
+                "unknown": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.MembershipPlanResponse": {
+            "type": "object",
+            "properties": {
+                "from_leader": {
+                    "type": "boolean"
+                },
+                "in_sync": {
+                    "type": "boolean"
+                },
+                "network": {
                     "type": "string"
                 },
-                "type": {
+                "source": {
                     "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.PlanStepResponse"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
-        "handlers.ForceActiveRequest": {
+        "handlers.MembershipResponse": {
             "type": "object",
             "properties": {
-                "block_hash": {
+                "from_leader": {
+                    "type": "boolean"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MemberResponse"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "network": {
+                    "type": "string"
+                },
+                "source": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
-        "handlers.Link": {
+        "handlers.NetworkHealthResponse": {
             "type": "object",
             "properties": {
-                "href": {
-                    "type": "string"
+                "healthy": {
+                    "type": "boolean"
                 },
-                "method": {
+                "name": {
                     "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ViolationResponse"
+                    }
                 }
             }
         },
         "handlers.NetworkLinks": {
             "type": "object",
             "properties": {
+                "apply_membership": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "handover": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "membership": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
+                "membership_plan": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
                 "self": {
                     "$ref": "#/definitions/handlers.Link"
                 },
@@ -727,6 +3216,30 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ViolationResponse"
+                    }
+                }
+            }
+        },
+        "handlers.NetworksHealthResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.NetworkHealthResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
                 }
             }
         },
@@ -738,6 +3251,54 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.PendingApprovalResponse": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/handlers.ApprovalResponse"
+                }
+            }
+        },
+        "handlers.PlanStepRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "add_voter"
+                },
+                "addr": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PlanStepResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "add_voter"
+                },
+                "addr": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                }
+            }
+        },
         "handlers.RemoveMemberRequest": {
             "type": "object",
             "required": [
@@ -752,6 +3313,9 @@ const docTemplate = `{
         "handlers.SequencerLinks": {
             "type": "object",
             "properties": {
+                "demote": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
                 "force_active": {
                     "$ref": "#/definitions/handlers.Link"
                 },
@@ -767,6 +3331,9 @@ const docTemplate = `{
                 "pause": {
                     "$ref": "#/definitions/handlers.Link"
                 },
+                "promote": {
+                    "$ref": "#/definitions/handlers.Link"
+                },
                 "remove_member": {
                     "$ref": "#/definitions/handlers.Link"
                 },
@@ -793,6 +3360,12 @@ const docTemplate = `{
                 "_links": {
                     "$ref": "#/definitions/handlers.SequencerLinks"
                 },
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.CheckResponse"
+                    }
+                },
                 "conductor_active": {
                     "type": "boolean"
                 },
@@ -805,21 +3378,34 @@ const docTemplate = `{
                 "conductor_stopped": {
                     "type": "boolean"
                 },
+                "head": {
+                    "$ref": "#/definitions/handlers.HeadResponse"
+                },
                 "id": {
                     "type": "string"
                 },
+                "lag": {
+                    "$ref": "#/definitions/handlers.LagResponse"
+                },
                 "network_id": {
                     "type": "string"
                 },
                 "raft_addr": {
                     "type": "string"
                 },
+                "reachability": {
+                    "type": "string",
+                    "example": "degraded"
+                },
                 "sequencer_active": {
                     "type": "boolean"
                 },
                 "sequencer_healthy": {
                     "type": "boolean"
                 },
+                "sync_status": {
+                    "$ref": "#/definitions/handlers.SyncStatusResponse"
+                },
                 "unsafe_l2": {
                     "type": "integer"
                 },
@@ -831,6 +3417,111 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.SilenceRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "2h"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SilenceResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SilencesResponse": {
+            "type": "object",
+            "properties": {
+                "silences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SilenceResponse"
+                    }
+                }
+            }
+        },
+        "handlers.SuffrageResponse": {
+            "type": "object",
+            "properties": {
+                "membership": {
+                    "$ref": "#/definitions/handlers.MembershipResponse"
+                },
+                "voting": {
+                    "type": "string",
+                    "example": "recorded"
+                },
+                "voting_error": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SyncStatusResponse": {
+            "type": "object",
+            "properties": {
+                "current_l1": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "finalized_l1": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "finalized_l2": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "head_l1": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "safe_l1": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "safe_l2": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                },
+                "unsafe_l2": {
+                    "$ref": "#/definitions/handlers.BlockRefResponse"
+                }
+            }
+        },
         "handlers.TransferLeaderRequest": {
             "type": "object",
             "required": [
@@ -846,6 +3537,29 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.TransitionResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "conductor_leader"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "false"
+                },
+                "sequencer": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "true"
+                }
+            }
+        },
         "handlers.UpdateMembershipRequest": {
             "type": "object",
             "required": [
@@ -863,6 +3577,51 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "handlers.ViolationResponse": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "example": "multiple_leaders"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "sequencers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "critical"
+                }
+            }
+        },
+        "handlers.WSMessage": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "network": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "API token or OIDC JWT as \"Bearer \u003ctoken\u003e\". Required when auth is enabled.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "tags": [
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/golem-base/seqctl/pkg/action"
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+// Keys understood by the UI, after escape sequences have been decoded
+const (
+	keyUp     = "up"
+	keyDown   = "down"
+	keyEscape = "esc"
+	keyCtrlC  = "ctrl+c"
+)
+
+// networkState is a network as shown on screen
+type networkState struct {
+	net        *network.Network
+	name       string
+	healthy    bool
+	violations []network.Violation
+	sequencers []sequencerState
+}
+
+// sequencerState is a sequencer as shown on screen
+type sequencerState struct {
+	seq       *sequencer.Sequencer
+	id        string
+	status    sequencer.Status
+	voting    bool
+	head      network.HeadStats
+	headKnown bool
+}
+
+// request is an action waiting for the operator's confirmation
+type request struct {
+	label  string // Shown while running and in the result, e.g. "pause seq-0"
+	prompt string
+	net    *network.Network
+	run    func(ctx context.Context) error
+}
+
+// model holds the screen state. It knows nothing about the terminal, so key
+// handling and rendering can be tested on their own.
+type model struct {
+	networks []networkState
+	selected int // Index into the sequencers of all networks, in display order
+
+	pending *request // Awaiting confirmation
+	running string   // Label of the action in flight, if any
+	message string
+	failed  bool
+}
+
+func newModel() *model {
+	return &model{}
+}
+
+// update replaces the displayed networks with a fresh snapshot
+func (m *model) update(networks map[string]*network.Network) {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m.networks = m.networks[:0]
+	for _, name := range names {
+		net := networks[name]
+		state := networkState{
+			net:        net,
+			name:       name,
+			healthy:    net.IsHealthy(),
+			violations: net.Invariants(),
+		}
+		for _, v := range state.violations {
+			if v.Severity == network.SeverityCritical {
+				state.healthy = false
+			}
+		}
+
+		for _, seq := range net.Sequencers() {
+			head, headKnown := net.HeadStats(seq.ID())
+			state.sequencers = append(state.sequencers, sequencerState{
+				seq:       seq,
+				id:        seq.ID(),
+				status:    seq.Status(),
+				voting:    seq.Voting(),
+				head:      head,
+				headKnown: headKnown,
+			})
+		}
+		m.networks = append(m.networks, state)
+	}
+
+	m.selected = min(m.selected, max(m.count()-1, 0))
+}
+
+// count returns the number of sequencers on screen
+func (m *model) count() int {
+	n := 0
+	for _, net := range m.networks {
+		n += len(net.sequencers)
+	}
+	return n
+}
+
+// current returns the selected sequencer and its network
+func (m *model) current() (*networkState, *sequencerState) {
+	i := m.selected
+	for n := range m.networks {
+		net := &m.networks[n]
+		if i < len(net.sequencers) {
+			return net, &net.sequencers[i]
+		}
+		i -= len(net.sequencers)
+	}
+	return nil, nil
+}
+
+// handleKey applies a key press. It returns the request to run once the
+// operator confirmed it, and whether the UI should exit.
+func (m *model) handleKey(key string) (confirmed *request, quit bool) {
+	if m.pending != nil {
+		switch key {
+		case "y", "Y":
+			confirmed, m.pending = m.pending, nil
+			return confirmed, false
+		case "n", "N", keyEscape, "q":
+			m.pending = nil
+			m.message, m.failed = "Cancelled", false
+		case keyCtrlC:
+			return nil, true
+		}
+		return nil, false
+	}
+
+	switch key {
+	case "q", keyCtrlC:
+		return nil, true
+	case "j", keyDown:
+		m.selected = min(m.selected+1, max(m.count()-1, 0))
+	case "k", keyUp:
+		m.selected = max(m.selected-1, 0)
+	case "p", "r", "t", "l":
+		if m.running != "" {
+			m.message, m.failed = fmt.Sprintf("Still running %s", m.running), true
+			return nil, false
+		}
+		net, seq := m.current()
+		if seq == nil {
+			return nil, false
+		}
+		m.pending = newRequest(key, net, seq)
+		m.message = ""
+	}
+	return nil, false
+}
+
+// newRequest builds the request behind an action key for the selected
+// sequencer. The actions check the sequencer's state themselves when run.
+func newRequest(key string, net *networkState, seq *sequencerState) *request {
+	s := seq.seq
+	switch key {
+	case "p":
+		return &request{
+			label:  "pause " + seq.id,
+			prompt: fmt.Sprintf("Pause the conductor of %s?", seq.id),
+			net:    net.net,
+			run:    func(ctx context.Context) error { return action.Pause(ctx, s) },
+		}
+	case "r":
+		return &request{
+			label:  "resume " + seq.id,
+			prompt: fmt.Sprintf("Resume the conductor of %s?", seq.id),
+			net:    net.net,
+			run:    func(ctx context.Context) error { return action.Resume(ctx, s) },
+		}
+	case "t":
+		n := net.net
+		return &request{
+			label:  "transfer to " + seq.id,
+			prompt: fmt.Sprintf("Transfer leadership of %s to %s?", net.name, seq.id),
+			net:    n,
+			run:    func(ctx context.Context) error { return action.TransferLeaderTo(ctx, n, s) },
+		}
+	default:
+		return &request{
+			label:  "resign " + seq.id,
+			prompt: fmt.Sprintf("Make %s resign leadership of %s?", seq.id, net.name),
+			net:    net.net,
+			run:    func(ctx context.Context) error { return action.ResignLeader(ctx, s) },
+		}
+	}
+}
+
+// finish records the outcome of a request
+func (m *model) finish(label string, err error) {
+	m.running = ""
+	if err != nil {
+		m.message, m.failed = fmt.Sprintf("%s failed: %v", label, err), true
+		return
+	}
+	m.message, m.failed = fmt.Sprintf("%s succeeded", label), false
+}
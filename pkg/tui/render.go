@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golem-base/seqctl/pkg/network"
+)
+
+// ANSI escape sequences
+const (
+	reset  = "\x1b[0m"
+	bold   = "\x1b[1m"
+	dim    = "\x1b[2m"
+	invert = "\x1b[7m"
+	red    = "\x1b[31m"
+	green  = "\x1b[32m"
+	yellow = "\x1b[33m"
+	cyan   = "\x1b[36m"
+
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+const helpLine = "↑/↓ select  p pause  r resume  t transfer here  l resign  q quit"
+
+// cell is a table cell. Colors are applied after padding so escape codes do
+// not throw off the alignment.
+type cell struct {
+	text  string
+	color string
+}
+
+// render draws the whole screen
+func render(m *model) string {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+
+	fmt.Fprintf(&b, "%sseqctl%s  %s%s%s\r\n\r\n", bold, reset, dim, helpLine, reset)
+
+	if len(m.networks) == 0 {
+		b.WriteString("No networks discovered yet\r\n")
+	}
+
+	idWidth := len("SEQUENCER")
+	for _, net := range m.networks {
+		for _, seq := range net.sequencers {
+			idWidth = max(idWidth, utf8.RuneCountInString(seq.id))
+		}
+	}
+	widths := []int{idWidth, 8, 8, 9, 8, 9, 12, 12}
+	header := []string{"SEQUENCER", "LEADER", "ACTIVE", "CONDUCTOR", "HEALTHY", "VOTING", "UNSAFE L2", "PROGRESS"}
+
+	index := 0
+	for _, net := range m.networks {
+		health := cell{"healthy", green}
+		if !net.healthy {
+			health = cell{"UNHEALTHY", red}
+		}
+		fmt.Fprintf(&b, "%s%s%s  %s%s%s\r\n", bold, net.name, reset, health.color, health.text, reset)
+		for _, v := range net.violations {
+			color := yellow
+			if v.Severity == network.SeverityCritical {
+				color = red
+			}
+			fmt.Fprintf(&b, "  %s%s: %s%s\r\n", color, v.Kind, v.Message, reset)
+		}
+
+		b.WriteString("  ")
+		for i, h := range header {
+			b.WriteString(dim + pad(h, widths[i]) + reset + "  ")
+		}
+		b.WriteString("\r\n")
+
+		for _, seq := range net.sequencers {
+			marker := "  "
+			if index == m.selected {
+				marker = invert + ">" + reset + " "
+			}
+			b.WriteString(marker)
+			for i, c := range sequencerCells(seq) {
+				text := pad(c.text, widths[i])
+				if c.color != "" {
+					text = c.color + text + reset
+				}
+				b.WriteString(text + "  ")
+			}
+			b.WriteString("\r\n")
+			index++
+		}
+		b.WriteString("\r\n")
+	}
+
+	switch {
+	case m.pending != nil:
+		fmt.Fprintf(&b, "%s%s [y/N]%s", yellow+bold, m.pending.prompt, reset)
+	case m.running != "":
+		fmt.Fprintf(&b, "%sRunning %s...%s", cyan, m.running, reset)
+	case m.message != "":
+		color := green
+		if m.failed {
+			color = red
+		}
+		fmt.Fprintf(&b, "%s%s%s", color, m.message, reset)
+	}
+	b.WriteString("\r\n")
+
+	return b.String()
+}
+
+// sequencerCells returns the colored cells of a sequencer row
+func sequencerCells(seq sequencerState) []cell {
+	s := seq.status
+
+	leader := cell{"follower", dim}
+	if s.ConductorLeader {
+		leader = cell{"leader", green + bold}
+	}
+
+	active := cell{"standby", dim}
+	if s.SequencerActive {
+		active = cell{"active", green + bold}
+	}
+
+	conductor := cell{"inactive", dim}
+	switch {
+	case s.ConductorStopped:
+		conductor = cell{"stopped", red}
+	case s.ConductorPaused:
+		conductor = cell{"paused", yellow}
+	case s.ConductorActive:
+		conductor = cell{"active", green}
+	}
+
+	healthy := cell{"no", red}
+	if s.SequencerHealthy {
+		healthy = cell{"yes", green}
+	}
+
+	voting := cell{"non-voter", dim}
+	if seq.voting {
+		voting = cell{"voter", ""}
+	}
+
+	head := cell{"-", dim}
+	rate := cell{"-", dim}
+	if s.UnsafeL2 != nil {
+		head = cell{strconv.FormatUint(s.UnsafeL2.Number, 10), ""}
+	}
+	if seq.headKnown {
+		switch {
+		case s.SequencerActive && seq.head.Stalled:
+			rate = cell{"stalled", red + bold}
+		case seq.head.Lagging:
+			rate = cell{fmt.Sprintf("%d behind", seq.head.Lag), yellow}
+		case seq.head.RateWindow > 0:
+			rate = cell{fmt.Sprintf("%.1f blk/s", seq.head.Rate), ""}
+		}
+	}
+
+	return []cell{{seq.id, bold}, leader, active, conductor, healthy, voting, head, rate}
+}
+
+// pad right-pads text with spaces to width runes
+func pad(text string, width int) string {
+	if n := utf8.RuneCountInString(text); n < width {
+		return text + strings.Repeat(" ", width-n)
+	}
+	return text
+}
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/golem-base/seqctl/pkg/app"
+)
+
+// Default UI settings
+const (
+	DefaultRefreshInterval = time.Second
+	actionTimeout          = 30 * time.Second
+)
+
+// Terminal control sequences
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// UI is a full-screen terminal interface showing the live status of every
+// network and letting an operator run control actions
+type UI struct {
+	app      *app.App
+	in       *os.File
+	out      io.Writer
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// New creates a UI reading keys from in and drawing to out. in must be a
+// terminal.
+func New(application *app.App, in *os.File, out io.Writer) *UI {
+	return &UI{
+		app:      application,
+		in:       in,
+		out:      out,
+		interval: DefaultRefreshInterval,
+		logger:   slog.Default().With(slog.String("component", "tui")),
+	}
+}
+
+// actionResult is the outcome of a confirmed request
+type actionResult struct {
+	label string
+	err   error
+}
+
+// Run draws the UI until the operator quits or the context is cancelled
+func (u *UI) Run(ctx context.Context) error {
+	fd := int(u.in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the terminal UI needs an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	fmt.Fprint(u.out, enterAltScreen)
+	defer fmt.Fprint(u.out, leaveAltScreen)
+
+	keys := make(chan string)
+	go readKeys(u.in, keys)
+
+	results := make(chan actionResult, 1)
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	m := newModel()
+	u.refresh(ctx, m)
+
+	for {
+		fmt.Fprint(u.out, render(m))
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			u.refresh(ctx, m)
+
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			req, quit := m.handleKey(key)
+			if quit {
+				return nil
+			}
+			if req != nil {
+				m.running = req.label
+				go u.run(ctx, req, results)
+			}
+
+		case result := <-results:
+			m.finish(result.label, result.err)
+			u.refresh(ctx, m)
+		}
+	}
+}
+
+// refresh loads the latest network snapshot into the model
+func (u *UI) refresh(ctx context.Context, m *model) {
+	networks, err := u.app.ListNetworks(ctx)
+	if err != nil {
+		u.logger.Warn("Failed to list networks", "error", err)
+		return
+	}
+	m.update(networks)
+}
+
+// run executes a confirmed request. The network is refreshed first so the
+// action's state checks see current values, and again afterwards so the
+// result shows up without waiting for the poller.
+func (u *UI) run(ctx context.Context, req *request, results chan<- actionResult) {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	if err := req.net.Update(ctx); err != nil {
+		u.logger.Warn("Failed to refresh network before action", "network", req.net.Name(), "error", err)
+	}
+
+	err := req.run(ctx)
+	if err == nil {
+		if uerr := req.net.Update(ctx); uerr != nil {
+			u.logger.Warn("Failed to refresh network after action", "network", req.net.Name(), "error", uerr)
+		}
+	}
+
+	u.logger.Info("Action finished", "action", req.label, "error", err)
+	results <- actionResult{label: req.label, err: err}
+}
+
+// readKeys decodes key presses from the terminal until it is closed
+func readKeys(in io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 16)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// decodeKeys splits raw terminal input into keys, translating the escape
+// sequences of the arrow keys
+func decodeKeys(input []byte) []string {
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		switch b := input[i]; {
+		case b == 0x03:
+			keys = append(keys, keyCtrlC)
+		case b == 0x1b && i+2 < len(input) && input[i+1] == '[':
+			switch input[i+2] {
+			case 'A':
+				keys = append(keys, keyUp)
+			case 'B':
+				keys = append(keys, keyDown)
+			}
+			i += 2
+		case b == 0x1b:
+			keys = append(keys, keyEscape)
+		default:
+			keys = append(keys, string(b))
+		}
+	}
+	return keys
+}
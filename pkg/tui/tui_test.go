@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golem-base/seqctl/pkg/network"
+	"github.com/golem-base/seqctl/pkg/sequencer"
+)
+
+func testNetworks(t *testing.T) map[string]*network.Network {
+	t.Helper()
+
+	newSequencer := func(id string) *sequencer.Sequencer {
+		seq, err := sequencer.New(context.Background(), sequencer.Config{
+			ID:           id,
+			ConductorURL: "http://127.0.0.1:1",
+			NodeURL:      "http://127.0.0.1:1",
+			Voting:       true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create sequencer: %v", err)
+		}
+		return seq
+	}
+
+	return map[string]*network.Network{
+		"testnet": network.NewNetwork("testnet", []*sequencer.Sequencer{newSequencer("test-0")}),
+		"devnet":  network.NewNetwork("devnet", []*sequencer.Sequencer{newSequencer("dev-0"), newSequencer("dev-1")}),
+	}
+}
+
+func TestModel_SelectAndConfirm(t *testing.T) {
+	m := newModel()
+	m.update(testNetworks(t))
+
+	// Networks are sorted, so the third row is the first testnet sequencer
+	for _, key := range []string{"j", keyDown, keyDown, "k"} {
+		m.handleKey(key)
+	}
+	if _, seq := m.current(); seq == nil || seq.id != "dev-1" {
+		t.Fatalf("Expected dev-1 to be selected, got %+v", seq)
+	}
+
+	if req, _ := m.handleKey("p"); req != nil {
+		t.Fatal("Expected the action to wait for confirmation")
+	}
+	if m.pending == nil || !strings.Contains(render(m), "Pause the conductor of dev-1? [y/N]") {
+		t.Fatal("Expected a confirmation prompt for dev-1")
+	}
+
+	if req, _ := m.handleKey("n"); req != nil || m.pending != nil {
+		t.Fatal("Expected the action to be cancelled")
+	}
+
+	m.handleKey("r")
+	req, quit := m.handleKey("y")
+	if req == nil || quit {
+		t.Fatal("Expected the confirmed request")
+	}
+	if req.label != "resume dev-1" || req.net.Name() != "devnet" {
+		t.Errorf("Unexpected request %q on %s", req.label, req.net.Name())
+	}
+
+	if _, quit := m.handleKey("q"); !quit {
+		t.Error("Expected q to quit")
+	}
+}
+
+func TestModel_SelectionFollowsShrinkingNetworks(t *testing.T) {
+	m := newModel()
+	networks := testNetworks(t)
+	m.update(networks)
+	m.selected = 2
+
+	delete(networks, "testnet")
+	m.update(networks)
+
+	if m.selected != 1 {
+		t.Errorf("Expected the selection to move to the last row, got %d", m.selected)
+	}
+}
+
+func TestSequencerCells_Head(t *testing.T) {
+	tests := []struct {
+		name   string
+		active bool
+		head   network.HeadStats
+		want   string
+	}{
+		{"rate", true, network.HeadStats{Rate: 0.5, RateWindow: time.Minute}, "0.5 blk/s"},
+		{"stalled", true, network.HeadStats{Stalled: true, RateWindow: time.Minute}, "stalled"},
+		{"stalled follower", false, network.HeadStats{Stalled: true}, "-"},
+		{"lagging", false, network.HeadStats{Lag: 42, Lagging: true, RateWindow: time.Minute}, "42 behind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := sequencerState{
+				id:        "dev-0",
+				status:    sequencer.Status{SequencerActive: tt.active},
+				head:      tt.head,
+				headKnown: true,
+			}
+			cells := sequencerCells(seq)
+			if got := cells[len(cells)-1].text; got != tt.want {
+				t.Errorf("Expected rate %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("j\x1b[A\x1b[Bq\x03\x1b"))
+	want := []string{"j", keyUp, keyDown, "q", keyCtrlC, keyEscape}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}